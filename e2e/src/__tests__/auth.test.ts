@@ -8,7 +8,7 @@ describe('Auth Service E2E Tests', () => {
     // Create a test user for authentication tests
     testUser = await TestHelper.createTestUser({
       email: TestHelper.generateRandomEmail(),
-      password: 'TestPassword123!',
+      password: 'Clave-Segura1!',
       role: 'cliente',
       first_name: 'Auth Test',
       last_name: 'User'
@@ -19,8 +19,8 @@ describe('Auth Service E2E Tests', () => {
     test('should create new user successfully', async () => {
       const userData = {
         email: TestHelper.generateRandomEmail(),
-        password: 'Test123!',
-        confirm_password: 'Test123!',
+        password: 'Clave-Segura1!',
+        confirm_password: 'Clave-Segura1!',
         first_name: 'Test',
         last_name: 'User',
         role: 'cliente'
@@ -171,7 +171,7 @@ describe('Auth Service E2E Tests', () => {
       
       const authClient = TestHelper.createAuthenticatedClient(userTokens.accessToken);
       
-      const newPassword = 'NewPassword123!';
+      const newPassword = 'Nueva-Clave3!';
       const response = await authClient.patch(`/auth/usuarios/${userTokens.user.id}`, {
         current_password: testUser.password,
         new_password: newPassword
@@ -64,8 +64,8 @@ describe('Smoke Tests - Basic Service Health', () => {
     test('should be able to create new user (public endpoint)', async () => {
       const testUser = {
         email: TestHelper.generateRandomEmail(),
-        password: 'Test123!',
-        confirm_password: 'Test123!',
+        password: 'Clave-Segura1!',
+        confirm_password: 'Clave-Segura1!',
         first_name: 'Test',
         last_name: 'User',
         role: 'cliente'
@@ -15,7 +15,7 @@ describe('Users Service E2E Tests', () => {
     // Create a test user
     testUser = await TestHelper.createTestUser({
       email: TestHelper.generateRandomEmail(),
-      password: 'UsersTestPassword123!',
+      password: 'Clave-Segura1!',
       role: 'cliente',
       first_name: 'Users Test',
       last_name: 'User'
@@ -32,8 +32,8 @@ describe('Users Service E2E Tests', () => {
     test('should create a new user (admin only)', async () => {
       const userData = {
         email: TestHelper.generateRandomEmail(),
-        password: 'NewUser123!',
-        confirm_password: 'NewUser123!',
+        password: 'Nueva-Cuenta2!',
+        confirm_password: 'Nueva-Cuenta2!',
         first_name: 'Admin Created',
         last_name: 'User',
         role: 'cliente'
@@ -133,8 +133,8 @@ describe('Users Service E2E Tests', () => {
     test('should prevent regular users from creating users', async () => {
       const userData = {
         email: TestHelper.generateRandomEmail(),
-        password: 'NewUser123!',
-        confirm_password: 'NewUser123!',
+        password: 'Nueva-Cuenta2!',
+        confirm_password: 'Nueva-Cuenta2!',
         first_name: 'Unauthorized',
         last_name: 'User',
         role: 'cliente'
@@ -34,7 +34,8 @@ export interface AuthTokens {
 export class TestHelper {
   private static baseUrl = process.env.BASE_URL || 'https://localhost';
   private static apiUrl = process.env.API_BASE_URL || 'http://localhost:8080';
-  private static authUrl = process.env.AUTH_SERVICE_URL || 'http://localhost:8001';
+  // Auth no publica su puerto: los endpoints /auth/* se usan a través del gateway
+  private static authUrl = process.env.AUTH_SERVICE_URL || 'http://localhost:8080';
   
   private static httpClient: AxiosInstance;
 
@@ -56,7 +57,6 @@ export class TestHelper {
     const services = [
       { name: 'Nginx Load Balancer', url: `${this.baseUrl}/health` },
       { name: 'API Gateway', url: `${this.apiUrl}/health` },
-      { name: 'Auth Service', url: `${this.authUrl}/health` },
     ];
 
     const maxRetries = parseInt(process.env.HEALTH_CHECK_RETRIES || '10');
@@ -114,7 +114,7 @@ export class TestHelper {
   static async createTestUser(userData?: Partial<TestUser>): Promise<TestUser> {
     const user: TestUser = {
       email: `test-${uuidv4()}@streamflow.com`,
-      password: 'Test123!',
+      password: 'Clave-Segura1!',
       role: 'cliente',
       first_name: 'Test',
       last_name: 'User',
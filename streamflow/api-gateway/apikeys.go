@@ -0,0 +1,146 @@
+package main
+
+import (
+    "bytes"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net/http"
+    "strconv"
+    "sync"
+    "time"
+)
+
+// Las API keys personales se resuelven contra el servicio de autenticación
+// (POST /auth/api-keys/introspect) y el resultado se cachea. Una key revocada
+// puede seguir siendo aceptada hasta apiKeyCacheTTL.
+const (
+    apiKeyCacheTTL         = time.Minute
+    apiKeyNegativeCacheTTL = 10 * time.Second
+    apiKeyCacheMaxEntries  = 10000
+)
+
+var (
+    errInvalidAPIKey   = errors.New("API key inválida")
+    errAuthUnavailable = errors.New("servicio de autenticación no disponible")
+)
+
+type introspectionCacheEntry struct {
+    user    *UserContext // nil si la key o el token no es válido
+    expires time.Time
+}
+
+// introspectionCache guarda keys y tokens por su hash, nunca en claro
+type introspectionCache struct {
+    mu      sync.Mutex
+    entries map[string]introspectionCacheEntry
+}
+
+func newIntrospectionCache() *introspectionCache {
+    return &introspectionCache{entries: make(map[string]introspectionCacheEntry)}
+}
+
+func (c *introspectionCache) get(hash string) (introspectionCacheEntry, bool) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    entry, ok := c.entries[hash]
+    if !ok || time.Now().After(entry.expires) {
+        return introspectionCacheEntry{}, false
+    }
+    return entry, true
+}
+
+func (c *introspectionCache) set(hash string, entry introspectionCacheEntry) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    // Limpiar entradas vencidas antes de crecer sin límite
+    if len(c.entries) >= apiKeyCacheMaxEntries {
+        now := time.Now()
+        for key, existing := range c.entries {
+            if now.After(existing.expires) {
+                delete(c.entries, key)
+            }
+        }
+    }
+    if len(c.entries) < apiKeyCacheMaxEntries {
+        c.entries[hash] = entry
+    }
+}
+
+type introspectAPIKeyResponse struct {
+    Active    bool     `json:"active"`
+    KeyID     int      `json:"key_id"`
+    UserID    int      `json:"user_id"`
+    Email     string   `json:"email"`
+    Role      string   `json:"role"`
+    Scopes    []string `json:"scopes"`
+    ExpiresAt int64    `json:"exp"`
+}
+
+// ValidateAPIKey resuelve una API key al mismo UserContext que un JWT de usuario,
+// limitado a los scopes de la key
+func (a *AuthService) ValidateAPIKey(key string) (*UserContext, error) {
+    sum := sha256.Sum256([]byte(key))
+    hash := hex.EncodeToString(sum[:])
+
+    if entry, ok := a.apiKeys.get(hash); ok {
+        if entry.user == nil {
+            return nil, errInvalidAPIKey
+        }
+        return entry.user, nil
+    }
+
+    body, err := json.Marshal(map[string]string{"key": key})
+    if err != nil {
+        return nil, err
+    }
+
+    req, err := http.NewRequest(http.MethodPost, a.BaseURL+"/auth/api-keys/introspect", bytes.NewReader(body))
+    if err != nil {
+        return nil, err
+    }
+    req.Header.Set("Content-Type", "application/json")
+    // Auth solo responde la introspección a quien presenta el secreto compartido
+    req.Header.Set("X-Service-Secret", a.introspectionSecret)
+
+    resp, err := a.httpClient.Do(req)
+    if err != nil {
+        return nil, fmt.Errorf("%w: %v", errAuthUnavailable, err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("%w: introspección respondió %d", errAuthUnavailable, resp.StatusCode)
+    }
+
+    var result introspectAPIKeyResponse
+    if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+        return nil, fmt.Errorf("%w: respuesta inválida: %v", errAuthUnavailable, err)
+    }
+
+    if !result.Active {
+        a.apiKeys.set(hash, introspectionCacheEntry{expires: time.Now().Add(apiKeyNegativeCacheTTL)})
+        return nil, errInvalidAPIKey
+    }
+
+    user := &UserContext{
+        ID:          strconv.Itoa(result.UserID),
+        Email:       result.Email,
+        Role:        result.Role,
+        Permissions: result.Scopes,
+        APIKeyID:    result.KeyID,
+    }
+
+    // No cachear más allá de la expiración de la propia key
+    expires := time.Now().Add(apiKeyCacheTTL)
+    if keyExpires := time.Unix(result.ExpiresAt, 0); keyExpires.Before(expires) {
+        expires = keyExpires
+    }
+    a.apiKeys.set(hash, introspectionCacheEntry{user: user, expires: expires})
+
+    return user, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+    "context"
+    "log"
+    "net/http"
+    "os"
+    "strconv"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/credentials/insecure"
+    "api-gateway/pb"
+)
+
+// Las suplantaciones (tokens con claim "act") quedan auditadas en el servicio
+// de monitoreo con la identidad real del administrador. El inicio lo registra
+// auth (IMPERSONATION_STARTED, en su outbox junto con la suplantación); el
+// gateway registra cada petición hecha con el token.
+const auditTimeout = 3 * time.Second
+
+// gRPC client para monitoreo
+func getMonitoringClient() (pb.MonitoringServiceClient, *grpc.ClientConn, error) {
+    monitoringServiceURL := os.Getenv("MONITORING_SERVICE_URL")
+    if monitoringServiceURL == "" {
+        monitoringServiceURL = "localhost:50054"
+    }
+
+    conn, err := grpc.Dial(monitoringServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+    if err != nil {
+        return nil, nil, err
+    }
+
+    client := pb.NewMonitoringServiceClient(conn)
+    return client, conn, nil
+}
+
+func logAuditAction(request *pb.LogActionRequest) error {
+    client, conn, err := getMonitoringClient()
+    if err != nil {
+        return err
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), auditTimeout)
+    defer cancel()
+
+    _, err = client.LogAction(ctx, request)
+    return err
+}
+
+func parseAuditID(id string) int64 {
+    value, _ := strconv.ParseInt(id, 10, 64)
+    return value
+}
+
+// auditImpersonation registra cada petición hecha con un token de suplantación
+// antes de atenderla; si no se puede auditar, la petición se rechaza.
+func auditImpersonation() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        if user, ok := currentUser(c); ok && user.IsImpersonation() {
+            err := logAuditAction(&pb.LogActionRequest{
+                Service:     "api-gateway",
+                Action:      "impersonated_request",
+                UserId:      parseAuditID(user.ID),
+                UserEmail:   user.Email,
+                UrlMethod:   c.Request.Method,
+                Url:         c.Request.URL.Path,
+                ActorUserId: parseAuditID(user.ActorID),
+                ActorEmail:  user.ActorEmail,
+            })
+            if err != nil {
+                log.Printf("Error auditando suplantación de %s por %s: %v", user.ID, user.ActorID, err)
+                c.JSON(http.StatusServiceUnavailable, gin.H{"error": "No se pudo registrar la auditoría de la suplantación"})
+                c.Abort()
+                return
+            }
+        }
+        c.Next()
+    }
+}
+
+// denyImpersonation bloquea acciones sensibles para tokens de suplantación
+func denyImpersonation() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        if user, ok := currentUser(c); ok && user.IsImpersonation() {
+            c.JSON(http.StatusForbidden, gin.H{"error": "Acción no permitida durante una suplantación"})
+            c.Abort()
+            return
+        }
+        c.Next()
+    }
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+    "net/http"
+    "os"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/credentials/insecure"
+    "google.golang.org/grpc/metadata"
+    "api-gateway/pb"
+)
+
+// gRPC client para facturación
+func getBillingClient() (pb.BillingServiceClient, *grpc.ClientConn, error) {
+    billingServiceURL := os.Getenv("BILLING_SERVICE_URL")
+    if billingServiceURL == "" {
+        billingServiceURL = "localhost:50052"
+    }
+
+    conn, err := grpc.Dial(billingServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+    if err != nil {
+        return nil, nil, err
+    }
+
+    client := pb.NewBillingServiceClient(conn)
+    return client, conn, nil
+}
+
+// parseInvoiceStatus convierte "Pendiente", "Pagado" o "Vencido" al enum
+func parseInvoiceStatus(value string) (pb.InvoiceStatus, bool) {
+    status, ok := pb.InvoiceStatus_value[strings.ToUpper(strings.TrimSpace(value))]
+    if !ok || status == int32(pb.InvoiceStatus_INVOICE_STATUS_UNSPECIFIED) {
+        return pb.InvoiceStatus_INVOICE_STATUS_UNSPECIFIED, false
+    }
+    return pb.InvoiceStatus(status), true
+}
+
+func parseInvoiceID(c *gin.Context) (int64, bool) {
+    id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+    if err != nil || id <= 0 {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "ID de factura inválido"})
+        return 0, false
+    }
+    return id, true
+}
+
+func createInvoice(c *gin.Context) {
+    client, conn, err := getBillingClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de facturación: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    var requestBody struct {
+        UserID int64  `json:"user_id"`
+        Amount int64  `json:"amount"` // en centavos
+        Status string `json:"status"`
+    }
+    if err := c.ShouldBindJSON(&requestBody); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+        return
+    }
+
+    request := &pb.CreateInvoiceRequest{UserId: requestBody.UserID, Amount: requestBody.Amount}
+    if requestBody.Status != "" {
+        status, ok := parseInvoiceStatus(requestBody.Status)
+        if !ok {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Estado inválido: debe ser Pendiente, Pagado o Vencido"})
+            return
+        }
+        request.Status = status
+    }
+
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+
+    response, err := client.CreateInvoice(ctx, request)
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error creando factura: " + err.Error()})
+        return
+    }
+
+    setETag(c, response.Invoice.GetVersion())
+    c.JSON(http.StatusCreated, response.Invoice)
+}
+
+func getInvoice(c *gin.Context) {
+    client, conn, err := getBillingClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de facturación: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    id, ok := parseInvoiceID(c)
+    if !ok {
+        return
+    }
+
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+
+    response, err := client.GetInvoiceById(ctx, &pb.GetInvoiceByIdRequest{Id: id})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error obteniendo factura: " + err.Error()})
+        return
+    }
+
+    setETag(c, response.Invoice.GetVersion())
+    c.JSON(http.StatusOK, response.Invoice)
+}
+
+// updateInvoice cambia el estado de la factura; requiere If-Match
+func updateInvoice(c *gin.Context) {
+    client, conn, err := getBillingClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de facturación: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    id, ok := parseInvoiceID(c)
+    if !ok {
+        return
+    }
+
+    expectedVersion, ok := requireIfMatch(c)
+    if !ok {
+        return
+    }
+
+    var requestBody struct {
+        Status string `json:"status"`
+    }
+    if err := c.ShouldBindJSON(&requestBody); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+        return
+    }
+    status, ok := parseInvoiceStatus(requestBody.Status)
+    if !ok {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Estado inválido: debe ser Pendiente, Pagado o Vencido"})
+        return
+    }
+
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+
+    response, err := client.UpdateInvoiceState(ctx, &pb.UpdateInvoiceStateRequest{
+        Id:              id,
+        NewStatus:       status,
+        ExpectedVersion: expectedVersion,
+    })
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error actualizando factura: " + err.Error()})
+        return
+    }
+
+    setETag(c, response.Invoice.GetVersion())
+    c.JSON(http.StatusOK, response.Invoice)
+}
+
+func deleteInvoice(c *gin.Context) {
+    client, conn, err := getBillingClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de facturación: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    id, ok := parseInvoiceID(c)
+    if !ok {
+        return
+    }
+
+    expectedVersion, ok := requireIfMatch(c)
+    if !ok {
+        return
+    }
+
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+
+    _, err = client.DeleteInvoice(ctx, &pb.DeleteInvoiceRequest{Id: id, ExpectedVersion: expectedVersion})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error eliminando factura: " + err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Factura eliminada exitosamente"})
+}
+
+// listInvoices lista las facturas propias; con invoices:read_all,
+// ?user_id= lista las de otro usuario
+func listInvoices(c *gin.Context) {
+    client, conn, err := getBillingClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de facturación: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    request := &pb.ListInvoicesByUserRequest{}
+    if value := c.Query("status"); value != "" {
+        status, ok := parseInvoiceStatus(value)
+        if !ok {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Estado inválido: debe ser Pendiente, Pagado o Vencido"})
+            return
+        }
+        request.StatusFilter = &status
+    }
+
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+    if target := c.Query("user_id"); target != "" {
+        ctx = metadata.AppendToOutgoingContext(ctx, "target_user_id", target)
+    }
+
+    response, err := client.ListInvoicesByUser(ctx, request)
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error listando facturas: " + err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+    "net/http"
+    "strconv"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+)
+
+// Usuarios, videos y facturas tienen un campo version que se incrementa en
+// cada cambio. Los GET lo devuelven como ETag y los PATCH/DELETE exigen el
+// mismo valor en If-Match; si el recurso cambió mientras tanto, el servicio
+// responde codes.Aborted y el gateway 412 Precondition Failed.
+
+// setETag publica la versión del recurso como ETag
+func setETag(c *gin.Context, version int64) {
+    if version > 0 {
+        c.Header("ETag", `"`+strconv.FormatInt(version, 10)+`"`)
+    }
+}
+
+// requireIfMatch lee la versión esperada del header If-Match. Si falta, es
+// "*" (que se saltearía la comprobación) o es inválido responde y devuelve
+// false.
+func requireIfMatch(c *gin.Context) (int64, bool) {
+    value := strings.TrimSpace(c.GetHeader("If-Match"))
+    if value == "" || value == "*" {
+        c.JSON(http.StatusPreconditionRequired, gin.H{"error": "Se requiere el header If-Match con el ETag obtenido en el GET"})
+        return 0, false
+    }
+
+    value = strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+    version, err := strconv.ParseInt(value, 10, 64)
+    if err != nil || version <= 0 {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "If-Match inválido: debe ser el ETag del recurso"})
+        return 0, false
+    }
+    return version, true
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/gin-gonic/gin"
+)
+
+func TestRequireIfMatch(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+
+    tests := []struct {
+        header  string
+        status  int // 0 si el header se acepta
+        version int64
+    }{
+        {header: `"3"`, version: 3},
+        {header: `W/"7"`, version: 7},
+        {header: "", status: http.StatusPreconditionRequired},
+        {header: "*", status: http.StatusPreconditionRequired},
+        {header: `"0"`, status: http.StatusBadRequest},
+        {header: `"abc"`, status: http.StatusBadRequest},
+    }
+    for _, tt := range tests {
+        rec := httptest.NewRecorder()
+        c, _ := gin.CreateTestContext(rec)
+        c.Request = httptest.NewRequest(http.MethodPatch, "/usuarios/1", nil)
+        if tt.header != "" {
+            c.Request.Header.Set("If-Match", tt.header)
+        }
+
+        version, ok := requireIfMatch(c)
+        if tt.status != 0 {
+            if ok || rec.Code != tt.status {
+                t.Errorf("If-Match %q: expected %d, got ok=%v status=%d", tt.header, tt.status, ok, rec.Code)
+            }
+            continue
+        }
+        if !ok || version != tt.version {
+            t.Errorf("If-Match %q: expected version %d, got %d (ok=%v)", tt.header, tt.version, version, ok)
+        }
+    }
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+    "fmt"
+    "io"
+    "log"
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "api-gateway/pb"
+)
+
+// La exportación de datos personales la genera users-service en segundo
+// plano. Al completarse, el estado trae un enlace /exportaciones/<token> que
+// se puede abrir sin Authorization hasta expires_at: el token es la credencial.
+
+// dataExportJSON arma la respuesta de una exportación, con el enlace de
+// descarga en lugar del token
+func dataExportJSON(export *pb.DataExport) gin.H {
+    response := gin.H{
+        "id":           export.Id,
+        "user_id":      export.UserId,
+        "status":       export.Status,
+        "requested_at": export.RequestedAt,
+        "attempts":     export.Attempts,
+    }
+    if export.Error != "" {
+        response["error"] = export.Error
+    }
+    if export.CompletedAt != "" {
+        response["completed_at"] = export.CompletedAt
+        response["size_bytes"] = export.SizeBytes
+    }
+    if export.ExpiresAt != "" {
+        response["expires_at"] = export.ExpiresAt
+    }
+    if export.DownloadToken != "" {
+        response["download_url"] = "/exportaciones/" + export.DownloadToken
+    }
+    return response
+}
+
+// startDataExport inicia la exportación de los datos de un usuario
+func startDataExport(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "ID de usuario inválido"})
+        return
+    }
+
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+
+    response, err := client.StartDataExport(ctx, &pb.StartDataExportRequest{UserId: int32(id)})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error iniciando la exportación: " + err.Error()})
+        return
+    }
+
+    c.Header("Location", fmt.Sprintf("/usuarios/%d/export/%s", id, response.Id))
+    c.JSON(http.StatusAccepted, dataExportJSON(response))
+}
+
+// getDataExport devuelve el estado de una exportación
+func getDataExport(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "ID de usuario inválido"})
+        return
+    }
+
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+
+    response, err := client.GetDataExport(ctx, &pb.GetDataExportRequest{Id: c.Param("export_id"), UserId: int32(id)})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error obteniendo la exportación: " + err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, dataExportJSON(response))
+}
+
+// downloadDataExport envía el ZIP de una exportación; es pública, la
+// autoriza el token del enlace
+func downloadDataExport(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    ctx, cancel := outgoingContext(c, 5*time.Minute)
+    defer cancel()
+
+    stream, err := client.DownloadDataExport(ctx, &pb.DownloadDataExportRequest{Token: c.Param("token")})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error descargando la exportación: " + err.Error()})
+        return
+    }
+    // Los errores del servicio llegan con el primer mensaje
+    first, err := stream.Recv()
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error descargando la exportación: " + err.Error()})
+        return
+    }
+
+    c.Header("Content-Type", "application/zip")
+    c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", first.Filename))
+    c.Header("Content-Length", strconv.FormatInt(first.SizeBytes, 10))
+    c.Header("Cache-Control", "no-store")
+    c.Status(http.StatusOK)
+    if _, err := c.Writer.Write(first.Data); err != nil {
+        return
+    }
+    for {
+        chunk, err := stream.Recv()
+        if err == io.EOF {
+            return
+        }
+        if err != nil {
+            // Los encabezados ya se enviaron: solo queda cortar la respuesta
+            log.Printf("Error descargando exportación: %v", err)
+            return
+        }
+        if _, err := c.Writer.Write(chunk.Data); err != nil {
+            return
+        }
+    }
+}
@@ -2,6 +2,7 @@ package main
 
 import (
     "context"
+    "errors"
     "log"
     "net/http"
     "os"
@@ -15,29 +16,75 @@ import (
     "google.golang.org/grpc"
     "google.golang.org/grpc/codes"
     "google.golang.org/grpc/credentials/insecure"
+    "google.golang.org/grpc/metadata"
     "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/types/known/fieldmaskpb"
     "api-gateway/pb"
 )
 
 type Claims struct {
+    UserID      string   `json:"sub"`
+    Email       string   `json:"email"`
+    Role        string   `json:"role"`
+    Permissions []string `json:"permissions"` // permisos del rol al iniciar sesión
+    ClientID    string   `json:"client_id"`   // solo en tokens de cliente OAuth2
+    Scope       string   `json:"scope"`       // scopes separados por espacios
+    Act         *Actor   `json:"act"`         // solo en tokens de suplantación (RFC 8693)
+    jwt.StandardClaims
+}
+
+// Actor es el administrador que suplanta al usuario del token
+type Actor struct {
     UserID string `json:"sub"`
     Email  string `json:"email"`
-    Role   string `json:"role"`
-    jwt.StandardClaims
 }
 
 type AuthService struct {
-    BaseURL string
+    BaseURL             string
+    httpClient          *http.Client
+    apiKeys             *introspectionCache
+    tokens              *introspectionCache
+    // Autentica al gateway ante POST /auth/api-keys/introspect y /auth/token/introspect
+    introspectionSecret string
 }
 
+// UserContext identifica a quien llama. Permissions son los permisos del rol
+// para un JWT de usuario, los scopes para un cliente OAuth2 y los scopes de la
+// key (acotados al rol) para una API key.
 type UserContext struct {
-    ID    string `json:"id"`
-    Email string `json:"email"`
-    Role  string `json:"role"`
+    ID          string   `json:"id"`
+    Email       string   `json:"email"`
+    Role        string   `json:"role"`
+    ClientID    string   `json:"client_id,omitempty"`
+    Permissions []string `json:"permissions"`
+    APIKeyID    int      `json:"api_key_id,omitempty"`
+    ActorID     string   `json:"actor_id,omitempty"`
+    ActorEmail  string   `json:"actor_email,omitempty"`
+}
+
+// IsClient indica si el token pertenece a un cliente OAuth2 y no a un usuario
+func (u *UserContext) IsClient() bool {
+    return u.ClientID != ""
+}
+
+// IsAPIKey indica si el usuario se autenticó con una API key personal
+func (u *UserContext) IsAPIKey() bool {
+    return u.APIKeyID != 0
+}
+
+// IsImpersonation indica si un administrador está suplantando al usuario
+func (u *UserContext) IsImpersonation() bool {
+    return u.ActorID != ""
 }
 
 func NewAuthService(baseURL string) *AuthService {
-    return &AuthService{BaseURL: baseURL}
+    return &AuthService{
+        BaseURL:             baseURL,
+        httpClient:          &http.Client{Timeout: 5 * time.Second},
+        apiKeys:             newIntrospectionCache(),
+        tokens:              newIntrospectionCache(),
+        introspectionSecret: os.Getenv("INTROSPECTION_SECRET"),
+    }
 }
 
 func (a *AuthService) ValidateToken(tokenString string) (*UserContext, error) {
@@ -59,11 +106,89 @@ func (a *AuthService) ValidateToken(tokenString string) (*UserContext, error) {
         return nil, jwt.ErrInvalidKey
     }
 
-    return &UserContext{
-        ID:    claims.UserID,
-        Email: claims.Email,
-        Role:  claims.Role,
-    }, nil
+    permissions := claims.Permissions
+    if claims.ClientID != "" {
+        permissions = strings.Fields(claims.Scope)
+    }
+
+    user := &UserContext{
+        ID:          claims.UserID,
+        Email:       claims.Email,
+        Role:        claims.Role,
+        ClientID:    claims.ClientID,
+        Permissions: permissions,
+    }
+    if claims.Act != nil {
+        user.ActorID = claims.Act.UserID
+        user.ActorEmail = claims.Act.Email
+    }
+    return user, nil
+}
+
+// HasPermission indica si quien llama tiene el permiso indicado
+func (u *UserContext) HasPermission(permission string) bool {
+    for _, granted := range u.Permissions {
+        if granted == permission {
+            return true
+        }
+    }
+    return false
+}
+
+func currentUser(c *gin.Context) (*UserContext, bool) {
+    value, exists := c.Get("user")
+    user, ok := value.(*UserContext)
+    return user, exists && ok
+}
+
+// authorize exige el permiso indicado, sea cual sea el tipo de credencial
+func authorize(permission string) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        user, ok := currentUser(c)
+        if !ok {
+            c.JSON(http.StatusUnauthorized, gin.H{"error": "Token de autorización requerido"})
+            c.Abort()
+            return
+        }
+        
+        if !user.HasPermission(permission) {
+            c.JSON(http.StatusForbidden, gin.H{"error": "No tiene el permiso requerido: " + permission})
+            c.Abort()
+            return
+        }
+        
+        c.Next()
+    }
+}
+
+// authorizeSelfOr deja pasar a un usuario sobre su propio recurso (:id) y exige
+// el permiso para cualquier otro
+func authorizeSelfOr(permission string) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        user, ok := currentUser(c)
+        if ok && !user.IsClient() && user.ID == c.Param("id") {
+            c.Next()
+            return
+        }
+        authorize(permission)(c)
+    }
+}
+
+// outgoingContext propaga la identidad de quien llama a los servicios gRPC
+// como metadata (user_id, role, permissions y actor_user_id si es una suplantación)
+func outgoingContext(c *gin.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+    ctx, cancel := context.WithTimeout(context.Background(), timeout)
+    if user, ok := currentUser(c); ok {
+        ctx = metadata.AppendToOutgoingContext(ctx,
+            "user_id", user.ID,
+            "role", user.Role,
+            "permissions", strings.Join(user.Permissions, " "),
+        )
+        if user.IsImpersonation() {
+            ctx = metadata.AppendToOutgoingContext(ctx, "actor_user_id", user.ActorID)
+        }
+    }
+    return ctx, cancel
 }
 
 func authMiddleware(authService *AuthService) gin.HandlerFunc {
@@ -74,18 +199,27 @@ func authMiddleware(authService *AuthService) gin.HandlerFunc {
         publicEndpoints := []string{
             "POST /usuarios",
             "POST /auth/login",
+            "POST /auth/password/forgot",
+            "POST /auth/password/reset",
+            "POST /auth/token",
+            "GET /auth/verify",
+            "POST /auth/verify/resend",
             "GET /health",
             "GET /videos",
             "GET /videos/",
             "GET /comedia",
+            "GET /exportaciones/",
+            "GET /avatares/",
+            "GET /usuarios/confirmar-email",
         }
         
         method := c.Request.Method
         path := c.Request.URL.Path
         endpoint := method + " " + path
         
+        // Coincidencia exacta; las entradas terminadas en "/" cubren las subrutas
         for _, public := range publicEndpoints {
-            if strings.HasPrefix(endpoint, public) {
+            if endpoint == public || (strings.HasSuffix(public, "/") && strings.HasPrefix(endpoint, public)) {
                 c.Next()
                 return
             }
@@ -97,6 +231,26 @@ func authMiddleware(authService *AuthService) gin.HandlerFunc {
             return
         }
         
+        // API key personal: "Authorization: ApiKey <key>"
+        if strings.HasPrefix(authHeader, "ApiKey ") {
+            user, err := authService.ValidateAPIKey(strings.TrimPrefix(authHeader, "ApiKey "))
+            if errors.Is(err, errAuthUnavailable) {
+                log.Printf("Error validando API key: %v", err)
+                c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Servicio de autenticación no disponible"})
+                c.Abort()
+                return
+            }
+            if err != nil {
+                c.JSON(http.StatusUnauthorized, gin.H{"error": "API key inválida"})
+                c.Abort()
+                return
+            }
+            
+            c.Set("user", user)
+            c.Next()
+            return
+        }
+        
         tokenString := strings.TrimPrefix(authHeader, "Bearer ")
         user, err := authService.ValidateToken(tokenString)
         if err != nil {
@@ -105,6 +259,22 @@ func authMiddleware(authService *AuthService) gin.HandlerFunc {
             return
         }
         
+        // La firma no basta: el token puede haberse revocado antes de expirar
+        if !user.IsClient() {
+            err = authService.CheckTokenActive(tokenString)
+            if errors.Is(err, errAuthUnavailable) {
+                log.Printf("Error validando token: %v", err)
+                c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Servicio de autenticación no disponible"})
+                c.Abort()
+                return
+            }
+            if err != nil {
+                c.JSON(http.StatusUnauthorized, gin.H{"error": "Token invalidado"})
+                c.Abort()
+                return
+            }
+        }
+        
         c.Set("user", user)
         c.Next()
     }
@@ -206,14 +376,15 @@ func createUser(c *gin.Context) {
         ConfirmPassword: getString(requestBody, "confirm_password"),
         FirstName:       firstName,
         LastName:        lastName,
-        Role:            getString(requestBody, "role"),
     }
     
-    if request.Role == "" {
-        request.Role = "cliente"
+    // El registro solo crea clientes: el rol lo asigna un administrador
+    if role := getString(requestBody, "role"); role != "" && !strings.EqualFold(role, "cliente") {
+        c.JSON(http.StatusForbidden, gin.H{"error": "El rol no se puede elegir al registrarse: lo asigna un administrador"})
+        return
     }
     
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := outgoingContext(c, 30*time.Second)
     defer cancel()
     
     response, err := client.CreateUser(ctx, request)
@@ -242,7 +413,7 @@ func getUser(c *gin.Context) {
         return
     }
     
-    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    ctx, cancel := outgoingContext(c, 30*time.Second)
     defer cancel()
     
     response, err := client.GetUser(ctx, &pb.GetUserRequest{Id: int32(id)})
@@ -252,6 +423,7 @@ func getUser(c *gin.Context) {
         return
     }
     
+    setETag(c, response.Version)
     c.JSON(http.StatusOK, response)
 }
 
@@ -270,37 +442,61 @@ func updateUser(c *gin.Context) {
         return
     }
     
+    expectedVersion, ok := requireIfMatch(c)
+    if !ok {
+        return
+    }
+    
     var requestBody map[string]interface{}
     if err := c.ShouldBindJSON(&requestBody); err != nil {
         c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
         return
     }
     
-    // Mapear campos como en CreateUser
-    firstName := getString(requestBody, "first_name")
-    lastName := getString(requestBody, "last_name")
+    // Solo se actualizan los campos presentes en el JSON
+    request := &pb.UpdateUserRequest{Id: int32(id), ExpectedVersion: expectedVersion}
+    var paths []string
+    for _, field := range []string{"first_name", "last_name", "email"} {
+        if _, ok := requestBody[field]; !ok {
+            continue
+        }
+        value, ok := requestBody[field].(string)
+        if !ok {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "El campo " + field + " debe ser texto"})
+            return
+        }
+        switch field {
+        case "first_name":
+            request.FirstName = value
+        case "last_name":
+            request.LastName = value
+        case "email":
+            request.Email = value
+        }
+        paths = append(paths, field)
+    }
     
-    if firstName == "" && lastName == "" {
-        fullName := getString(requestBody, "name")
-        if fullName != "" {
-            parts := strings.Fields(fullName)
-            if len(parts) > 0 {
-                firstName = parts[0]
-            }
-            if len(parts) > 1 {
-                lastName = strings.Join(parts[1:], " ")
+    // "name" completa nombre y apellido, como en CreateUser
+    if _, hasFirst := requestBody["first_name"]; !hasFirst {
+        if _, hasLast := requestBody["last_name"]; !hasLast {
+            if fullName := strings.Fields(getString(requestBody, "name")); len(fullName) > 0 {
+                request.FirstName = fullName[0]
+                paths = append(paths, "first_name")
+                if len(fullName) > 1 {
+                    request.LastName = strings.Join(fullName[1:], " ")
+                    paths = append(paths, "last_name")
+                }
             }
         }
     }
     
-    request := &pb.UpdateUserRequest{
-        Id:        int32(id),
-        Email:     getString(requestBody, "email"),
-        FirstName: firstName,
-        LastName:  lastName,
+    if len(paths) == 0 {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "No hay campos para actualizar"})
+        return
     }
+    request.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
     
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    ctx, cancel := outgoingContext(c, 10*time.Second)
     defer cancel()
     
     response, err := client.UpdateUser(ctx, request)
@@ -310,9 +506,39 @@ func updateUser(c *gin.Context) {
         return
     }
     
+    setETag(c, response.Version)
     c.JSON(http.StatusOK, response)
 }
 
+// confirmEmailChange aplica un cambio de email pendiente desde el enlace
+// enviado a la dirección nueva (público: el token lo autoriza)
+func confirmEmailChange(c *gin.Context) {
+    token := c.Query("token")
+    if token == "" {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Falta el token"})
+        return
+    }
+    
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+    
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer cancel()
+    
+    response, err := client.ConfirmEmailChange(ctx, &pb.ConfirmEmailChangeRequest{Token: token})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error confirmando el email: " + err.Error()})
+        return
+    }
+    
+    c.JSON(http.StatusOK, gin.H{"message": "Email actualizado", "email": response.Email})
+}
+
 func deleteUser(c *gin.Context) {
     client, conn, err := getUsersClient()
     if err != nil {
@@ -328,10 +554,15 @@ func deleteUser(c *gin.Context) {
         return
     }
     
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    expectedVersion, ok := requireIfMatch(c)
+    if !ok {
+        return
+    }
+    
+    ctx, cancel := outgoingContext(c, 10*time.Second)
     defer cancel()
     
-    _, err = client.DeleteUser(ctx, &pb.DeleteUserRequest{Id: int32(id)})
+    _, err = client.DeleteUser(ctx, &pb.DeleteUserRequest{Id: int32(id), ExpectedVersion: expectedVersion})
     if err != nil {
         statusCode := mapGRPCErrorToHTTP(err)
         c.JSON(statusCode, gin.H{"error": "Error eliminando usuario: " + err.Error()})
@@ -341,6 +572,154 @@ func deleteUser(c *gin.Context) {
     c.JSON(http.StatusOK, gin.H{"message": "Usuario eliminado exitosamente"})
 }
 
+// restoreUser saca un usuario de la papelera
+func restoreUser(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+    
+    id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "ID de usuario inválido"})
+        return
+    }
+    
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+    
+    response, err := client.RestoreUser(ctx, &pb.RestoreUserRequest{Id: int32(id)})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error restaurando usuario: " + err.Error()})
+        return
+    }
+    
+    setETag(c, response.GetVersion())
+    c.JSON(http.StatusOK, response)
+}
+
+// purgeUser borra definitivamente un usuario que está en la papelera
+func purgeUser(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+    
+    id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "ID de usuario inválido"})
+        return
+    }
+    
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+    
+    response, err := client.PurgeUser(ctx, &pb.PurgeUserRequest{Id: int32(id)})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error borrando usuario: " + err.Error()})
+        return
+    }
+    
+    c.JSON(http.StatusOK, gin.H{"message": response.Message, "erasure_id": response.ErasureId})
+}
+
+// listDeletedUsers lista la papelera; acepta page_size y page_token
+func listDeletedUsers(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+    
+    request := &pb.ListDeletedUsersRequest{PageToken: c.Query("page_token")}
+    if value := c.Query("page_size"); value != "" {
+        pageSize, err := strconv.Atoi(value)
+        if err != nil || pageSize <= 0 {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "page_size inválido: debe ser un entero positivo"})
+            return
+        }
+        request.PageSize = int32(pageSize)
+    }
+    
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+    
+    response, err := client.ListDeletedUsers(ctx, request)
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error listando la papelera: " + err.Error()})
+        return
+    }
+    
+    c.JSON(http.StatusOK, response)
+}
+
+// getErasureRequest muestra el avance del borrado de datos de un usuario
+// purgado en cada servicio
+func getErasureRequest(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+    
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+    
+    response, err := client.GetErasureRequest(ctx, &pb.GetErasureRequestRequest{Id: c.Param("erasure_id")})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error obteniendo la solicitud de borrado: " + err.Error()})
+        return
+    }
+    
+    c.JSON(http.StatusOK, response)
+}
+
+// listErasureRequests lista las solicitudes de borrado; acepta status y user_id
+func listErasureRequests(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+    
+    request := &pb.ListErasureRequestsRequest{Status: c.Query("status")}
+    if value := c.Query("user_id"); value != "" {
+        userID, err := strconv.ParseInt(value, 10, 32)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "user_id inválido"})
+            return
+        }
+        request.UserId = int32(userID)
+    }
+    
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+    
+    response, err := client.ListErasureRequests(ctx, request)
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error listando solicitudes de borrado: " + err.Error()})
+        return
+    }
+    
+    c.JSON(http.StatusOK, response)
+}
+
+// listUsers acepta email y name (buscan el texto en cualquier parte del
+// email o del nombre completo), role, status, created_after, created_before,
+// order_by ("campo [desc]"), page_size y page_token; la respuesta incluye
+// next_page_token y total_size
 func listUsers(c *gin.Context) {
     client, conn, err := getUsersClient()
     if err != nil {
@@ -349,10 +728,29 @@ func listUsers(c *gin.Context) {
     }
     defer conn.Close()
     
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    ctx, cancel := outgoingContext(c, 10*time.Second)
     defer cancel()
     
-    response, err := client.ListUsers(ctx, &pb.ListUsersRequest{})
+    request := &pb.ListUsersRequest{
+        Email:         c.Query("email"),
+        Name:          c.Query("name"),
+        Role:          c.Query("role"),
+        Status:        c.Query("status"),
+        CreatedAfter:  c.Query("created_after"),
+        CreatedBefore: c.Query("created_before"),
+        OrderBy:       c.Query("order_by"),
+        PageToken:     c.Query("page_token"),
+    }
+    if value := c.Query("page_size"); value != "" {
+        pageSize, err := strconv.Atoi(value)
+        if err != nil || pageSize <= 0 {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "page_size inválido: debe ser un entero positivo"})
+            return
+        }
+        request.PageSize = int32(pageSize)
+    }
+    
+    response, err := client.ListUsers(ctx, request)
     if err != nil {
         statusCode := mapGRPCErrorToHTTP(err)
         c.JSON(statusCode, gin.H{"error": "Error listando usuarios: " + err.Error()})
@@ -397,7 +795,8 @@ func mapGRPCErrorToHTTP(err error) int {
     case codes.FailedPrecondition:
         return http.StatusPreconditionFailed
     case codes.Aborted:
-        return http.StatusConflict
+        // Escritura condicional con una versión desactualizada (If-Match)
+        return http.StatusPreconditionFailed
     case codes.OutOfRange:
         return http.StatusBadRequest
     case codes.Unimplemented:
@@ -437,7 +836,7 @@ func listVideos(c *gin.Context) {
     }
     defer conn.Close()
     
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    ctx, cancel := outgoingContext(c, 10*time.Second)
     defer cancel()
     
     response, err := client.ListVideos(ctx, &pb.ListVideosRequest{})
@@ -460,7 +859,7 @@ func getVideo(c *gin.Context) {
     
     videoID := c.Param("id")
     
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    ctx, cancel := outgoingContext(c, 10*time.Second)
     defer cancel()
     
     response, err := client.GetVideo(ctx, &pb.GetVideoRequest{Id: videoID})
@@ -470,6 +869,97 @@ func getVideo(c *gin.Context) {
         return
     }
     
+    setETag(c, response.Version)
+    c.JSON(http.StatusOK, response)
+}
+
+func updateVideo(c *gin.Context) {
+    client, conn, err := getVideosClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de videos: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+    
+    videoID := c.Param("id")
+    
+    expectedVersion, ok := requireIfMatch(c)
+    if !ok {
+        return
+    }
+    
+    var requestBody map[string]interface{}
+    if err := c.ShouldBindJSON(&requestBody); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+        return
+    }
+    
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+    
+    // UpdateVideo reemplaza los tres campos: se parte del video actual y la
+    // versión esperada evita pisar un cambio hecho entre la lectura y la escritura
+    current, err := client.GetVideo(ctx, &pb.GetVideoRequest{Id: videoID})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error obteniendo video: " + err.Error()})
+        return
+    }
+    if expectedVersion == 0 {
+        expectedVersion = current.Version
+    }
+    
+    request := &pb.UpdateVideoRequest{
+        Id:              videoID,
+        Title:           current.Title,
+        Description:     current.Description,
+        Genre:           current.Genre,
+        ExpectedVersion: expectedVersion,
+    }
+    if _, ok := requestBody["title"]; ok {
+        request.Title = getString(requestBody, "title")
+    }
+    if _, ok := requestBody["description"]; ok {
+        request.Description = getString(requestBody, "description")
+    }
+    if _, ok := requestBody["genre"]; ok {
+        request.Genre = getString(requestBody, "genre")
+    }
+    
+    response, err := client.UpdateVideo(ctx, request)
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error actualizando video: " + err.Error()})
+        return
+    }
+    
+    setETag(c, response.Version)
+    c.JSON(http.StatusOK, response)
+}
+
+func deleteVideo(c *gin.Context) {
+    client, conn, err := getVideosClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de videos: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+    
+    expectedVersion, ok := requireIfMatch(c)
+    if !ok {
+        return
+    }
+    
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+    
+    response, err := client.DeleteVideo(ctx, &pb.DeleteVideoRequest{Id: c.Param("id"), ExpectedVersion: expectedVersion})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error eliminando video: " + err.Error()})
+        return
+    }
+    
     c.JSON(http.StatusOK, response)
 }
 
@@ -482,14 +972,6 @@ func handleVideos(c *gin.Context) {
     })
 }
 
-func handleBilling(c *gin.Context) {
-    c.JSON(http.StatusOK, gin.H{
-        "message": "Billing service - Implementación pendiente",
-        "method": c.Request.Method,
-        "path": c.Request.URL.Path,
-    })
-}
-
 func handleMonitoring(c *gin.Context) {
     c.JSON(http.StatusOK, gin.H{
         "message": "Monitoring service - Implementación pendiente",
@@ -526,6 +1008,7 @@ func main() {
     
     // Middleware de autenticación
     router.Use(authMiddleware(authService))
+    router.Use(auditImpersonation())
     
     // Middleware de logging
     router.Use(gin.Logger())
@@ -557,56 +1040,77 @@ func main() {
     userGroup := router.Group("/usuarios")
     {
         userGroup.POST("", createUser)
-        userGroup.GET("/:id", getUser)
-        userGroup.PATCH("/:id", updateUser)
-        userGroup.DELETE("/:id", deleteUser)
-        userGroup.GET("", listUsers)
+        userGroup.GET("/:id", authorizeSelfOr("users:read"), getUser)
+        userGroup.PATCH("/:id", denyImpersonation(), authorizeSelfOr("users:write"), updateUser) // requiere If-Match; el email queda pendiente
+        userGroup.GET("/confirmar-email", confirmEmailChange) // pública: ?token= del enlace enviado al email nuevo
+        userGroup.DELETE("/:id", denyImpersonation(), authorize("users:write"), deleteUser) // requiere If-Match
+        userGroup.GET("", authorize("users:read"), listUsers)
+        userGroup.POST("/importar", denyImpersonation(), authorize("users:write"), importUsers) // ?dry_run=true solo valida
+        userGroup.GET("/exportar", authorize("users:read"), exportUsers)
+        userGroup.GET("/papelera", authorize("users:read"), listDeletedUsers)
+        userGroup.POST("/:id/restaurar", denyImpersonation(), authorize("users:write"), restoreUser)
+        userGroup.DELETE("/:id/definitivo", denyImpersonation(), authorize("users:purge"), purgeUser)
+        userGroup.GET("/borrados", authorize("users:purge"), listErasureRequests)
+        userGroup.GET("/borrados/:erasure_id", authorize("users:purge"), getErasureRequest)
+        userGroup.POST("/:id/export", denyImpersonation(), authorizeSelfOr("users:read"), startDataExport)
+        userGroup.GET("/:id/export/:export_id", authorizeSelfOr("users:read"), getDataExport)
+        userGroup.GET("/:id/perfil", authorizeSelfOr("users:read"), getProfile)
+        userGroup.PATCH("/:id/perfil", denyImpersonation(), authorizeSelfOr("users:write"), updateProfile) // requiere If-Match
+        userGroup.PUT("/:id/avatar", denyImpersonation(), authorizeSelfOr("users:write"), uploadAvatar)
+        userGroup.DELETE("/:id/avatar", denyImpersonation(), authorizeSelfOr("users:write"), deleteAvatar)
+        userGroup.PATCH("/:id/rol", denyImpersonation(), authorize("roles:manage"), changeUserRole(authService)) // requiere If-Match
     }
     
+    // Avatares (públicos: se muestran a los demás usuarios)
+    router.GET("/avatares/:id", getAvatar)
+    
+    // Descarga de exportaciones de datos (pública: el token del enlace la autoriza)
+    router.GET("/exportaciones/:token", downloadDataExport)
+    
     // Rutas de facturas
     billGroup := router.Group("/facturas")
     {
-        billGroup.POST("", handleBilling)
-        billGroup.GET("/:id", handleBilling)
-        billGroup.PATCH("/:id", handleBilling)
-        billGroup.DELETE("/:id", handleBilling)
-        billGroup.GET("", handleBilling)
+        billGroup.POST("", denyImpersonation(), authorize("invoices:write"), createInvoice)
+        billGroup.GET("/:id", authorize("invoices:read"), getInvoice)
+        billGroup.PATCH("/:id", denyImpersonation(), authorize("invoices:write"), updateInvoice) // requiere If-Match
+        billGroup.DELETE("/:id", denyImpersonation(), authorize("invoices:write"), deleteInvoice) // requiere If-Match
+        billGroup.GET("", authorize("invoices:read"), listInvoices)
     }
     
     // Rutas de videos
     videoGroup := router.Group("/videos")
     {
-        videoGroup.POST("", handleVideos)    // POST /videos - Not implemented yet
+        videoGroup.POST("", authorize("videos:publish"), handleVideos)  // POST /videos - Not implemented yet
         videoGroup.GET("/:id", getVideo)    // GET /videos/:id
-        videoGroup.PATCH("/:id", handleVideos) // PATCH /videos/:id - Not implemented yet
-        videoGroup.DELETE("/:id", handleVideos) // DELETE /videos/:id - Not implemented yet
+        videoGroup.PATCH("/:id", authorize("videos:write"), updateVideo) // PATCH /videos/:id - requiere If-Match
+        videoGroup.DELETE("/:id", authorize("videos:write"), deleteVideo) // DELETE /videos/:id - requiere If-Match
         videoGroup.GET("", listVideos)     // GET /videos
     }
     
     // Rutas de monitoreo
     monitoringGroup := router.Group("/monitoreo")
     {
-        monitoringGroup.GET("/acciones", handleMonitoring)
-        monitoringGroup.GET("/errores", handleMonitoring)
+        monitoringGroup.GET("/acciones", authorize("monitoring:read"), handleMonitoring)
+        monitoringGroup.GET("/errores", authorize("monitoring:read"), handleMonitoring)
     }
     
     // Rutas de listas de reproducción
     playlistGroup := router.Group("/listas-reproduccion")
     {
-        playlistGroup.POST("", handlePlaylists)
-        playlistGroup.POST("/:id/videos", handlePlaylists)
-        playlistGroup.GET("", handlePlaylists)
-        playlistGroup.GET("/:id/videos", handlePlaylists)
-        playlistGroup.DELETE("/:id/videos", handlePlaylists)
-        playlistGroup.DELETE("/:id", handlePlaylists)
+        playlistGroup.POST("", authorize("playlists:write"), handlePlaylists)
+        playlistGroup.POST("/:id/videos", authorize("playlists:write"), handlePlaylists)
+        playlistGroup.GET("", authorize("playlists:read"), handlePlaylists)
+        playlistGroup.GET("/:id/videos", authorize("playlists:read"), handlePlaylists)
+        playlistGroup.DELETE("/:id/videos", authorize("playlists:write"), handlePlaylists)
+        playlistGroup.DELETE("/:id", authorize("playlists:write"), handlePlaylists)
     }
     
     // Rutas de interacciones sociales
     socialGroup := router.Group("/interacciones")
     {
-        socialGroup.POST("/:id/likes", handleSocial)
-        socialGroup.POST("/:id/comentarios", handleSocial)
-        socialGroup.GET("/:id", handleSocial)
+        socialGroup.POST("/:id/likes", authorize("social:write"), handleSocial)
+        socialGroup.POST("/:id/comentarios", authorize("social:write"), handleSocial)
+        socialGroup.GET("/:id", authorize("social:read"), handleSocial)
     }
  
     port := os.Getenv("PORT")
@@ -0,0 +1,323 @@
+package main
+
+import (
+    "encoding/json"
+    "io"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "api-gateway/pb"
+    "google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// El perfil comparte la versión del usuario: GET /usuarios/:id/perfil la
+// devuelve como ETag y PATCH exige If-Match. Los avatares se sirven en
+// /avatares/:id sin autenticación, porque se muestran a los demás usuarios.
+
+// avatarUploadMaxBytes coincide con el máximo que acepta users-service
+const avatarUploadMaxBytes = 3 << 20
+
+// profileJSON arma la respuesta del perfil, con las preferencias en false
+// incluidas
+func profileJSON(profile *pb.UserProfile) gin.H {
+    notifications := profile.Notifications
+    if notifications == nil {
+        notifications = &pb.NotificationPreferences{}
+    }
+    genres := profile.FavoriteGenres
+    if genres == nil {
+        genres = []string{}
+    }
+    response := gin.H{
+        "user_id":      profile.UserId,
+        "display_name": profile.DisplayName,
+        "avatar_url":   nil,
+        "locale":       profile.Locale,
+        "timezone":     profile.Timezone,
+        "notifications": gin.H{
+            "newsletter":      notifications.Newsletter,
+            "recommendations": notifications.Recommendations,
+            "social":          notifications.Social,
+        },
+        "favorite_genres": genres,
+        "version":         profile.Version,
+    }
+    if profile.AvatarUrl != "" {
+        response["avatar_url"] = profile.AvatarUrl
+    }
+    if profile.UpdatedAt != "" {
+        response["updated_at"] = profile.UpdatedAt
+    }
+    return response
+}
+
+// getProfile devuelve el perfil de un usuario
+func getProfile(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "ID de usuario inválido"})
+        return
+    }
+
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+
+    response, err := client.GetProfile(ctx, &pb.GetProfileRequest{UserId: int32(id)})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error obteniendo el perfil: " + err.Error()})
+        return
+    }
+
+    setETag(c, response.Version)
+    c.JSON(http.StatusOK, profileJSON(response))
+}
+
+// updateProfile actualiza los campos del perfil presentes en el JSON; en
+// notifications, solo las preferencias enviadas
+func updateProfile(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "ID de usuario inválido"})
+        return
+    }
+
+    expectedVersion, ok := requireIfMatch(c)
+    if !ok {
+        return
+    }
+
+    var requestBody map[string]json.RawMessage
+    if err := c.ShouldBindJSON(&requestBody); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+        return
+    }
+
+    request := &pb.UpdateProfileRequest{UserId: int32(id), ExpectedVersion: expectedVersion}
+    var paths []string
+    for field, raw := range requestBody {
+        var err error
+        switch field {
+        case "display_name":
+            err = json.Unmarshal(raw, &request.DisplayName)
+        case "locale":
+            err = json.Unmarshal(raw, &request.Locale)
+        case "timezone":
+            err = json.Unmarshal(raw, &request.Timezone)
+        case "favorite_genres":
+            err = json.Unmarshal(raw, &request.FavoriteGenres)
+        case "notifications":
+            var prefs map[string]bool
+            if err = json.Unmarshal(raw, &prefs); err != nil {
+                break
+            }
+            request.Notifications = &pb.NotificationPreferences{}
+            for name, value := range prefs {
+                switch name {
+                case "newsletter":
+                    request.Notifications.Newsletter = value
+                case "recommendations":
+                    request.Notifications.Recommendations = value
+                case "social":
+                    request.Notifications.Social = value
+                default:
+                    c.JSON(http.StatusBadRequest, gin.H{"error": "Preferencia de notificación desconocida: " + name})
+                    return
+                }
+                paths = append(paths, "notifications."+name)
+            }
+            continue
+        default:
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Campo no actualizable: " + field})
+            return
+        }
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Tipo inválido en el campo " + field})
+            return
+        }
+        paths = append(paths, field)
+    }
+
+    if len(paths) == 0 {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "No hay campos para actualizar"})
+        return
+    }
+    request.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
+
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+
+    response, err := client.UpdateProfile(ctx, request)
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error actualizando el perfil: " + err.Error()})
+        return
+    }
+
+    setETag(c, response.Version)
+    c.JSON(http.StatusOK, profileJSON(response))
+}
+
+// optionalIfMatch lee If-Match si viene; el avatar se puede cambiar sin él
+func optionalIfMatch(c *gin.Context) (int64, bool) {
+    if strings.TrimSpace(c.GetHeader("If-Match")) == "" {
+        return 0, true
+    }
+    return requireIfMatch(c)
+}
+
+// uploadAvatar recibe la imagen como campo "avatar" de un formulario
+// multipart o como cuerpo image/*
+func uploadAvatar(c *gin.Context) {
+    id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "ID de usuario inválido"})
+        return
+    }
+    expectedVersion, ok := optionalIfMatch(c)
+    if !ok {
+        return
+    }
+
+    // Margen para el resto del formulario multipart
+    c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, avatarUploadMaxBytes+64<<10)
+    var image []byte
+    if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+        file, err := c.FormFile("avatar")
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Falta la imagen en el campo 'avatar'"})
+            return
+        }
+        f, err := file.Open()
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Error leyendo la imagen: " + err.Error()})
+            return
+        }
+        defer f.Close()
+        image, err = io.ReadAll(f)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Error leyendo la imagen: " + err.Error()})
+            return
+        }
+    } else {
+        image, err = io.ReadAll(c.Request.Body)
+        if err != nil {
+            c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "La imagen supera el tamaño máximo de 3 MB"})
+            return
+        }
+    }
+    if len(image) > avatarUploadMaxBytes {
+        c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "La imagen supera el tamaño máximo de 3 MB"})
+        return
+    }
+
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    ctx, cancel := outgoingContext(c, 30*time.Second)
+    defer cancel()
+
+    response, err := client.UploadAvatar(ctx, &pb.UploadAvatarRequest{UserId: int32(id), Image: image, ExpectedVersion: expectedVersion})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error subiendo el avatar: " + err.Error()})
+        return
+    }
+
+    setETag(c, response.Version)
+    c.JSON(http.StatusOK, profileJSON(response))
+}
+
+// deleteAvatar quita el avatar del usuario
+func deleteAvatar(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "ID de usuario inválido"})
+        return
+    }
+    expectedVersion, ok := optionalIfMatch(c)
+    if !ok {
+        return
+    }
+
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+
+    response, err := client.DeleteAvatar(ctx, &pb.DeleteAvatarRequest{UserId: int32(id), ExpectedVersion: expectedVersion})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error borrando el avatar: " + err.Error()})
+        return
+    }
+
+    setETag(c, response.Version)
+    c.JSON(http.StatusOK, profileJSON(response))
+}
+
+// getAvatar sirve la imagen del avatar. Es pública; con el ?v= de
+// avatar_url se puede cachear sin vencimiento, porque cada subida cambia la URL.
+func getAvatar(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "ID de usuario inválido"})
+        return
+    }
+
+    ctx, cancel := outgoingContext(c, 10*time.Second)
+    defer cancel()
+
+    avatar, err := client.GetAvatar(ctx, &pb.GetAvatarRequest{UserId: int32(id)})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error obteniendo el avatar: " + err.Error()})
+        return
+    }
+
+    etag := `"` + avatar.Etag + `"`
+    c.Header("ETag", etag)
+    if c.Query("v") == avatar.Etag {
+        c.Header("Cache-Control", "public, max-age=31536000, immutable")
+    } else {
+        c.Header("Cache-Control", "public, max-age=300")
+    }
+    if c.GetHeader("If-None-Match") == etag {
+        c.Status(http.StatusNotModified)
+        return
+    }
+    c.Data(http.StatusOK, avatar.ContentType, avatar.Data)
+}
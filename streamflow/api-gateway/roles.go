@@ -0,0 +1,131 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "api-gateway/pb"
+)
+
+// El registro siempre crea clientes. Para dar otro rol, quien tiene
+// roles:manage usa PATCH /usuarios/:id/rol: el gateway comprueba que el rol
+// exista en auth y users-service publica user.role_changed, con el que auth
+// revoca los tokens del usuario y monitoring registra el cambio.
+
+// errUnknownRole indica que el rol pedido no existe en auth
+var errUnknownRole = errors.New("rol inexistente")
+
+// ResolveRole busca el rol en auth con las credenciales de quien llama y
+// devuelve su nombre tal como está escrito allí
+func (a *AuthService) ResolveRole(authHeader, role string) (string, error) {
+    req, err := http.NewRequest(http.MethodGet, a.BaseURL+"/auth/roles", nil)
+    if err != nil {
+        return "", err
+    }
+    req.Header.Set("Authorization", authHeader)
+
+    resp, err := a.httpClient.Do(req)
+    if err != nil {
+        return "", fmt.Errorf("%w: %v", errAuthUnavailable, err)
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return "", fmt.Errorf("%w: la consulta de roles respondió %d", errAuthUnavailable, resp.StatusCode)
+    }
+
+    var result struct {
+        Roles []struct {
+            Name string `json:"name"`
+        } `json:"roles"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+        return "", fmt.Errorf("%w: respuesta inválida: %v", errAuthUnavailable, err)
+    }
+    for _, existing := range result.Roles {
+        if strings.EqualFold(existing.Name, role) {
+            return existing.Name, nil
+        }
+    }
+    return "", errUnknownRole
+}
+
+// changeUserRole asigna otro rol a un usuario (requiere If-Match)
+func changeUserRole(authService *AuthService) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "ID de usuario inválido"})
+            return
+        }
+
+        user, _ := currentUser(c)
+        // Auth solo consulta roles con un token Bearer
+        if user.IsAPIKey() {
+            c.JSON(http.StatusForbidden, gin.H{"error": "Los roles no se pueden cambiar con una API key"})
+            return
+        }
+        if !user.IsClient() && user.ID == c.Param("id") {
+            c.JSON(http.StatusForbidden, gin.H{"error": "No se puede cambiar el rol propio"})
+            return
+        }
+
+        expectedVersion, ok := requireIfMatch(c)
+        if !ok {
+            return
+        }
+
+        var requestBody struct {
+            Role   string `json:"role"`
+            Reason string `json:"reason"`
+        }
+        if err := c.ShouldBindJSON(&requestBody); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+            return
+        }
+        if strings.TrimSpace(requestBody.Role) == "" {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Falta el rol"})
+            return
+        }
+
+        role, err := authService.ResolveRole(c.GetHeader("Authorization"), strings.TrimSpace(requestBody.Role))
+        if errors.Is(err, errUnknownRole) {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Rol inexistente: " + requestBody.Role})
+            return
+        }
+        if err != nil {
+            c.JSON(http.StatusServiceUnavailable, gin.H{"error": "No se pudo validar el rol: " + err.Error()})
+            return
+        }
+
+        client, conn, err := getUsersClient()
+        if err != nil {
+            c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+            return
+        }
+        defer conn.Close()
+
+        ctx, cancel := outgoingContext(c, 10*time.Second)
+        defer cancel()
+
+        response, err := client.ChangeUserRole(ctx, &pb.ChangeUserRoleRequest{
+            Id:              int32(id),
+            Role:            role,
+            ExpectedVersion: expectedVersion,
+            Reason:          requestBody.Reason,
+        })
+        if err != nil {
+            statusCode := mapGRPCErrorToHTTP(err)
+            c.JSON(statusCode, gin.H{"error": "Error cambiando el rol: " + err.Error()})
+            return
+        }
+
+        setETag(c, response.Version)
+        c.JSON(http.StatusOK, response)
+    }
+}
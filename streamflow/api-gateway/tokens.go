@@ -0,0 +1,79 @@
+package main
+
+import (
+    "bytes"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net/http"
+    "time"
+)
+
+// Los JWT de usuario se validan localmente (firma y expiración) y además se
+// consultan al servicio de autenticación (POST /auth/token/introspect), que
+// conoce los logouts, las sesiones revocadas y tokens_revoked_at. El resultado
+// se cachea, así que un token revocado puede seguir aceptándose hasta
+// tokenCacheTTL.
+const tokenCacheTTL = 10 * time.Second
+
+var errRevokedToken = errors.New("token invalidado")
+
+type introspectTokenResponse struct {
+    Active    bool  `json:"active"`
+    ExpiresAt int64 `json:"exp"`
+}
+
+// CheckTokenActive indica si un token con firma válida sigue vigente en auth
+func (a *AuthService) CheckTokenActive(token string) error {
+    sum := sha256.Sum256([]byte(token))
+    hash := hex.EncodeToString(sum[:])
+
+    if entry, ok := a.tokens.get(hash); ok {
+        if entry.user == nil {
+            return errRevokedToken
+        }
+        return nil
+    }
+
+    body, err := json.Marshal(map[string]string{"token": token})
+    if err != nil {
+        return err
+    }
+
+    req, err := http.NewRequest(http.MethodPost, a.BaseURL+"/auth/token/introspect", bytes.NewReader(body))
+    if err != nil {
+        return err
+    }
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("X-Service-Secret", a.introspectionSecret)
+
+    resp, err := a.httpClient.Do(req)
+    if err != nil {
+        return fmt.Errorf("%w: %v", errAuthUnavailable, err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return fmt.Errorf("%w: introspección respondió %d", errAuthUnavailable, resp.StatusCode)
+    }
+
+    var result introspectTokenResponse
+    if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+        return fmt.Errorf("%w: respuesta inválida: %v", errAuthUnavailable, err)
+    }
+
+    expires := time.Now().Add(tokenCacheTTL)
+    if !result.Active {
+        a.tokens.set(hash, introspectionCacheEntry{expires: expires})
+        return errRevokedToken
+    }
+
+    if tokenExpires := time.Unix(result.ExpiresAt, 0); result.ExpiresAt != 0 && tokenExpires.Before(expires) {
+        expires = tokenExpires
+    }
+    // La entrada solo marca el token como vigente; el UserContext sale del JWT
+    a.tokens.set(hash, introspectionCacheEntry{user: &UserContext{}, expires: expires})
+    return nil
+}
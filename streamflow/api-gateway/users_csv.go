@@ -0,0 +1,183 @@
+package main
+
+import (
+    "encoding/csv"
+    "io"
+    "log"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "api-gateway/pb"
+)
+
+// Importación y exportación de usuarios en CSV. La importación la valida y
+// ejecuta users-service fila por fila; el gateway solo recibe el archivo.
+
+// importMaxBytes limita el tamaño del CSV subido (users-service acepta hasta 1000 filas)
+const importMaxBytes = 2 << 20
+
+// exportPageSize es el tamaño de página con el que se recorre ListUsers
+const exportPageSize = 200
+
+// importUsers recibe un CSV como campo "file" de un formulario multipart o
+// como cuerpo text/csv. Con dry_run=true solo valida.
+func importUsers(c *gin.Context) {
+    dryRun := false
+    if value := c.Query("dry_run"); value != "" {
+        parsed, err := strconv.ParseBool(value)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "dry_run inválido: debe ser true o false"})
+            return
+        }
+        dryRun = parsed
+    }
+
+    c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, importMaxBytes)
+    var data []byte
+    if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+        file, err := c.FormFile("file")
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Falta el archivo CSV en el campo 'file'"})
+            return
+        }
+        f, err := file.Open()
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Error leyendo el archivo: " + err.Error()})
+            return
+        }
+        defer f.Close()
+        data, err = io.ReadAll(f)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Error leyendo el archivo: " + err.Error()})
+            return
+        }
+    } else {
+        var err error
+        data, err = io.ReadAll(c.Request.Body)
+        if err != nil {
+            c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "El CSV supera el tamaño máximo de 2 MB"})
+            return
+        }
+    }
+    if len(data) == 0 {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "El CSV está vacío"})
+        return
+    }
+
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    // Cada fila hashea una contraseña: un archivo grande tarda
+    ctx, cancel := outgoingContext(c, 2*time.Minute)
+    defer cancel()
+
+    response, err := client.ImportUsers(ctx, &pb.ImportUsersRequest{Csv: data, DryRun: dryRun})
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error importando usuarios: " + err.Error()})
+        return
+    }
+
+    errors := make([]gin.H, 0, len(response.Errors))
+    for _, rowErr := range response.Errors {
+        errors = append(errors, gin.H{"row": rowErr.Row, "email": rowErr.Email, "error": rowErr.Error})
+    }
+    createdIDs := response.CreatedIds
+    if createdIDs == nil {
+        createdIDs = []int32{}
+    }
+    c.JSON(http.StatusOK, gin.H{
+        "dry_run":     response.DryRun,
+        "total_rows":  response.TotalRows,
+        "valid_rows":  response.ValidRows,
+        "created":     response.Created,
+        "failed":      response.Failed,
+        "errors":      errors,
+        "created_ids": createdIDs,
+    })
+}
+
+// exportUsers devuelve como CSV todos los usuarios que cumplen los filtros de
+// GET /usuarios, recorriendo las páginas de ListUsers
+func exportUsers(c *gin.Context) {
+    client, conn, err := getUsersClient()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Error conectando al servicio de usuarios: " + err.Error()})
+        return
+    }
+    defer conn.Close()
+
+    ctx, cancel := outgoingContext(c, 2*time.Minute)
+    defer cancel()
+
+    request := &pb.ListUsersRequest{
+        Email:         c.Query("email"),
+        Name:          c.Query("name"),
+        Role:          c.Query("role"),
+        Status:        c.Query("status"),
+        CreatedAfter:  c.Query("created_after"),
+        CreatedBefore: c.Query("created_before"),
+        OrderBy:       c.Query("order_by"),
+        PageSize:      exportPageSize,
+    }
+
+    // La primera página se pide antes de escribir para poder responder los errores con su código
+    response, err := client.ListUsers(ctx, request)
+    if err != nil {
+        statusCode := mapGRPCErrorToHTTP(err)
+        c.JSON(statusCode, gin.H{"error": "Error listando usuarios: " + err.Error()})
+        return
+    }
+
+    filename := "usuarios-" + time.Now().UTC().Format("20060102-150405") + ".csv"
+    c.Header("Content-Type", "text/csv; charset=utf-8")
+    c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+    c.Header("Cache-Control", "no-store")
+    c.Status(http.StatusOK)
+
+    writer := csv.NewWriter(c.Writer)
+    writer.Write([]string{"id", "first_name", "last_name", "email", "role", "status", "created_at"})
+    for {
+        for _, user := range response.Users {
+            writer.Write([]string{
+                strconv.Itoa(int(user.Id)),
+                csvCell(user.FirstName),
+                csvCell(user.LastName),
+                csvCell(user.Email),
+                csvCell(user.Role),
+                user.Status,
+                user.CreatedAt,
+            })
+        }
+        writer.Flush()
+        if err := writer.Error(); err != nil {
+            return
+        }
+        if response.NextPageToken == "" {
+            return
+        }
+
+        request.PageToken = response.NextPageToken
+        response, err = client.ListUsers(ctx, request)
+        if err != nil {
+            // Los encabezados ya se enviaron: solo queda cortar la respuesta
+            log.Printf("Error exportando usuarios: %v", err)
+            return
+        }
+    }
+}
+
+// csvCell evita que una planilla interprete el valor como fórmula
+func csvCell(value string) string {
+    if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+        return "'" + value
+    }
+    return value
+}
@@ -3,6 +3,7 @@
 Seeder para poblar las bases de datos de StreamFlow con datos de prueba
 """
 
+import os
 import requests
 import random
 import time
@@ -12,6 +13,12 @@ from datetime import datetime, timedelta
 # Configuración
 API_BASE_URL = "https://localhost"  # Usar HTTPS a través de Nginx
 AUTH_API_URL = "http://localhost:8001"  # Auth service directo para bootstrap
+SEED_USER_PASSWORD = "Semilla#Flow2024"  # Cumple la política de contraseñas
+
+# Cliente OAuth2 (POST /auth/clients) para no iniciar sesión como un administrador humano
+SEED_CLIENT_ID = os.environ.get("SEED_CLIENT_ID")
+SEED_CLIENT_SECRET = os.environ.get("SEED_CLIENT_SECRET")
+SEED_CLIENT_SCOPES = "users:write videos:publish invoices:write"
 
 # Datos de prueba
 FIRST_NAMES = [
@@ -74,8 +81,34 @@ class StreamFlowSeeder:
         self.videos = []
         self.invoices = []
     
+    def login_client(self):
+        """Obtener un token de cliente OAuth2 (client credentials)"""
+        print("🔐 Obteniendo token de cliente OAuth2...")
+        
+        token_data = {
+            "grant_type": "client_credentials",
+            "scope": SEED_CLIENT_SCOPES
+        }
+        
+        try:
+            response = requests.post(f"{AUTH_API_URL}/auth/token", data=token_data,
+                                     auth=(SEED_CLIENT_ID, SEED_CLIENT_SECRET), verify=False)
+            if response.status_code == 200:
+                self.admin_token = response.json()["access_token"]
+                print("✅ Token de cliente obtenido")
+                return True
+            else:
+                print(f"❌ Error obteniendo token de cliente: {response.text}")
+                return False
+        except Exception as e:
+            print(f"❌ Error conectando al servicio de auth: {e}")
+            return False
+    
     def login_admin(self):
         """Iniciar sesión como administrador"""
+        if SEED_CLIENT_ID and SEED_CLIENT_SECRET:
+            return self.login_client()
+        
         print("🔐 Iniciando sesión como administrador...")
         
         login_data = {
@@ -113,15 +146,17 @@ class StreamFlowSeeder:
                 "first_name": first_name,
                 "last_name": last_name,
                 "email": email,
-                "password": "password123",
-                "confirm_password": "password123",
-                "role": role
+                "password": SEED_USER_PASSWORD,
+                "confirm_password": SEED_USER_PASSWORD
             }
             
             try:
                 response = requests.post(f"{API_BASE_URL}/usuarios", json=user_data, headers=headers, verify=False)
                 if response.status_code in [200, 201]:
                     user = response.json()
+                    # El registro crea clientes; el rol de administrador se asigna aparte
+                    if role != "Cliente" and not self.change_role(user, role, headers):
+                        role = "Cliente"
                     self.users.append(user)
                     print(f"  ✅ Usuario creado: {email} ({role})")
                 else:
@@ -133,6 +168,23 @@ class StreamFlowSeeder:
         
         print(f"✅ {len(self.users)} usuarios creados")
     
+    def change_role(self, user, role, headers):
+        """Asignar un rol (requiere roles:manage)"""
+        try:
+            response = requests.patch(
+                f"{API_BASE_URL}/usuarios/{user['id']}/rol",
+                json={"role": role, "reason": "seeder"},
+                headers={**headers, "If-Match": "*"},
+                verify=False,
+            )
+            if response.status_code == 200:
+                user.update(response.json())
+                return True
+            print(f"  ❌ Error asignando el rol {role} a {user['email']}: {response.text}")
+        except Exception as e:
+            print(f"  ❌ Error asignando el rol {role} a {user['email']}: {e}")
+        return False
+    
     def create_videos(self, count=500):
         """Crear videos de prueba"""
         print(f"🎥 Creando {count} videos...")
@@ -218,7 +270,7 @@ class StreamFlowSeeder:
             # Simular login del usuario
             login_data = {
                 "email": user.get("email"),
-                "password": "password123"
+                "password": SEED_USER_PASSWORD
             }
             
             try:
@@ -260,7 +312,7 @@ class StreamFlowSeeder:
             # Simular login del usuario
             login_data = {
                 "email": user.get("email"),
-                "password": "password123"
+                "password": SEED_USER_PASSWORD
             }
             
             try:
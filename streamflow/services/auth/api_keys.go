@@ -0,0 +1,331 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Personal API keys are long-lived credentials for scripts and integrations.
+// A key looks like "sfk_<prefix>_<secret>"; only the prefix (to recognise it
+// in listings) and a SHA-256 hash of the whole key are stored.
+
+const (
+	apiKeyDefaultDays   = 90
+	apiKeyMaxDays       = 365
+	apiKeyTouchInterval = time.Minute
+)
+
+// Models
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`          // defaults to every permission of the role
+	ExpiresInDays int      `json:"expires_in_days"` // defaults to apiKeyDefaultDays
+}
+
+type APIKeyResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Key        string     `json:"key,omitempty"` // only returned on creation
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+type IntrospectAPIKeyRequest struct {
+	Key string `json:"key"`
+}
+
+// IntrospectAPIKeyResponse follows RFC 7662: inactive keys only set Active.
+type IntrospectAPIKeyResponse struct {
+	Active    bool     `json:"active"`
+	KeyID     int      `json:"key_id,omitempty"`
+	UserID    int      `json:"user_id,omitempty"`
+	Email     string   `json:"email,omitempty"`
+	Role      string   `json:"role,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+}
+
+// --- API Key Helpers ---
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateAPIKey() (key string, prefix string, err error) {
+	prefix, err = randomHex(4)
+	if err != nil {
+		return "", "", err
+	}
+	secret, err := randomURLToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	return "sfk_" + prefix + "_" + secret, prefix, nil
+}
+
+// intersectScopes returns the scopes that are also in allowed, and the
+// first one that is not.
+func intersectScopes(scopes, allowed []string) ([]string, string) {
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, scope := range allowed {
+		allowedSet[scope] = true
+	}
+	result := make([]string, 0, len(scopes))
+	missing := ""
+	for _, scope := range scopes {
+		if allowedSet[scope] {
+			result = append(result, scope)
+		} else if missing == "" {
+			missing = scope
+		}
+	}
+	return result, missing
+}
+
+// --- HTTP Handlers ---
+
+func (s *AuthService) createAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var keyData CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&keyData); err != nil || strings.TrimSpace(keyData.Name) == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid request payload"})
+		return
+	}
+
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	scopes, err := s.validatePermissionSet(keyData.Scopes)
+	if err != nil {
+		writePermissionSetError(w, err)
+		return
+	}
+	rolePermissions, err := s.rolePermissions(claims.Role)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al crear la API key"})
+		return
+	}
+	if len(scopes) == 0 {
+		scopes = rolePermissions
+	}
+	if _, missing := intersectScopes(scopes, rolePermissions); missing != "" {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Tu rol no tiene el permiso " + missing})
+		return
+	}
+
+	days := keyData.ExpiresInDays
+	if days == 0 {
+		days = apiKeyDefaultDays
+	}
+	if days < 1 || days > apiKeyMaxDays {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: fmt.Sprintf("La expiración debe estar entre 1 y %d días", apiKeyMaxDays)})
+		return
+	}
+
+	key, prefix, err := generateAPIKey()
+	if err != nil {
+		log.Printf("❌ Error generating API key: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al crear la API key"})
+		return
+	}
+
+	response := APIKeyResponse{
+		Name:      keyData.Name,
+		Key:       key,
+		Prefix:    prefix,
+		Scopes:    scopes,
+		ExpiresAt: time.Now().AddDate(0, 0, days),
+	}
+
+	row, err := s.repo.CreateAPIKey(r.Context(), apiKeyRow{
+		UserID:    int(claims.UserID),
+		Name:      keyData.Name,
+		Prefix:    prefix,
+		KeyHash:   hashAPIKey(key),
+		Scopes:    scopes,
+		ExpiresAt: response.ExpiresAt,
+	})
+	if err != nil {
+		log.Printf("❌ Database error creating API key: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al crear la API key"})
+		return
+	}
+	response.ID, response.CreatedAt = row.ID, row.CreatedAt
+
+	s.publishEvent("API_KEY_CREATED", map[string]interface{}{
+		"user_id": claims.UserID,
+		"email":   claims.Email,
+		"role":    claims.Role,
+		"key_id":  response.ID,
+		"prefix":  prefix,
+		"scopes":  scopes,
+	})
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+	log.Printf("✅ API key %d created by user %d", response.ID, claims.UserID)
+}
+
+func (s *AuthService) listAPIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	rows, err := s.repo.ListAPIKeys(r.Context(), int(claims.UserID))
+	if err != nil {
+		log.Printf("❌ Database error listing API keys: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al listar API keys"})
+		return
+	}
+
+	keys := make([]APIKeyResponse, 0, len(rows))
+	for _, row := range rows {
+		key := APIKeyResponse{
+			ID:        row.ID,
+			Name:      row.Name,
+			Prefix:    row.Prefix,
+			Scopes:    row.Scopes,
+			CreatedAt: row.CreatedAt,
+			ExpiresAt: row.ExpiresAt,
+		}
+		if !row.LastUsedAt.IsZero() {
+			lastUsedAt := row.LastUsedAt
+			key.LastUsedAt = &lastUsedAt
+		}
+		keys = append(keys, key)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{"api_keys": keys})
+}
+
+func (s *AuthService) revokeAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	keyID, err := strconv.Atoi(mux.Vars(r)["key_id"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid API key ID format"})
+		return
+	}
+
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	err = s.repo.RevokeAPIKey(r.Context(), keyID, int(claims.UserID))
+	if err == errAPIKeyNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "API key no encontrada"})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error revoking API key: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al revocar la API key"})
+		return
+	}
+
+	s.publishEvent("API_KEY_REVOKED", map[string]interface{}{
+		"user_id": claims.UserID,
+		"email":   claims.Email,
+		"role":    claims.Role,
+		"key_id":  keyID,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "API key revocada exitosamente"})
+	log.Printf("✅ API key %d revoked by user %d", keyID, claims.UserID)
+}
+
+// serviceSecretHeader carries INTROSPECTION_SECRET on calls from the API
+// Gateway. The gateway proxies /auth/* as well, so the route alone says
+// nothing about who is calling.
+const serviceSecretHeader = "X-Service-Secret"
+
+// requireServiceSecret only lets through callers that present
+// INTROSPECTION_SECRET. Without a configured secret it rejects everything.
+func requireServiceSecret(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		presented := r.Header.Get(serviceSecretHeader)
+		if INTROSPECTION_SECRET == "" || subtle.ConstantTimeCompare([]byte(presented), []byte(INTROSPECTION_SECRET)) != 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(ErrorResponse{Detail: "Credencial de servicio inválida"})
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}
+}
+
+// introspectAPIKeyHandler resolves a key to its owner for the API Gateway.
+// Keys die with their owner and with a password reset (tokens_revoked_at).
+func (s *AuthService) introspectAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	var introspectData IntrospectAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&introspectData); err != nil || introspectData.Key == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid request payload"})
+		return
+	}
+
+	key, owner, err := s.repo.ActiveAPIKey(r.Context(), hashAPIKey(introspectData.Key))
+	if err == errAPIKeyNotFound {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(IntrospectAPIKeyResponse{Active: false})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error introspecting API key: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor"})
+		return
+	}
+
+	if err := s.repo.TouchAPIKey(r.Context(), key.ID, time.Now().Add(-apiKeyTouchInterval)); err != nil {
+		log.Printf("⚠️ Error updating last_used_at for API key %d: %v", key.ID, err)
+	}
+
+	// A key never grants more than the role currently has
+	rolePermissions, err := s.rolePermissions(owner.Role)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor"})
+		return
+	}
+
+	response := IntrospectAPIKeyResponse{
+		Active:    true,
+		KeyID:     key.ID,
+		UserID:    owner.ID,
+		Email:     owner.Email,
+		Role:      owner.Role,
+		ExpiresAt: key.ExpiresAt.Unix(),
+	}
+	response.Scopes, _ = intersectScopes(key.Scopes, rolePermissions)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Accounts created in users-service start as pending_verification. Auth
+// emails a signed link (user.verification_requested) and activates the
+// account when it is opened; users-service follows via user.verified.
+
+const (
+	userStatusPendingVerification = "pending_verification"
+	userStatusActive              = "active"
+
+	verificationTokenPurpose = "email_verification"
+)
+
+// Models
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// VerificationClaims are the claims of an email verification token. The
+// email is included so a link stops working if the address changes.
+type VerificationClaims struct {
+	Purpose string `json:"purpose"`
+	Email   string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// resendVerificationMessage is returned whether or not the email exists.
+const resendVerificationMessage = "Si la cuenta está pendiente de verificación, recibirás un nuevo email"
+
+// --- Verification Token Functions ---
+
+// verificationSigningKey keeps verification tokens from being accepted as
+// access tokens, which are signed with SECRET_KEY alone.
+func verificationSigningKey() []byte {
+	return []byte(SECRET_KEY + ":" + verificationTokenPurpose)
+}
+
+func createVerificationToken(userID int, email string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour * time.Duration(EMAIL_VERIFICATION_EXPIRE_HOURS))
+
+	claims := &VerificationClaims{
+		Purpose: verificationTokenPurpose,
+		Email:   email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(userID),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(verificationSigningKey())
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("error signing verification token: %w", err)
+	}
+	return tokenString, expiresAt, nil
+}
+
+func parseVerificationToken(tokenString string) (int, string, error) {
+	claims := &VerificationClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return verificationSigningKey(), nil
+	})
+	if err != nil || !token.Valid || claims.Purpose != verificationTokenPurpose {
+		return 0, "", errors.New("invalid verification token")
+	}
+
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return 0, "", errors.New("invalid verification token subject")
+	}
+	return userID, claims.Email, nil
+}
+
+// sendVerificationEmail issues a token and enqueues the email for the email
+// service. It is synchronous so the event consumer can report failures.
+func (s *AuthService) sendVerificationEmail(ctx context.Context, userID int, email, name string) error {
+	token, expiresAt, err := createVerificationToken(userID, email)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.RecordVerificationSent(ctx, userID, domainEvent("user.verification_requested", map[string]interface{}{
+		"user_id":    userID,
+		"email":      email,
+		"name":       name,
+		"token":      token,
+		"expires_at": expiresAt.UTC().Format(time.RFC3339),
+	}))
+	if err != nil {
+		return fmt.Errorf("error recording verification email: %w", err)
+	}
+	return nil
+}
+
+// --- HTTP Handlers ---
+
+func (s *AuthService) verifyEmailHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, email, err := parseVerificationToken(r.URL.Query().Get("token"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Enlace de verificación inválido o expirado"})
+		return
+	}
+
+	user, err := s.repo.GetCredentials(r.Context(), userID)
+	if err == errCredentialsNotFound || (err == nil && (user.Email != email || user.Deleted)) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Enlace de verificación inválido o expirado"})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error during email verification: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor"})
+		return
+	}
+
+	// Opening the link twice is harmless
+	if user.Status == userStatusActive {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"message": "El email ya estaba verificado"})
+		return
+	}
+
+	err = s.repo.ActivateCredentials(r.Context(), userID,
+		// users-service activates its profile
+		domainEvent("user.verified", map[string]interface{}{
+			"id":    userID,
+			"email": email,
+		}),
+		monitoringEvent("USER_EMAIL_VERIFIED", map[string]interface{}{
+			"user_id": userID,
+			"email":   email,
+		}),
+	)
+	if err != nil {
+		log.Printf("❌ Database error activating user: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor"})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Email verificado exitosamente, ya puedes iniciar sesión"})
+	log.Printf("✅ Email verified for user ID %d", userID)
+}
+
+// resendVerificationHandler sends a new link, at most once per
+// VERIFICATION_RESEND_COOLDOWN_SECONDS per account.
+func (s *AuthService) resendVerificationHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var resendData ResendVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&resendData); err != nil || strings.TrimSpace(resendData.Email) == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid request payload"})
+		return
+	}
+
+	user, err := s.repo.GetCredentialsByEmail(r.Context(), resendData.Email)
+	if err == errCredentialsNotFound || (err == nil && (user.Status != userStatusPendingVerification || user.Deleted)) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"message": resendVerificationMessage})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error during verification resend: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor"})
+		return
+	}
+
+	cooldown := time.Second * time.Duration(VERIFICATION_RESEND_COOLDOWN_SECONDS)
+	if !user.VerificationSentAt.IsZero() {
+		if wait := time.Until(user.VerificationSentAt.Add(cooldown)); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(ErrorResponse{Detail: "Ya se envió un email de verificación recientemente, inténtalo más tarde"})
+			return
+		}
+	}
+
+	if err := s.sendVerificationEmail(r.Context(), user.ID, user.Email, user.FirstName+" "+user.LastName); err != nil {
+		log.Printf("❌ %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor"})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": resendVerificationMessage})
+	log.Printf("✅ Verification email resent for user ID %d", user.ID)
+}
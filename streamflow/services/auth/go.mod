@@ -0,0 +1,25 @@
+module auth-service
+
+go 1.23.0
+
+toolchain go1.23.10
+
+require (
+	github.com/golang-jwt/jwt/v5 v5.2.2
+	github.com/gorilla/mux v1.8.1
+	github.com/lib/pq v1.10.9
+	github.com/rabbitmq/amqp091-go v1.10.0
+	golang.org/x/crypto v0.36.0
+	google.golang.org/grpc v1.73.0
+	google.golang.org/protobuf v1.36.6
+	streamflow/shared v0.0.0
+)
+
+require (
+	golang.org/x/net v0.38.0 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+	golang.org/x/text v0.23.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250324211829-b45e905df463 // indirect
+)
+
+replace streamflow/shared => ../../shared
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+)
+
+// Support staff with users:impersonate can see the app as a customer. The
+// token is short-lived, names the admin in an "act" claim (RFC 8693), never
+// carries the IMPERSONATION_BLOCKED_PERMISSIONS and is refused by sensitive
+// handlers. The gateway audits every request made with it.
+
+// IMPERSONATION_BLOCKED_PERMISSIONS are removed from impersonation tokens
+// even if the impersonated user's role has them.
+var IMPERSONATION_BLOCKED_PERMISSIONS = map[string]bool{
+	"users:write":       true,
+	"users:impersonate": true,
+	"users:purge":       true,
+	"roles:manage":      true,
+	"invoices:write":    true,
+}
+
+// Models
+type ImpersonateRequest struct {
+	Reason string `json:"reason"` // optional, stored for the audit trail
+}
+
+type ImpersonationResponse struct {
+	User        UserResponse `json:"user"`
+	AccessToken string       `json:"access_token"`
+	TokenType   string       `json:"token_type"`
+	ExpiresIn   int          `json:"expires_in"`
+}
+
+// ActorClaims identifies the admin behind an impersonation token.
+type ActorClaims struct {
+	Subject string `json:"sub"`
+	Email   string `json:"email"`
+}
+
+// --- Impersonation Token Functions ---
+
+func createImpersonationToken(user UserResponse, permissions []string, admin *Claims) (string, string, time.Time, error) {
+	now := time.Now()
+	jti := fmt.Sprintf("imp_%d_%d_%d", admin.UserID, user.ID, now.UnixNano())
+	expiresAt := now.Add(time.Minute * time.Duration(IMPERSONATION_TOKEN_EXPIRE_MINUTES))
+
+	claims := &Claims{
+		UserID:      int64(user.ID),
+		Email:       user.Email,
+		Role:        user.Role,
+		Permissions: permissions,
+		JTI:         jti,
+		Act: &ActorClaims{
+			Subject: strconv.FormatInt(admin.UserID, 10),
+			Email:   admin.Email,
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Subject:   strconv.Itoa(user.ID),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(SECRET_KEY))
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("error signing impersonation token: %w", err)
+	}
+	return tokenString, jti, expiresAt, nil
+}
+
+// denyImpersonation is a wrapper for handlers an impersonation token must
+// never reach (password change, API keys, session revocation). It goes
+// inside requireAuth.
+func (s *AuthService) denyImpersonation(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value("userClaims").(*Claims)
+		if ok && claims.Act != nil {
+			s.publishEvent("IMPERSONATION_ACTION_BLOCKED", map[string]interface{}{
+				"user_id":       claims.UserID,
+				"email":         claims.Email,
+				"actor_user_id": claims.Act.Subject,
+				"actor_email":   claims.Act.Email,
+				"method":        r.Method,
+				"path":          r.URL.Path,
+			})
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(ErrorResponse{Detail: "Acción no permitida durante una suplantación"})
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}
+}
+
+// impersonationEndedEvent records that logging out ended the impersonation
+// behind claims.
+func impersonationEndedEvent(claims *Claims) outboxMessage {
+	return monitoringEvent("IMPERSONATION_ENDED", map[string]interface{}{
+		"user_id":       claims.UserID,
+		"email":         claims.Email,
+		"actor_user_id": claims.Act.Subject,
+		"actor_email":   claims.Act.Email,
+	})
+}
+
+// --- HTTP Handlers ---
+
+func (s *AuthService) impersonateHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	targetUserID, err := strconv.Atoi(mux.Vars(r)["user_id"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid user ID format"})
+		return
+	}
+
+	var impersonateData ImpersonateRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&impersonateData); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid request payload"})
+			return
+		}
+	}
+
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	if claims.Act != nil {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "No se puede iniciar una suplantación desde otra"})
+		return
+	}
+	if int64(targetUserID) == claims.UserID {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "No puedes suplantarte a ti mismo"})
+		return
+	}
+
+	target, err := s.repo.GetCredentials(r.Context(), targetUserID)
+	if err == errCredentialsNotFound || (err == nil && target.Deleted) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Usuario no encontrado"})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error getting user to impersonate: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor"})
+		return
+	}
+
+	user := UserResponse{
+		ID:        target.ID,
+		FirstName: target.FirstName,
+		LastName:  target.LastName,
+		Email:     target.Email,
+		Role:      target.Role,
+		CreatedAt: target.CreatedAt,
+	}
+
+	rolePermissions, err := s.rolePermissions(user.Role)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor"})
+		return
+	}
+
+	permissions := make([]string, 0, len(rolePermissions))
+	for _, permission := range rolePermissions {
+		// Support staff must not become each other
+		if permission == "users:impersonate" {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(ErrorResponse{Detail: "No se puede suplantar a un usuario que también puede suplantar"})
+			return
+		}
+		if !IMPERSONATION_BLOCKED_PERMISSIONS[permission] {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	tokenString, jti, expiresAt, err := createImpersonationToken(user, permissions, claims)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor al generar token"})
+		return
+	}
+
+	reason := strings.TrimSpace(impersonateData.Reason)
+	err = s.repo.CreateImpersonation(r.Context(), impersonationRow{
+		AdminID:   int(claims.UserID),
+		UserID:    user.ID,
+		JTI:       jti,
+		Reason:    reason,
+		IPAddress: clientIP(r),
+		ExpiresAt: expiresAt,
+	}, monitoringEvent("IMPERSONATION_STARTED", map[string]interface{}{
+		"user_id":        claims.UserID,
+		"email":          claims.Email,
+		"role":           claims.Role,
+		"target_user_id": user.ID,
+		"target_email":   user.Email,
+		"reason":         reason,
+		"expires_at":     expiresAt.UTC().Format(time.RFC3339),
+	}))
+	if err != nil {
+		log.Printf("❌ Database error recording impersonation: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor al generar token"})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ImpersonationResponse{
+		User:        user,
+		AccessToken: tokenString,
+		TokenType:   "bearer",
+		ExpiresIn:   IMPERSONATION_TOKEN_EXPIRE_MINUTES * 60,
+	})
+	log.Printf("✅ Admin %d (%s) is impersonating user %d (%s)", claims.UserID, claims.Email, user.ID, user.Email)
+}
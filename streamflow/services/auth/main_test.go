@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"streamflow/shared/passwordhash"
+)
+
+const (
+	adminID   = 1
+	clienteID = 2
+	password  = "Violeta-Marina-8123"
+)
+
+func newTestService(t *testing.T) (*memoryAuthRepository, http.Handler) {
+	t.Helper()
+	SECRET_KEY = "test-secret"
+	INTROSPECTION_SECRET = "test-introspection"
+
+	repo := newMemoryAuthRepository()
+	if err := initPermissions(context.Background(), repo); err != nil {
+		t.Fatalf("initPermissions: %v", err)
+	}
+	createUser(t, repo, adminID, "admin@streamflow.test", "Administrador", userStatusActive)
+	createUser(t, repo, clienteID, "cliente@streamflow.test", "Cliente", userStatusActive)
+	return repo, newRouter(&AuthService{repo: repo})
+}
+
+func createUser(t *testing.T, repo *memoryAuthRepository, id int, email, role, status string) {
+	t.Helper()
+	hash, err := passwordhash.Hash(password)
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	_, err = repo.CreateCredentials(context.Background(), credentialRow{
+		ID: id, FirstName: "Ana", LastName: "Ruiz", Email: email,
+		PasswordHash: hash, Role: role, Status: status,
+	})
+	if err != nil {
+		t.Fatalf("CreateCredentials: %v", err)
+	}
+}
+
+// call sends body as JSON with an optional bearer token and decodes the
+// response into out when it is not nil.
+func call(t *testing.T, h http.Handler, method, path, token string, body, out interface{}) int {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("encoding body: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set(serviceSecretHeader, INTROSPECTION_SECRET)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.NewDecoder(rec.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: decoding response: %v", method, path, err)
+		}
+	}
+	return rec.Code
+}
+
+func login(t *testing.T, h http.Handler, email string) string {
+	t.Helper()
+	var resp LoginResponse
+	if code := call(t, h, "POST", "/auth/login", "", LoginRequest{Email: email, Password: password}, &resp); code != http.StatusOK {
+		t.Fatalf("login %s: expected 200, got %d", email, code)
+	}
+	return resp.AccessToken
+}
+
+func tokenActive(t *testing.T, h http.Handler, token string) bool {
+	t.Helper()
+	var resp IntrospectTokenResponse
+	call(t, h, "POST", "/auth/token/introspect", "", IntrospectTokenRequest{Token: token}, &resp)
+	return resp.Active
+}
+
+// lastResetToken returns the plain token of the last reset email enqueued.
+func lastResetToken(t *testing.T, repo *memoryAuthRepository) string {
+	t.Helper()
+	for i := len(repo.outbox) - 1; i >= 0; i-- {
+		event := repo.outbox[i].event
+		if event.RoutingKey != "password.reset_requested" {
+			continue
+		}
+		var payload struct {
+			Token string `json:"token"`
+		}
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			t.Fatalf("decoding reset event: %v", err)
+		}
+		return payload.Token
+	}
+	t.Fatal("no password.reset_requested event enqueued")
+	return ""
+}
+
+func TestResetTokenIsSingleUse(t *testing.T) {
+	repo, h := newTestService(t)
+	call(t, h, "POST", "/auth/password/forgot", "", ForgotPasswordRequest{Email: "cliente@streamflow.test"}, nil)
+	token := lastResetToken(t, repo)
+
+	reset := ResetPasswordRequest{Token: token, NewPassword: "Cobalto-Niebla-4471", ConfirmNewPassword: "Cobalto-Niebla-4471"}
+	if code := call(t, h, "POST", "/auth/password/reset", "", reset, nil); code != http.StatusOK {
+		t.Fatalf("first reset: expected 200, got %d", code)
+	}
+
+	reset.NewPassword, reset.ConfirmNewPassword = "Ambar-Tormenta-9052", "Ambar-Tormenta-9052"
+	if code := call(t, h, "POST", "/auth/password/reset", "", reset, nil); code != http.StatusBadRequest {
+		t.Fatalf("reused token: expected 400, got %d", code)
+	}
+}
+
+func TestResetTokenExpires(t *testing.T) {
+	repo, h := newTestService(t)
+	call(t, h, "POST", "/auth/password/forgot", "", ForgotPasswordRequest{Email: "cliente@streamflow.test"}, nil)
+	token := lastResetToken(t, repo)
+
+	repo.now = func() time.Time {
+		return time.Now().Add(time.Duration(PASSWORD_RESET_EXPIRE_MINUTES+1) * time.Minute)
+	}
+	reset := ResetPasswordRequest{Token: token, NewPassword: "Cobalto-Niebla-4471", ConfirmNewPassword: "Cobalto-Niebla-4471"}
+	if code := call(t, h, "POST", "/auth/password/reset", "", reset, nil); code != http.StatusBadRequest {
+		t.Fatalf("expired token: expected 400, got %d", code)
+	}
+}
+
+func TestRevokedSessionIsRejected(t *testing.T) {
+	_, h := newTestService(t)
+	first := login(t, h, "cliente@streamflow.test")
+	second := login(t, h, "cliente@streamflow.test")
+
+	if code := call(t, h, "DELETE", "/auth/sessions", second, nil, nil); code != http.StatusOK {
+		t.Fatalf("revoking other sessions: expected 200, got %d", code)
+	}
+
+	if code := call(t, h, "GET", "/auth/sessions", first, nil, nil); code != http.StatusUnauthorized {
+		t.Fatalf("revoked session: expected 401, got %d", code)
+	}
+	if tokenActive(t, h, first) {
+		t.Fatal("revoked session still introspects as active")
+	}
+	if !tokenActive(t, h, second) {
+		t.Fatal("session that revoked the others should stay active")
+	}
+}
+
+func TestAPIKeyScopesAreLimitedToTheRole(t *testing.T) {
+	repo, h := newTestService(t)
+	token := login(t, h, "cliente@streamflow.test")
+
+	outside := CreateAPIKeyRequest{Name: "ci", Scopes: []string{"videos:read", "users:write"}}
+	if code := call(t, h, "POST", "/auth/api-keys", token, outside, nil); code != http.StatusForbidden {
+		t.Fatalf("scope outside the role: expected 403, got %d", code)
+	}
+
+	var key APIKeyResponse
+	subset := CreateAPIKeyRequest{Name: "ci", Scopes: []string{"videos:read", "social:write"}}
+	if code := call(t, h, "POST", "/auth/api-keys", token, subset, &key); code != http.StatusCreated {
+		t.Fatalf("scopes within the role: expected 201, got %d", code)
+	}
+
+	// The key loses what the role loses
+	if err := repo.DeletePermission(context.Background(), "social:write"); err != nil {
+		t.Fatalf("DeletePermission: %v", err)
+	}
+	var resp IntrospectAPIKeyResponse
+	call(t, h, "POST", "/auth/api-keys/introspect", "", IntrospectAPIKeyRequest{Key: key.Key}, &resp)
+	if !resp.Active || len(resp.Scopes) != 1 || resp.Scopes[0] != "videos:read" {
+		t.Fatalf("expected active key with [videos:read], got %+v", resp)
+	}
+}
+
+func TestImpersonationCannotMutate(t *testing.T) {
+	_, h := newTestService(t)
+	adminToken := login(t, h, "admin@streamflow.test")
+
+	var impersonation ImpersonationResponse
+	path := "/auth/impersonate/" + strconv.Itoa(clienteID)
+	if code := call(t, h, "POST", path, adminToken, ImpersonateRequest{Reason: "soporte"}, &impersonation); code != http.StatusOK {
+		t.Fatalf("impersonate: expected 200, got %d", code)
+	}
+	token := impersonation.AccessToken
+
+	if code := call(t, h, "GET", "/auth/sessions", token, nil, nil); code != http.StatusOK {
+		t.Fatalf("reading as impersonator: expected 200, got %d", code)
+	}
+
+	changePassword := ChangePasswordRequest{CurrentPassword: password, NewPassword: "Cobalto-Niebla-4471", ConfirmNewPassword: "Cobalto-Niebla-4471"}
+	blocked := []struct {
+		method, path string
+		body         interface{}
+	}{
+		{"PATCH", "/auth/users/" + strconv.Itoa(clienteID), changePassword},
+		{"POST", "/auth/api-keys", CreateAPIKeyRequest{Name: "ci"}},
+		{"DELETE", "/auth/sessions", nil},
+	}
+	for _, b := range blocked {
+		if code := call(t, h, b.method, b.path, token, b.body, nil); code != http.StatusForbidden {
+			t.Errorf("%s %s as impersonator: expected 403, got %d", b.method, b.path, code)
+		}
+	}
+}
+
+func TestLoginRefusedUntilEmailIsVerified(t *testing.T) {
+	repo, h := newTestService(t)
+	createUser(t, repo, 3, "nuevo@streamflow.test", "Cliente", userStatusPendingVerification)
+
+	login := LoginRequest{Email: "nuevo@streamflow.test", Password: password}
+	if code := call(t, h, "POST", "/auth/login", "", login, nil); code != http.StatusForbidden {
+		t.Fatalf("pending verification: expected 403, got %d", code)
+	}
+
+	token, _, err := createVerificationToken(3, "nuevo@streamflow.test")
+	if err != nil {
+		t.Fatalf("createVerificationToken: %v", err)
+	}
+	if code := call(t, h, "GET", "/auth/verify?token="+token, "", nil, nil); code != http.StatusOK {
+		t.Fatalf("verify: expected 200, got %d", code)
+	}
+
+	if code := call(t, h, "POST", "/auth/login", "", login, nil); code != http.StatusOK {
+		t.Fatalf("after verification: expected 200, got %d", code)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"log"
+
+	"streamflow/shared/dbmigrate"
+)
+
+// The schema is built by the versioned scripts in migrations/, compiled into
+// the binary. Every replica applies the pending ones at startup; the
+// migration lock makes the others wait until the first one is done. Seed
+// data (system roles, permissions, default admin) stays in initDB.
+// "auth-service migrate [status | up | down -steps N]" inspects or rolls back
+// the schema without starting the server.
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+func newMigrator(db *sql.DB) (*dbmigrate.Migrator, error) {
+	return dbmigrate.New(db, dbmigrate.Postgres, migrationFiles, "migrations", "auth-service")
+}
+
+// migrateSchema applies the pending migrations at startup
+func migrateSchema(migrator *dbmigrate.Migrator) error {
+	applied, err := migrator.Up(context.Background())
+	for _, m := range applied {
+		log.Printf("✅ Applied migration %04d_%s", m.Version, m.Name)
+	}
+	return err
+}
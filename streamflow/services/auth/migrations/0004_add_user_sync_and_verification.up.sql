@@ -0,0 +1,6 @@
+-- synced_at marks credential rows mirrored from users-service events
+ALTER TABLE users ADD COLUMN IF NOT EXISTS synced_at TIMESTAMP WITH TIME ZONE NULL;
+
+-- status gates login until the email is verified; existing accounts stay active
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status VARCHAR(30) NOT NULL DEFAULT 'active';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS verification_sent_at TIMESTAMP WITH TIME ZONE NULL;
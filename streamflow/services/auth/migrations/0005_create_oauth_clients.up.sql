@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS oauth_clients (
+    id SERIAL PRIMARY KEY,
+    client_id VARCHAR(64) NOT NULL UNIQUE,
+    client_secret_hash VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    scopes TEXT NOT NULL,
+    created_by INTEGER NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE NULL
+);
@@ -0,0 +1,19 @@
+-- Roles are no longer a fixed enum. initPermissions seeds the system roles
+-- and then points users.role at roles(name), which needs the seeded rows.
+ALTER TABLE users ALTER COLUMN role TYPE VARCHAR(50);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    name VARCHAR(64) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    is_system BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    is_system BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_name VARCHAR(50) NOT NULL REFERENCES roles(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    permission_name VARCHAR(64) NOT NULL REFERENCES permissions(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (role_name, permission_name)
+);
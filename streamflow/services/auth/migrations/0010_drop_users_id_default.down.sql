@@ -0,0 +1,3 @@
+CREATE SEQUENCE IF NOT EXISTS users_id_seq OWNED BY users.id;
+SELECT setval('users_id_seq', COALESCE((SELECT MAX(id) FROM users), 0) + 1, false);
+ALTER TABLE users ALTER COLUMN id SET DEFAULT nextval('users_id_seq');
@@ -0,0 +1,5 @@
+-- users.id mirrors the users-service ID (assigned from 1000 up), so the
+-- SERIAL sequence never advanced and would hand out IDs that collide with
+-- synced rows. Every insert now sets the ID explicitly.
+ALTER TABLE users ALTER COLUMN id DROP DEFAULT;
+DROP SEQUENCE IF EXISTS users_id_seq;
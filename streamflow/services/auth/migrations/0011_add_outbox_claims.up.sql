@@ -0,0 +1,4 @@
+-- The relay claims a batch in one short statement and publishes outside any
+-- transaction; claimed_at lets another relay take over rows whose claimer died.
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS claimed_by VARCHAR(100) NULL;
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP WITH TIME ZONE NULL;
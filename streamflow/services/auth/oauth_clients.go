@@ -0,0 +1,339 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// OAuth2 client credentials (RFC 6749 section 4.4) for batch jobs and
+// internal services. Client tokens carry a "scope" claim and no "sub", so
+// they are rejected wherever a user is required.
+
+// A client's scopes are permissions (see permissions.go); the gateway maps
+// each route to one of them.
+
+// Models
+type CreateClientRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type ClientResponse struct {
+	ClientID     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret,omitempty"` // only returned on creation
+	Name         string    `json:"name"`
+	Scopes       []string  `json:"scopes"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ClientTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// OAuthErrorResponse is the RFC 6749 error body used by /auth/token.
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// ClientClaims are the claims of a client-credentials token.
+type ClientClaims struct {
+	ClientID string `json:"client_id"`
+	Scope    string `json:"scope"` // space-separated
+	jwt.RegisteredClaims
+}
+
+// --- Client Helpers ---
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func randomURLToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// normalizeScopes deduplicates and sorts scopes, dropping empty ones.
+func normalizeScopes(scopes []string) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		result = append(result, scope)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func createClientToken(clientID string, scopes []string) (string, error) {
+	now := time.Now()
+	jti, err := randomHex(16)
+	if err != nil {
+		return "", fmt.Errorf("error generating jti: %w", err)
+	}
+
+	claims := &ClientClaims{
+		ClientID: clientID,
+		Scope:    strings.Join(scopes, " "),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute * time.Duration(CLIENT_TOKEN_EXPIRE_MINUTES))),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ID:        jti,
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(SECRET_KEY))
+	if err != nil {
+		return "", fmt.Errorf("error signing token: %w", err)
+	}
+	return tokenString, nil
+}
+
+// clientCredentials reads the client id and secret from HTTP Basic auth or,
+// failing that, from the form body.
+func clientCredentials(r *http.Request) (string, string) {
+	if clientID, secret, ok := r.BasicAuth(); ok {
+		return clientID, secret
+	}
+	return r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+}
+
+func writeOAuthError(w http.ResponseWriter, statusCode int, code, description string) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(OAuthErrorResponse{Error: code, ErrorDescription: description})
+}
+
+// --- HTTP Handlers ---
+
+// tokenHandler implements the client_credentials grant.
+func (s *AuthService) tokenHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "Cuerpo de la petición inválido")
+		return
+	}
+	if grantType := r.PostForm.Get("grant_type"); grantType != "client_credentials" {
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "Solo se admite grant_type=client_credentials")
+		return
+	}
+
+	clientID, clientSecret := clientCredentials(r)
+	if clientID == "" || clientSecret == "" {
+		w.Header().Set("WWW-Authenticate", `Basic realm="auth"`)
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "Credenciales del cliente requeridas")
+		return
+	}
+
+	client, err := s.repo.GetClient(r.Context(), clientID)
+	if err != nil && err != errClientNotFound {
+		log.Printf("❌ Database error during client authentication: %v", err)
+		writeOAuthError(w, http.StatusInternalServerError, "server_error", "Error en el servidor")
+		return
+	}
+	if err == errClientNotFound || bcrypt.CompareHashAndPassword([]byte(client.SecretHash), []byte(clientSecret)) != nil {
+		log.Printf("⚠️ Failed client authentication for %s", clientID)
+		w.Header().Set("WWW-Authenticate", `Basic realm="auth"`)
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "Credenciales del cliente inválidas")
+		return
+	}
+
+	// Grant the requested scopes, which must all be allowed, or every allowed scope
+	allowed := client.Scopes
+	granted := allowed
+	if requested := strings.Fields(r.PostForm.Get("scope")); len(requested) > 0 {
+		allowedSet := make(map[string]bool, len(allowed))
+		for _, scope := range allowed {
+			allowedSet[scope] = true
+		}
+		for _, scope := range requested {
+			if !allowedSet[scope] {
+				writeOAuthError(w, http.StatusBadRequest, "invalid_scope", "Scope no permitido: "+scope)
+				return
+			}
+		}
+		granted = normalizeScopes(requested)
+	}
+
+	accessToken, err := createClientToken(clientID, granted)
+	if err != nil {
+		log.Printf("❌ Error creating client token: %v", err)
+		writeOAuthError(w, http.StatusInternalServerError, "server_error", "Error en el servidor")
+		return
+	}
+
+	s.publishEvent("CLIENT_TOKEN_ISSUED", map[string]interface{}{
+		"client_id": clientID,
+		"scope":     strings.Join(granted, " "),
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ClientTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "bearer",
+		ExpiresIn:   CLIENT_TOKEN_EXPIRE_MINUTES * 60,
+		Scope:       strings.Join(granted, " "),
+	})
+	log.Printf("✅ Client token issued for %s", clientID)
+}
+
+func (s *AuthService) createClientHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var clientData CreateClientRequest
+	if err := json.NewDecoder(r.Body).Decode(&clientData); err != nil || strings.TrimSpace(clientData.Name) == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid request payload"})
+		return
+	}
+
+	scopes, err := s.validatePermissionSet(clientData.Scopes)
+	if err != nil {
+		writePermissionSetError(w, err)
+		return
+	}
+	if len(scopes) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "El cliente debe tener al menos un scope"})
+		return
+	}
+
+	clientID, err := randomHex(12)
+	var clientSecret string
+	if err == nil {
+		clientSecret, err = randomURLToken(32)
+	}
+	if err != nil {
+		log.Printf("❌ Error generating client credentials: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al crear el cliente"})
+		return
+	}
+	clientID = "sf_" + clientID
+
+	secretHash, err := bcrypt.GenerateFromPassword([]byte(clientSecret), bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("❌ Error hashing client secret: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al crear el cliente"})
+		return
+	}
+
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	row, err := s.repo.CreateClient(r.Context(), clientRow{
+		ClientID:   clientID,
+		SecretHash: string(secretHash),
+		Name:       clientData.Name,
+		Scopes:     scopes,
+		CreatedBy:  int(claims.UserID),
+	})
+	response := ClientResponse{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Name:         clientData.Name,
+		Scopes:       scopes,
+		CreatedAt:    row.CreatedAt,
+	}
+	if err != nil {
+		log.Printf("❌ Database error creating client: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al crear el cliente"})
+		return
+	}
+
+	s.publishEvent("OAUTH_CLIENT_CREATED", map[string]interface{}{
+		"user_id":   claims.UserID, // ID of the admin performing the action
+		"email":     claims.Email,
+		"role":      claims.Role,
+		"client_id": clientID,
+		"scopes":    scopes,
+	})
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+	log.Printf("✅ OAuth client %s created by admin %d", clientID, claims.UserID)
+}
+
+func (s *AuthService) listClientsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	rows, err := s.repo.ListClients(r.Context())
+	if err != nil {
+		log.Printf("❌ Database error listing clients: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al listar clientes"})
+		return
+	}
+
+	clients := make([]ClientResponse, 0, len(rows))
+	for _, row := range rows {
+		clients = append(clients, ClientResponse{ClientID: row.ClientID, Name: row.Name, Scopes: row.Scopes, CreatedAt: row.CreatedAt})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{"clients": clients})
+}
+
+// revokeClientHandler stops a client from obtaining new tokens. Tokens
+// already issued stay valid until they expire (CLIENT_TOKEN_EXPIRE_MINUTES).
+func (s *AuthService) revokeClientHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	clientID := mux.Vars(r)["client_id"]
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	err := s.repo.RevokeClient(r.Context(), clientID)
+	if err == errClientNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Cliente no encontrado"})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error revoking client: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al revocar el cliente"})
+		return
+	}
+
+	s.publishEvent("OAUTH_CLIENT_REVOKED", map[string]interface{}{
+		"user_id":   claims.UserID, // ID of the admin performing the action
+		"email":     claims.Email,
+		"role":      claims.Role,
+		"client_id": clientID,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Cliente revocado exitosamente"})
+	log.Printf("✅ OAuth client %s revoked by admin %d", clientID, claims.UserID)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Events are never published from request handlers. They are written to the
+// outbox_events table, together with the change they describe when there is
+// one (see AuthRepository), and a relay loop publishes them with publisher confirms.
+// An event is therefore lost neither when RabbitMQ is down nor when the
+// process dies right after committing; at worst it is delivered twice.
+
+// Outbox relay configuration
+var (
+	OUTBOX_POLL_INTERVAL    = time.Second
+	OUTBOX_BATCH_SIZE       = 100
+	OUTBOX_PUBLISH_TIMEOUT  = 10 * time.Second
+	OUTBOX_MAX_RETRY_DELAY  = 5 * time.Minute
+	OUTBOX_ALERT_ATTEMPTS   = 10 // log loudly once an event has failed this often
+	OUTBOX_RETENTION        = 72 * time.Hour
+	OUTBOX_CLEANUP_INTERVAL = time.Hour
+	OUTBOX_CLAIM_TIMEOUT    = 5 * time.Minute // claims older than this are taken over
+)
+
+// outboxRelayID marks the rows this process has claimed.
+var outboxRelayID = outboxRelayName()
+
+func outboxRelayName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "auth"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// --- Enqueueing ---
+
+// monitoringEvent builds a monitoring event for RABBITMQ_QUEUE. The timestamp
+// is taken now, not when the relay gets to publish it.
+func monitoringEvent(eventType string, payload map[string]interface{}) outboxMessage {
+	return outboxMessage{
+		RoutingKey: RABBITMQ_QUEUE,
+		Payload: map[string]interface{}{
+			"type":      eventType,
+			"timestamp": time.Now().UTC().Format(time.RFC3339), // Use RFC3339 for consistent timestamp format
+			"data":      payload,
+		},
+	}
+}
+
+// domainEvent builds an event for events_exchange with routingKey, the same
+// way users-service publishes, so other services (email) can consume it.
+func domainEvent(routingKey string, payload map[string]interface{}) outboxMessage {
+	return outboxMessage{Exchange: EVENTS_EXCHANGE, RoutingKey: routingKey, Payload: payload}
+}
+
+// publishEvent enqueues a monitoring event that is not tied to a database
+// change. Repository writes that change data take their events along.
+func (s *AuthService) publishEvent(eventType string, payload map[string]interface{}) {
+	if err := s.repo.Enqueue(context.Background(), monitoringEvent(eventType, payload)); err != nil {
+		log.Printf("❌ %v", err)
+	}
+}
+
+// --- Relay ---
+
+type outboxEvent struct {
+	ID         int64
+	Exchange   string
+	RoutingKey string
+	Payload    []byte
+	Attempts   int
+}
+
+// startOutboxRelay publishes pending events until the process exits. Several
+// replicas can run it at once: each claims its batch before publishing.
+func (s *AuthService) startOutboxRelay() {
+	log.Println("📮 Outbox relay started")
+	lastCleanup := time.Now()
+	for {
+		published, err := s.relayOutboxBatch()
+		if err != nil {
+			log.Printf("❌ Outbox relay error: %v", err)
+		}
+
+		if time.Since(lastCleanup) > OUTBOX_CLEANUP_INTERVAL {
+			s.cleanupOutbox()
+			lastCleanup = time.Now()
+		}
+
+		// A full batch means there is probably more waiting
+		if err != nil || published < OUTBOX_BATCH_SIZE {
+			time.Sleep(OUTBOX_POLL_INTERVAL)
+		}
+	}
+}
+
+// relayOutboxBatch publishes the due events in order and returns how many
+// were confirmed. It stops at the first failure so later events do not
+// overtake it while the broker is unreachable.
+//
+// No transaction is held while publishing: the batch is claimed and committed
+// first, then every outcome is written with its own short statement.
+func (s *AuthService) relayOutboxBatch() (int, error) {
+	ctx := context.Background()
+	events, err := s.repo.ClaimEvents(ctx, outboxRelayID, time.Now().Add(-OUTBOX_CLAIM_TIMEOUT), OUTBOX_BATCH_SIZE)
+	if err != nil {
+		return 0, fmt.Errorf("error claiming outbox events: %w", err)
+	}
+	// Whatever is left claimed when the batch ends goes back to the queue, here
+	// or in another replica
+	defer func() {
+		if err := s.repo.ReleaseEvents(ctx, outboxRelayID); err != nil {
+			log.Printf("⚠️ Error releasing outbox claims: %v", err)
+		}
+	}()
+
+	deadline := time.Now().Add(OUTBOX_CLAIM_TIMEOUT - OUTBOX_PUBLISH_TIMEOUT)
+	published := 0
+	for _, event := range events {
+		// Past this point another relay may take the rows over
+		if time.Now().After(deadline) {
+			break
+		}
+
+		publishCtx, cancel := context.WithTimeout(ctx, OUTBOX_PUBLISH_TIMEOUT)
+		publishErr := s.publisher.Publish(publishCtx, event.Exchange, event.RoutingKey, "auth-outbox-"+strconv.FormatInt(event.ID, 10), event.Payload)
+		cancel()
+
+		if publishErr != nil {
+			attempts := event.Attempts + 1
+			err = s.repo.MarkEventFailed(ctx, event.ID, outboxRelayID, attempts, publishErr.Error(), time.Now().Add(outboxRetryDelay(attempts)))
+			if err != nil {
+				return published, fmt.Errorf("error recording outbox failure: %w", err)
+			}
+			if attempts == OUTBOX_ALERT_ATTEMPTS {
+				log.Printf("🚨 Outbox event %d (%s) has failed %d times: %v", event.ID, event.RoutingKey, attempts, publishErr)
+			} else {
+				log.Printf("⚠️ Outbox event %d (%s) not published, attempt %d: %v", event.ID, event.RoutingKey, attempts, publishErr)
+			}
+			break
+		}
+
+		if err := s.repo.MarkEventPublished(ctx, event.ID); err != nil {
+			// The event stays pending and is published again: at-least-once
+			return published, fmt.Errorf("error marking outbox event as published: %w", err)
+		}
+		published++
+	}
+
+	if published > 0 {
+		log.Printf("📤 Outbox relay published %d events", published)
+	}
+	return published, nil
+}
+
+// outboxRetryDelay doubles the wait after each failed attempt, up to
+// OUTBOX_MAX_RETRY_DELAY. Events are retried forever.
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := OUTBOX_POLL_INTERVAL
+	for i := 1; i < attempts && delay < OUTBOX_MAX_RETRY_DELAY; i++ {
+		delay *= 2
+	}
+	if delay > OUTBOX_MAX_RETRY_DELAY {
+		delay = OUTBOX_MAX_RETRY_DELAY
+	}
+	return delay
+}
+
+// cleanupOutbox deletes events published more than OUTBOX_RETENTION ago.
+// Reset and verification tokens travel in some payloads, so published rows
+// are not kept forever.
+func (s *AuthService) cleanupOutbox() {
+	deleted, err := s.repo.DeletePublishedEvents(context.Background(), time.Now().Add(-OUTBOX_RETENTION))
+	if err != nil {
+		log.Printf("⚠️ Error cleaning up outbox: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("🧹 Deleted %d published outbox events", deleted)
+	}
+}
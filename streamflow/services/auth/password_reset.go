@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"streamflow/shared/passwordhash"
+	"streamflow/shared/passwordpolicy"
+)
+
+// Models
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token              string `json:"token"`
+	NewPassword        string `json:"new_password"`
+	ConfirmNewPassword string `json:"confirm_new_password"`
+}
+
+// forgotPasswordMessage is returned whether or not the email exists, so the
+// endpoint cannot be used to discover registered accounts.
+const forgotPasswordMessage = "Si el email está registrado, recibirás instrucciones para restablecer tu contraseña"
+
+// --- Reset Token Helpers ---
+
+// generateResetToken returns a random URL-safe token and its SHA-256 hash.
+// Only the hash is stored; the plain token travels in the email.
+func generateResetToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("error generating reset token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashResetToken(token), nil
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// createPasswordResetToken stores a new reset token for the user,
+// invalidating any previous one, and enqueues the email with the link in the
+// same transaction.
+func (s *AuthService) createPasswordResetToken(ctx context.Context, userID int, email, name string) error {
+	token, tokenHash, err := generateResetToken()
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(time.Minute * time.Duration(PASSWORD_RESET_EXPIRE_MINUTES))
+
+	// Only the most recent reset link stays usable. The email service sends
+	// the reset link
+	err = s.repo.CreateResetToken(ctx, userID, tokenHash, expiresAt,
+		domainEvent("password.reset_requested", map[string]interface{}{
+			"user_id":    userID,
+			"email":      email,
+			"name":       name,
+			"token":      token,
+			"expires_at": expiresAt.UTC().Format(time.RFC3339),
+		}),
+		monitoringEvent("USER_PWD_RESET_REQUESTED", map[string]interface{}{
+			"user_id": userID,
+			"email":   email,
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("error storing reset token: %w", err)
+	}
+	return nil
+}
+
+// --- HTTP Handlers ---
+
+func (s *AuthService) forgotPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var forgotData ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&forgotData); err != nil || strings.TrimSpace(forgotData.Email) == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid request payload"})
+		return
+	}
+
+	user, err := s.repo.GetCredentialsByEmail(r.Context(), forgotData.Email)
+	if err == errCredentialsNotFound || (err == nil && user.Deleted) {
+		log.Printf("⚠️ Password reset requested for unknown email %s", forgotData.Email)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"message": forgotPasswordMessage})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error during password reset request: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor"})
+		return
+	}
+
+	if err := s.createPasswordResetToken(r.Context(), user.ID, user.Email, user.FirstName+" "+user.LastName); err != nil {
+		log.Printf("❌ %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor"})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": forgotPasswordMessage})
+	log.Printf("✅ Password reset requested for user ID %d", user.ID)
+}
+
+func (s *AuthService) resetPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var resetData ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&resetData); err != nil || resetData.Token == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid request payload"})
+		return
+	}
+
+	if resetData.NewPassword != resetData.ConfirmNewPassword {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Las contraseñas no coinciden"})
+		return
+	}
+
+	// The token must be unused and not expired
+	tokenHash := hashResetToken(resetData.Token)
+	user, err := s.repo.ResetTokenOwner(r.Context(), tokenHash)
+	if err == errResetTokenInvalid {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Token de restablecimiento inválido o expirado"})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error getting user for password reset: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al restablecer la contraseña"})
+		return
+	}
+
+	// Enforce the password policy; the token stays usable until it passes
+	if err := passwordpolicy.Validate(resetData.NewPassword, passwordpolicy.UserInfo{
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+	}); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: err.Error()})
+		return
+	}
+
+	newHashedPassword, err := passwordhash.Hash(resetData.NewPassword)
+	if err != nil {
+		log.Printf("❌ Error hashing new password: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al procesar la contraseña"})
+		return
+	}
+
+	// Consume the token, set the new password and revoke every token issued
+	// so far. A concurrent reset with the same token loses here
+	err = s.repo.ResetPassword(r.Context(), tokenHash, newHashedPassword,
+		// Notify the user that the password changed
+		domainEvent("password.updated", map[string]interface{}{
+			"user_email": user.Email,
+			"user_name":  user.FirstName + " " + user.LastName,
+		}),
+		monitoringEvent("USER_PWD_RESET", map[string]interface{}{
+			"user_id": user.ID,
+			"email":   user.Email,
+		}),
+	)
+	if err == errResetTokenInvalid {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Token de restablecimiento inválido o expirado"})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error resetting password: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al restablecer la contraseña"})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Contraseña restablecida exitosamente"})
+	log.Printf("✅ Password reset for user ID %d", user.ID)
+}
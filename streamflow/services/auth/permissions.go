@@ -0,0 +1,531 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// Roles are named sets of permissions ("invoices:write", "videos:publish").
+// Access tokens embed the permissions of the user's role, OAuth clients and
+// API keys are granted a subset of them as scopes, and services check
+// permissions instead of comparing role strings.
+//
+// Permission changes reach a user on their next login.
+
+// Permission catalogue seeded at startup. Services rely on these names, so
+// they cannot be deleted.
+var SYSTEM_PERMISSIONS = map[string]string{
+	"users:read":        "Ver y listar cualquier usuario",
+	"users:write":       "Modificar, eliminar y cerrar sesiones de cualquier usuario",
+	"users:impersonate": "Iniciar sesión como otro usuario para dar soporte",
+	"users:purge":       "Borrar definitivamente usuarios eliminados",
+	"roles:manage":      "Administrar roles, permisos y clientes OAuth2",
+	"invoices:read":     "Ver facturas propias",
+	"invoices:read_all": "Ver facturas de cualquier usuario",
+	"invoices:write":    "Crear, modificar y eliminar facturas",
+	"videos:read":       "Ver videos",
+	"videos:publish":    "Subir videos",
+	"videos:write":      "Modificar y eliminar videos",
+	"monitoring:read":   "Ver acciones y errores registrados",
+	"playlists:read":    "Ver listas de reproducción propias",
+	"playlists:write":   "Crear y modificar listas de reproducción propias",
+	"social:read":       "Ver likes y comentarios",
+	"social:write":      "Dar likes y comentar",
+}
+
+// SYSTEM_ROLES are seeded at startup and cannot be deleted.
+var SYSTEM_ROLES = map[string][]string{
+	"Administrador": nil, // every permission
+	"Cliente": {
+		"invoices:read",
+		"videos:read",
+		"playlists:read", "playlists:write",
+		"social:read", "social:write",
+	},
+}
+
+// DEFAULT_ROLE is assigned when users-service sends an unknown role.
+const DEFAULT_ROLE = "Cliente"
+
+var permissionNamePattern = regexp.MustCompile(`^[a-z][a-z_]*:[a-z][a-z_]*$`)
+
+// Models
+type PermissionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	System      bool   `json:"system"`
+}
+
+type RoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type RoleResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	System      bool     `json:"system"`
+	Permissions []string `json:"permissions"`
+}
+
+// --- Schema ---
+
+// initPermissions seeds the system roles and permissions (the tables come
+// from migration 0008).
+func initPermissions(ctx context.Context, repo AuthRepository) error {
+	// Defaults are only granted to new roles and for new permissions, so admin
+	// edits survive restarts and later releases can add system permissions
+	newRoles := make(map[string]bool)
+	for role := range SYSTEM_ROLES {
+		err := repo.CreateRole(ctx, roleRow{Name: role, System: true})
+		if err == errRoleExists {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error seeding role %s: %w", role, err)
+		}
+		newRoles[role] = true
+	}
+
+	newPermissions := make(map[string]bool)
+	for name, description := range SYSTEM_PERMISSIONS {
+		err := repo.CreatePermission(ctx, permissionRow{Name: name, Description: description, System: true})
+		if err == nil {
+			newPermissions[name] = true
+			continue
+		}
+		if err == errPermissionExists {
+			// An admin may have created it before it became a system permission
+			err = repo.MarkPermissionSystem(ctx, name)
+		}
+		if err != nil {
+			return fmt.Errorf("error seeding permission %s: %w", name, err)
+		}
+	}
+
+	for role, defaults := range SYSTEM_ROLES {
+		if defaults == nil {
+			for name := range SYSTEM_PERMISSIONS {
+				defaults = append(defaults, name)
+			}
+		}
+		grant := make([]string, 0, len(defaults))
+		for _, permission := range defaults {
+			if newRoles[role] || newPermissions[permission] {
+				grant = append(grant, permission)
+			}
+		}
+		if len(grant) == 0 {
+			continue
+		}
+		if err := repo.GrantPermissions(ctx, role, grant); err != nil {
+			return fmt.Errorf("error seeding permissions of role %s: %w", role, err)
+		}
+	}
+	return nil
+}
+
+// --- Permission Functions ---
+
+// rolePermissions returns the sorted permissions granted to role.
+func (s *AuthService) rolePermissions(role string) ([]string, error) {
+	permissions, err := s.repo.RolePermissions(context.Background(), role)
+	if err != nil {
+		return nil, fmt.Errorf("error loading role permissions: %w", err)
+	}
+	return permissions, nil
+}
+
+// knownPermissions returns every permission name as a set.
+func (s *AuthService) knownPermissions() (map[string]bool, error) {
+	permissions, err := s.repo.ListPermissions(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error loading permissions: %w", err)
+	}
+	known := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		known[permission.Name] = true
+	}
+	return known, nil
+}
+
+// resolveRole maps a role name from users-service ("cliente", "admin") to an
+// existing role, falling back to DEFAULT_ROLE. Empty stays empty.
+func (s *AuthService) resolveRole(role string) (string, error) {
+	role = strings.TrimSpace(role)
+	if role == "" {
+		return "", nil
+	}
+	if strings.EqualFold(role, "admin") {
+		role = "Administrador"
+	}
+
+	name, err := s.repo.FindRole(context.Background(), role)
+	if err == errRoleNotFound {
+		return DEFAULT_ROLE, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error resolving role: %w", err)
+	}
+	return name, nil
+}
+
+// hasPermission reports whether claims grant permission.
+func (c *Claims) hasPermission(permission string) bool {
+	for _, granted := range c.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// requirePermission is a middleware/wrapper for handlers that need permission
+func (s *AuthService) requirePermission(permission string, handler http.HandlerFunc) http.HandlerFunc {
+	return s.requireAuth(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value("userClaims").(*Claims)
+		if !ok || !claims.hasPermission(permission) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(ErrorResponse{Detail: "No tiene permisos para esta acción"})
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// validatePermissionSet checks that every permission exists and returns
+// them deduplicated and sorted.
+func (s *AuthService) validatePermissionSet(permissions []string) ([]string, error) {
+	known, err := s.knownPermissions()
+	if err != nil {
+		return nil, err
+	}
+	result := normalizeScopes(permissions)
+	for _, permission := range result {
+		if !known[permission] {
+			return nil, &validationError{fmt.Sprintf("Permiso desconocido: %s", permission)}
+		}
+	}
+	return result, nil
+}
+
+// validationError carries a user-facing message for a 400 response.
+type validationError struct{ message string }
+
+func (e *validationError) Error() string { return e.message }
+
+func writePermissionSetError(w http.ResponseWriter, err error) {
+	if verr, ok := err.(*validationError); ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: verr.message})
+		return
+	}
+	log.Printf("❌ Error validating permissions: %v", err)
+	w.WriteHeader(http.StatusInternalServerError)
+	json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor"})
+}
+
+// --- HTTP Handlers: Permissions ---
+
+func (s *AuthService) listPermissionsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	rows, err := s.repo.ListPermissions(r.Context())
+	if err != nil {
+		log.Printf("❌ Database error listing permissions: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al listar permisos"})
+		return
+	}
+
+	permissions := make([]PermissionResponse, 0, len(rows))
+	for _, row := range rows {
+		permissions = append(permissions, PermissionResponse{Name: row.Name, Description: row.Description, System: row.System})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{"permissions": permissions})
+}
+
+func (s *AuthService) createPermissionHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var permission PermissionResponse
+	if err := json.NewDecoder(r.Body).Decode(&permission); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid request payload"})
+		return
+	}
+	if !permissionNamePattern.MatchString(permission.Name) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "El permiso debe tener el formato recurso:accion (ej. invoices:write)"})
+		return
+	}
+
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	err := s.repo.CreatePermission(r.Context(), permissionRow{Name: permission.Name, Description: permission.Description})
+	if err == errPermissionExists {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "El permiso ya existe"})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error creating permission: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al crear el permiso"})
+		return
+	}
+
+	s.publishEvent("PERMISSION_CREATED", map[string]interface{}{
+		"user_id":    claims.UserID,
+		"email":      claims.Email,
+		"role":       claims.Role,
+		"permission": permission.Name,
+	})
+
+	permission.System = false
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(permission)
+	log.Printf("✅ Permission %s created by admin %d", permission.Name, claims.UserID)
+}
+
+func (s *AuthService) deletePermissionHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	name := mux.Vars(r)["name"]
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	permission, err := s.repo.GetPermission(r.Context(), name)
+	if err == errPermissionNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Permiso no encontrado"})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error getting permission: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al eliminar el permiso"})
+		return
+	}
+	if permission.System {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Los permisos del sistema no se pueden eliminar"})
+		return
+	}
+
+	if err := s.repo.DeletePermission(r.Context(), name); err != nil {
+		log.Printf("❌ Database error deleting permission: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al eliminar el permiso"})
+		return
+	}
+
+	s.publishEvent("PERMISSION_DELETED", map[string]interface{}{
+		"user_id":    claims.UserID,
+		"email":      claims.Email,
+		"role":       claims.Role,
+		"permission": name,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Permiso eliminado exitosamente"})
+	log.Printf("✅ Permission %s deleted by admin %d", name, claims.UserID)
+}
+
+// --- HTTP Handlers: Roles ---
+
+func (s *AuthService) listRolesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	rows, err := s.repo.ListRoles(r.Context())
+	if err != nil {
+		log.Printf("❌ Database error listing roles: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al listar roles"})
+		return
+	}
+
+	roles := make([]RoleResponse, 0, len(rows))
+	for _, row := range rows {
+		roles = append(roles, RoleResponse{Name: row.Name, Description: row.Description, System: row.System, Permissions: row.Permissions})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{"roles": roles})
+}
+
+func (s *AuthService) createRoleHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var roleData RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&roleData); err != nil || strings.TrimSpace(roleData.Name) == "" || len(roleData.Name) > 50 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid request payload"})
+		return
+	}
+
+	permissions, err := s.validatePermissionSet(roleData.Permissions)
+	if err != nil {
+		writePermissionSetError(w, err)
+		return
+	}
+
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	err = s.repo.CreateRole(r.Context(), roleRow{Name: roleData.Name, Description: roleData.Description, Permissions: permissions})
+	if err == errRoleExists {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "El rol ya existe"})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error creating role: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al crear el rol"})
+		return
+	}
+
+	s.publishEvent("ROLE_CREATED", map[string]interface{}{
+		"user_id":     claims.UserID,
+		"email":       claims.Email,
+		"role":        claims.Role,
+		"target_role": roleData.Name,
+		"permissions": permissions,
+	})
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(RoleResponse{Name: roleData.Name, Description: roleData.Description, Permissions: permissions})
+	log.Printf("✅ Role %s created by admin %d", roleData.Name, claims.UserID)
+}
+
+// updateRoleHandler replaces the description and permission set of a role.
+func (s *AuthService) updateRoleHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	name := mux.Vars(r)["name"]
+
+	var roleData RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&roleData); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid request payload"})
+		return
+	}
+
+	permissions, err := s.validatePermissionSet(roleData.Permissions)
+	if err != nil {
+		writePermissionSetError(w, err)
+		return
+	}
+
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	// An admin must not lock everyone out of role management
+	if name == claims.Role && !containsString(permissions, "roles:manage") {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "No puedes quitar roles:manage a tu propio rol"})
+		return
+	}
+
+	err = s.repo.UpdateRole(r.Context(), name, roleData.Description, permissions)
+	if err == errRoleNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Rol no encontrado"})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error updating role: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al actualizar el rol"})
+		return
+	}
+
+	s.publishEvent("ROLE_UPDATED", map[string]interface{}{
+		"user_id":     claims.UserID,
+		"email":       claims.Email,
+		"role":        claims.Role,
+		"target_role": name,
+		"permissions": permissions,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(RoleResponse{Name: name, Description: roleData.Description, Permissions: permissions})
+	log.Printf("✅ Role %s updated by admin %d", name, claims.UserID)
+}
+
+func (s *AuthService) deleteRoleHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	name := mux.Vars(r)["name"]
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	role, err := s.repo.GetRole(r.Context(), name)
+	if err == errRoleNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Rol no encontrado"})
+		return
+	}
+	var assigned int
+	if err == nil {
+		assigned, err = s.repo.CountRoleUsers(r.Context(), name)
+	}
+	if err != nil {
+		log.Printf("❌ Database error getting role: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al eliminar el rol"})
+		return
+	}
+	if role.System {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Los roles del sistema no se pueden eliminar"})
+		return
+	}
+	if assigned > 0 {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: fmt.Sprintf("El rol está asignado a %d usuarios", assigned)})
+		return
+	}
+
+	err = s.repo.DeleteRole(r.Context(), name)
+	if err != nil {
+		// Soft-deleted users still reference the role
+		if err == errRoleInUse {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(ErrorResponse{Detail: "El rol está asignado a usuarios eliminados"})
+			return
+		}
+		log.Printf("❌ Database error deleting role: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al eliminar el rol"})
+		return
+	}
+
+	s.publishEvent("ROLE_DELETED", map[string]interface{}{
+		"user_id":     claims.UserID,
+		"email":       claims.Email,
+		"role":        claims.Role,
+		"target_role": name,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Rol eliminado exitosamente"})
+	log.Printf("✅ Role %s deleted by admin %d", name, claims.UserID)
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
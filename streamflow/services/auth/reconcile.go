@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"streamflow/shared/passwordhash"
+	"auth-service/pb"
+)
+
+// Reconciliation repairs drift between users-service profiles and auth
+// credentials left by lost or failed events. Run it as:
+//
+//	auth-service reconcile [-dry-run]
+//
+// Missing credentials are created with an unusable password and the user is
+// emailed a reset link, since users-service does not expose password hashes.
+
+// ReconcileReport counts the changes made (or, in dry-run, needed).
+type ReconcileReport struct {
+	Created   int
+	Updated   int
+	Restored  int
+	Deleted   int
+	Conflicts int
+}
+
+func (s *AuthService) loadCredentialRows() (map[int]credentialRow, error) {
+	rows, err := s.repo.ListCredentials(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error loading credentials: %w", err)
+	}
+
+	result := make(map[int]credentialRow, len(rows))
+	for _, row := range rows {
+		result[row.ID] = row
+	}
+	return result, nil
+}
+
+// fetchUserProfiles lists every active user from users-service.
+func fetchUserProfiles() ([]*pb.UserResponse, error) {
+	usersServiceURL := os.Getenv("USERS_SERVICE_URL")
+	if usersServiceURL == "" {
+		usersServiceURL = "localhost:50051"
+	}
+
+	conn, err := grpc.Dial(usersServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to users service: %w", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	// users-service checks ListUsers against the caller's permissions, which
+	// the gateway normally forwards as metadata
+	ctx = metadata.AppendToOutgoingContext(ctx, "permissions", "users:read")
+
+	client := pb.NewUserServiceClient(conn)
+	var users []*pb.UserResponse
+	pageToken := ""
+	for {
+		resp, err := client.ListUsers(ctx, &pb.ListUsersRequest{
+			OrderBy:   "id",
+			PageSize:  200,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		users = append(users, resp.Users...)
+		if resp.NextPageToken == "" {
+			return users, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// unusablePasswordHash returns a hash of random bytes nobody knows.
+func unusablePasswordHash() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return passwordhash.Hash(hex.EncodeToString(buf))
+}
+
+func (s *AuthService) runReconcile(dryRun bool) (*ReconcileReport, error) {
+	profiles, err := fetchUserProfiles()
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := s.loadCredentialRows()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReconcileReport{}
+	active := make(map[int]bool, len(profiles))
+
+	for _, profile := range profiles {
+		id := int(profile.Id)
+		active[id] = true
+		event := UserEvent{
+			ID:        id,
+			Email:     profile.Email,
+			FirstName: profile.FirstName,
+			LastName:  profile.LastName,
+			Role:      profile.Role,
+			Status:    profile.Status,
+		}
+
+		role, err := s.resolveRole(profile.Role)
+		if err != nil {
+			return report, fmt.Errorf("user %d: %w", id, err)
+		}
+
+		row, exists := credentials[id]
+		switch {
+		case !exists:
+			log.Printf("🔧 User %d (%s) has no credentials", id, profile.Email)
+			report.Created++
+			if !dryRun {
+				err = s.reconcileMissing(event)
+			}
+		case row.Deleted:
+			log.Printf("🔧 User %d (%s) is active but its credentials are deleted", id, profile.Email)
+			report.Restored++
+			if !dryRun {
+				err = s.reconcileRestore(event)
+			}
+		case row.FirstName != event.FirstName || row.LastName != event.LastName ||
+			row.Email != event.Email || row.Role != role:
+			log.Printf("🔧 User %d (%s) has outdated credentials", id, profile.Email)
+			report.Updated++
+			if !dryRun {
+				err = s.applyUserUpdated(event)
+			}
+		default:
+			continue
+		}
+
+		if errors.Is(err, errUserEmailConflict) {
+			log.Printf("⚠️ User %d: email %s belongs to another credential row, fix manually", id, profile.Email)
+			report.Conflicts++
+			err = nil
+		}
+		if err != nil {
+			return report, fmt.Errorf("user %d: %w", id, err)
+		}
+	}
+
+	// Rows mirrored from users-service whose profile no longer exists.
+	// Local rows (the seeded admin) are never touched.
+	for id, row := range credentials {
+		if !row.Synced || row.Deleted || active[id] {
+			continue
+		}
+		log.Printf("🔧 Credentials of user %d (%s) have no active profile", id, row.Email)
+		report.Deleted++
+		if !dryRun {
+			if err := s.applyUserDeleted(UserEvent{ID: id}); err != nil {
+				return report, fmt.Errorf("user %d: %w", id, err)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// reconcileMissing creates credentials the user cannot log in with yet and
+// emails them a link to choose a password.
+func (s *AuthService) reconcileMissing(event UserEvent) error {
+	hash, err := unusablePasswordHash()
+	if err != nil {
+		return fmt.Errorf("error generating password: %w", err)
+	}
+	event.PasswordHash = hash
+	if err := s.applyUserCreated(event); err != nil {
+		return err
+	}
+
+	// The running service's outbox relay sends the email
+	return s.createPasswordResetToken(context.Background(), event.ID, event.Email, event.FirstName+" "+event.LastName)
+}
+
+// reconcileRestore undeletes credentials of a profile that is still active.
+func (s *AuthService) reconcileRestore(event UserEvent) error {
+	role, err := s.resolveRole(event.Role)
+	if err != nil {
+		return err
+	}
+	_, err = s.repo.RestoreCredentials(context.Background(), event.ID, event.FirstName, event.LastName, event.Email, role)
+	if err == errUserEmailConflict {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("error restoring credentials: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package main
+
+// Storage of credentials, sessions, keys, clients, roles and the outbox. The
+// HTTP handlers keep the rules (who may do what, token lifetimes, password
+// policy) and reach the data only through AuthRepository:
+// postgresAuthRepository in production, memoryAuthRepository in the unit
+// tests.
+//
+// Writes that must be published take the outbox messages describing them and
+// store both atomically, so an event is never lost nor sent for a change that
+// was rolled back.
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// errCredentialsNotFound is returned for unknown user IDs and emails.
+	errCredentialsNotFound = errors.New("credentials not found")
+	// errResetTokenInvalid covers unknown, used and expired reset tokens.
+	errResetTokenInvalid  = errors.New("reset token invalid or expired")
+	errSessionNotFound    = errors.New("session not found")
+	errAPIKeyNotFound     = errors.New("api key not found")
+	errClientNotFound     = errors.New("oauth client not found")
+	errPermissionNotFound = errors.New("permission not found")
+	errPermissionExists   = errors.New("permission already exists")
+	errRoleNotFound       = errors.New("role not found")
+	errRoleExists         = errors.New("role already exists")
+	// errRoleInUse is returned when deleting a role that users (deleted ones
+	// included) still reference.
+	errRoleInUse = errors.New("role assigned to users")
+)
+
+// credentialRow is an auth users row. Zero times mean NULL.
+type credentialRow struct {
+	ID                 int
+	FirstName          string
+	LastName           string
+	Email              string
+	PasswordHash       string
+	Role               string
+	Status             string
+	CreatedAt          time.Time
+	VerificationSentAt time.Time
+	TokensRevokedAt    time.Time
+	Deleted            bool
+	Synced             bool // mirrored from users-service, not local (seeded admin)
+}
+
+type sessionRow struct {
+	ID         int
+	UserID     int
+	JTI        string
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+}
+
+type impersonationRow struct {
+	AdminID   int
+	UserID    int
+	JTI       string
+	Reason    string
+	IPAddress string
+	ExpiresAt time.Time
+}
+
+type apiKeyRow struct {
+	ID         int
+	UserID     int
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+}
+
+type clientRow struct {
+	ClientID   string
+	SecretHash string
+	Name       string
+	Scopes     []string
+	CreatedBy  int
+	CreatedAt  time.Time
+}
+
+type permissionRow struct {
+	Name        string
+	Description string
+	System      bool
+}
+
+type roleRow struct {
+	Name        string
+	Description string
+	System      bool
+	Permissions []string // sorted
+}
+
+// outboxMessage is an event to store in outbox_events. Payload is encoded as
+// JSON when it is written.
+type outboxMessage struct {
+	Exchange   string
+	RoutingKey string
+	Payload    interface{}
+}
+
+// AuthRepository stores everything the auth service owns.
+type AuthRepository interface {
+	// --- Credentials ---
+
+	// GetCredentials and GetCredentialsByEmail return deleted rows too and
+	// errCredentialsNotFound when there is no row.
+	GetCredentials(ctx context.Context, id int) (credentialRow, error)
+	GetCredentialsByEmail(ctx context.Context, email string) (credentialRow, error)
+	ListCredentials(ctx context.Context) ([]credentialRow, error)
+	// CreateCredentials inserts row unless its ID exists, reporting whether
+	// it did. An email taken by another ID is errUserEmailConflict.
+	CreateCredentials(ctx context.Context, row credentialRow) (bool, error)
+	// UpdateProfile sets the non-empty fields. RestoreCredentials does the
+	// same and undeletes the row. Both report whether the row exists.
+	UpdateProfile(ctx context.Context, id int, firstName, lastName, email, role string) (bool, error)
+	RestoreCredentials(ctx context.Context, id int, firstName, lastName, email, role string) (bool, error)
+	// DeleteCredentials soft-deletes the row and revokes every token and
+	// session. It reports false if the row was missing or already deleted.
+	DeleteCredentials(ctx context.Context, id int) (bool, error)
+	// ChangeRole sets the role and revokes every token and session. It
+	// reports false if the row is missing or already has the role.
+	ChangeRole(ctx context.Context, id int, role string) (bool, error)
+	// ChangeEmail sets the email and invalidates unused reset tokens. It
+	// reports false if the row is missing or already has the email.
+	ChangeEmail(ctx context.Context, id int, email string) (bool, error)
+	// PurgeCredentials deletes the row and everything that references it.
+	PurgeCredentials(ctx context.Context, id int) (bool, error)
+	SetPassword(ctx context.Context, id int, passwordHash string, events ...outboxMessage) error
+	// ReplacePasswordHash only writes if the stored hash is still oldHash.
+	ReplacePasswordHash(ctx context.Context, id int, oldHash, newHash string) error
+	// RevokeAllTokens sets tokens_revoked_at and revokes every session,
+	// returning how many sessions were open.
+	RevokeAllTokens(ctx context.Context, id int) (int64, error)
+	// ActivateCredentials moves a pending_verification row to active.
+	ActivateCredentials(ctx context.Context, id int, events ...outboxMessage) error
+	RecordVerificationSent(ctx context.Context, id int, events ...outboxMessage) error
+
+	// --- Password reset tokens ---
+
+	// CreateResetToken stores a token and invalidates the user's older ones.
+	CreateResetToken(ctx context.Context, userID int, tokenHash string, expiresAt time.Time, events ...outboxMessage) error
+	// ResetTokenOwner returns the credentials behind a usable token, or
+	// errResetTokenInvalid.
+	ResetTokenOwner(ctx context.Context, tokenHash string) (credentialRow, error)
+	// ResetPassword consumes the token, sets the password and revokes every
+	// token and session of its owner. A token already consumed, expired or
+	// whose owner was deleted is errResetTokenInvalid.
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string, events ...outboxMessage) error
+
+	// --- Sessions ---
+
+	CreateSession(ctx context.Context, session sessionRow, events ...outboxMessage) error
+	// IsTokenRevoked reports whether jti was logged out or its session revoked.
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	// TouchSession refreshes last_seen_at if it is older than before.
+	TouchSession(ctx context.Context, jti string, before time.Time) error
+	// ListSessions returns the open sessions of a user, most recent first.
+	ListSessions(ctx context.Context, userID int) ([]sessionRow, error)
+	// RevokeSession revokes a session of userID or returns errSessionNotFound.
+	RevokeSession(ctx context.Context, id, userID int) error
+	RevokeOtherSessions(ctx context.Context, userID int, keepJTI string) (int64, error)
+	// EndToken blacklists jti and revokes its session and impersonation.
+	EndToken(ctx context.Context, jti string, userID int, events ...outboxMessage) error
+	CreateImpersonation(ctx context.Context, impersonation impersonationRow, events ...outboxMessage) error
+
+	// --- API keys ---
+
+	CreateAPIKey(ctx context.Context, key apiKeyRow) (apiKeyRow, error)
+	// ListAPIKeys returns the usable keys of a user, newest first.
+	ListAPIKeys(ctx context.Context, userID int) ([]apiKeyRow, error)
+	RevokeAPIKey(ctx context.Context, id, userID int) error
+	// ActiveAPIKey returns a usable key and its owner: not revoked, not
+	// expired, owner not deleted and key created after tokens_revoked_at.
+	ActiveAPIKey(ctx context.Context, keyHash string) (apiKeyRow, credentialRow, error)
+	TouchAPIKey(ctx context.Context, id int, before time.Time) error
+
+	// --- OAuth clients ---
+
+	CreateClient(ctx context.Context, client clientRow) (clientRow, error)
+	// GetClient returns a client that is not revoked, or errClientNotFound.
+	GetClient(ctx context.Context, clientID string) (clientRow, error)
+	ListClients(ctx context.Context) ([]clientRow, error)
+	RevokeClient(ctx context.Context, clientID string) error
+
+	// --- Roles and permissions ---
+
+	ListPermissions(ctx context.Context) ([]permissionRow, error)
+	GetPermission(ctx context.Context, name string) (permissionRow, error)
+	CreatePermission(ctx context.Context, permission permissionRow) error
+	// MarkPermissionSystem turns an admin-created permission into a system one.
+	MarkPermissionSystem(ctx context.Context, name string) error
+	// DeletePermission also takes it away from every role.
+	DeletePermission(ctx context.Context, name string) error
+	ListRoles(ctx context.Context) ([]roleRow, error)
+	GetRole(ctx context.Context, name string) (roleRow, error)
+	// FindRole returns the name of the role matching name case-insensitively.
+	FindRole(ctx context.Context, name string) (string, error)
+	// CreateRole creates the role with its permissions or returns errRoleExists.
+	CreateRole(ctx context.Context, role roleRow) error
+	// UpdateRole replaces the description and the permissions of a role.
+	UpdateRole(ctx context.Context, name, description string, permissions []string) error
+	// GrantPermissions adds permissions to a role, keeping the ones it has.
+	GrantPermissions(ctx context.Context, role string, permissions []string) error
+	RolePermissions(ctx context.Context, role string) ([]string, error)
+	// CountRoleUsers counts the users (not deleted) that have the role.
+	CountRoleUsers(ctx context.Context, role string) (int, error)
+	DeleteRole(ctx context.Context, name string) error
+
+	// --- Outbox ---
+
+	Enqueue(ctx context.Context, events ...outboxMessage) error
+	// ClaimEvents marks up to limit due events as taken by owner, skipping
+	// rows claimed by someone else after staleBefore, and returns them
+	// oldest first.
+	ClaimEvents(ctx context.Context, owner string, staleBefore time.Time, limit int) ([]outboxEvent, error)
+	MarkEventPublished(ctx context.Context, id int64) error
+	// MarkEventFailed records a failed attempt of an event owner still holds
+	// and releases it until nextAttempt.
+	MarkEventFailed(ctx context.Context, id int64, owner string, attempts int, lastError string, nextAttempt time.Time) error
+	// ReleaseEvents gives back the unpublished events still held by owner.
+	ReleaseEvents(ctx context.Context, owner string) error
+	// DeletePublishedEvents removes events published before before.
+	DeletePublishedEvents(ctx context.Context, before time.Time) (int64, error)
+}
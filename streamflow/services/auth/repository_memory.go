@@ -0,0 +1,956 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryAuthRepository keeps everything in memory with the same semantics as
+// the Postgres tables (unique emails, single-use reset tokens, revocation
+// cutoffs, outbox rows written with the change).
+type memoryAuthRepository struct {
+	mu             sync.Mutex
+	nextID         int
+	users          map[int]*credentialRow
+	blacklist      map[string]bool
+	sessions       []*memorySession
+	resetTokens    []*memoryResetToken
+	impersonations []*memoryImpersonation
+	apiKeys        []*memoryAPIKey
+	clients        map[string]*memoryClient
+	permissions    map[string]permissionRow
+	roles          map[string]*memoryRole
+	outbox         []*memoryOutboxEvent
+	now            func() time.Time
+}
+
+type memorySession struct {
+	row     sessionRow
+	revoked bool
+}
+
+type memoryResetToken struct {
+	userID    int
+	tokenHash string
+	expiresAt time.Time
+	used      bool
+}
+
+type memoryImpersonation struct {
+	row   impersonationRow
+	ended bool
+}
+
+type memoryAPIKey struct {
+	row     apiKeyRow
+	revoked bool
+}
+
+type memoryClient struct {
+	row     clientRow
+	revoked bool
+}
+
+type memoryRole struct {
+	row         roleRow // Permissions unused, see permissions
+	permissions map[string]bool
+}
+
+type memoryOutboxEvent struct {
+	event         outboxEvent
+	publishedAt   time.Time
+	nextAttemptAt time.Time
+	claimedBy     string
+	claimedAt     time.Time
+	lastError     string
+}
+
+func newMemoryAuthRepository() *memoryAuthRepository {
+	return &memoryAuthRepository{
+		nextID:      1,
+		users:       make(map[int]*credentialRow),
+		blacklist:   make(map[string]bool),
+		clients:     make(map[string]*memoryClient),
+		permissions: make(map[string]permissionRow),
+		roles:       make(map[string]*memoryRole),
+		now:         time.Now,
+	}
+}
+
+func (r *memoryAuthRepository) newID() int {
+	id := r.nextID
+	r.nextID++
+	return id
+}
+
+// encode turns events into outbox rows before anything is changed, so a
+// payload that cannot be marshaled leaves the repository untouched.
+func (r *memoryAuthRepository) encode(events []outboxMessage) ([]*memoryOutboxEvent, error) {
+	rows := make([]*memoryOutboxEvent, 0, len(events))
+	for _, event := range events {
+		body, err := json.Marshal(event.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling %s event: %w", event.RoutingKey, err)
+		}
+		rows = append(rows, &memoryOutboxEvent{
+			event:         outboxEvent{Exchange: event.Exchange, RoutingKey: event.RoutingKey, Payload: body},
+			nextAttemptAt: r.now(),
+		})
+	}
+	return rows, nil
+}
+
+func (r *memoryAuthRepository) store(rows []*memoryOutboxEvent) {
+	for _, row := range rows {
+		row.event.ID = int64(r.newID())
+		r.outbox = append(r.outbox, row)
+	}
+}
+
+// emailTaken reports whether email belongs to a row other than id.
+func (r *memoryAuthRepository) emailTaken(id int, email string) bool {
+	for _, u := range r.users {
+		if u.ID != id && u.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryAuthRepository) revokeSessions(userID int) int64 {
+	var revoked int64
+	for _, s := range r.sessions {
+		if s.row.UserID == userID && !s.revoked {
+			s.revoked = true
+			revoked++
+		}
+	}
+	return revoked
+}
+
+// --- Credentials ---
+
+func (r *memoryAuthRepository) GetCredentials(ctx context.Context, id int) (credentialRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[id]
+	if !ok {
+		return credentialRow{}, errCredentialsNotFound
+	}
+	return *u, nil
+}
+
+func (r *memoryAuthRepository) GetCredentialsByEmail(ctx context.Context, email string) (credentialRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, u := range r.users {
+		if u.Email == email {
+			return *u, nil
+		}
+	}
+	return credentialRow{}, errCredentialsNotFound
+}
+
+func (r *memoryAuthRepository) ListCredentials(ctx context.Context) ([]credentialRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows := make([]credentialRow, 0, len(r.users))
+	for _, u := range r.users {
+		rows = append(rows, *u)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+	return rows, nil
+}
+
+func (r *memoryAuthRepository) CreateCredentials(ctx context.Context, row credentialRow) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[row.ID]; ok {
+		return false, nil
+	}
+	if r.emailTaken(row.ID, row.Email) {
+		return false, errUserEmailConflict
+	}
+	if row.Status == "" {
+		row.Status = userStatusActive
+	}
+	row.CreatedAt = r.now()
+	row.VerificationSentAt, row.TokensRevokedAt, row.Deleted = time.Time{}, time.Time{}, false
+	r.users[row.ID] = &row
+	return true, nil
+}
+
+// updateProfile applies the non-empty fields, like COALESCE(NULLIF()).
+func (r *memoryAuthRepository) updateProfile(id int, firstName, lastName, email, role string, restore bool) (bool, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return false, nil
+	}
+	if email != "" && r.emailTaken(id, email) {
+		return false, errUserEmailConflict
+	}
+	for field, value := range map[*string]string{&u.FirstName: firstName, &u.LastName: lastName, &u.Email: email, &u.Role: role} {
+		if value != "" {
+			*field = value
+		}
+	}
+	if restore {
+		u.Deleted = false
+	}
+	u.Synced = true
+	return true, nil
+}
+
+func (r *memoryAuthRepository) UpdateProfile(ctx context.Context, id int, firstName, lastName, email, role string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateProfile(id, firstName, lastName, email, role, false)
+}
+
+func (r *memoryAuthRepository) RestoreCredentials(ctx context.Context, id int, firstName, lastName, email, role string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateProfile(id, firstName, lastName, email, role, true)
+}
+
+func (r *memoryAuthRepository) DeleteCredentials(ctx context.Context, id int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[id]
+	if !ok || u.Deleted {
+		return false, nil
+	}
+	u.Deleted, u.TokensRevokedAt, u.Synced = true, r.now(), true
+	r.revokeSessions(id)
+	return true, nil
+}
+
+func (r *memoryAuthRepository) ChangeRole(ctx context.Context, id int, role string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[id]
+	if !ok || u.Role == role {
+		return false, nil
+	}
+	u.Role, u.TokensRevokedAt, u.Synced = role, r.now(), true
+	r.revokeSessions(id)
+	return true, nil
+}
+
+func (r *memoryAuthRepository) ChangeEmail(ctx context.Context, id int, email string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[id]
+	if !ok || u.Email == email {
+		return false, nil
+	}
+	if r.emailTaken(id, email) {
+		return false, errUserEmailConflict
+	}
+	u.Email, u.Synced = email, true
+	for _, t := range r.resetTokens {
+		if t.userID == id {
+			t.used = true
+		}
+	}
+	return true, nil
+}
+
+func (r *memoryAuthRepository) PurgeCredentials(ctx context.Context, id int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var tokens []*memoryResetToken
+	for _, t := range r.resetTokens {
+		if t.userID != id {
+			tokens = append(tokens, t)
+		}
+	}
+	var sessions []*memorySession
+	for _, s := range r.sessions {
+		if s.row.UserID != id {
+			sessions = append(sessions, s)
+		}
+	}
+	var keys []*memoryAPIKey
+	for _, k := range r.apiKeys {
+		if k.row.UserID != id {
+			keys = append(keys, k)
+		}
+	}
+	var impersonations []*memoryImpersonation
+	for _, i := range r.impersonations {
+		if i.row.UserID != id && i.row.AdminID != id {
+			impersonations = append(impersonations, i)
+		}
+	}
+	r.resetTokens, r.sessions, r.apiKeys, r.impersonations = tokens, sessions, keys, impersonations
+
+	_, ok := r.users[id]
+	delete(r.users, id)
+	return ok, nil
+}
+
+func (r *memoryAuthRepository) SetPassword(ctx context.Context, id int, passwordHash string, events ...outboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows, err := r.encode(events)
+	if err != nil {
+		return err
+	}
+	if u, ok := r.users[id]; ok {
+		u.PasswordHash = passwordHash
+	}
+	r.store(rows)
+	return nil
+}
+
+func (r *memoryAuthRepository) ReplacePasswordHash(ctx context.Context, id int, oldHash, newHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if u, ok := r.users[id]; ok && u.PasswordHash == oldHash {
+		u.PasswordHash = newHash
+	}
+	return nil
+}
+
+func (r *memoryAuthRepository) RevokeAllTokens(ctx context.Context, id int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[id]
+	if !ok {
+		return 0, errCredentialsNotFound
+	}
+	u.TokensRevokedAt = r.now()
+	return r.revokeSessions(id), nil
+}
+
+func (r *memoryAuthRepository) ActivateCredentials(ctx context.Context, id int, events ...outboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows, err := r.encode(events)
+	if err != nil {
+		return err
+	}
+	if u, ok := r.users[id]; ok && u.Status == userStatusPendingVerification {
+		u.Status = userStatusActive
+	}
+	r.store(rows)
+	return nil
+}
+
+func (r *memoryAuthRepository) RecordVerificationSent(ctx context.Context, id int, events ...outboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows, err := r.encode(events)
+	if err != nil {
+		return err
+	}
+	if u, ok := r.users[id]; ok {
+		u.VerificationSentAt = r.now()
+	}
+	r.store(rows)
+	return nil
+}
+
+// --- Password reset tokens ---
+
+func (r *memoryAuthRepository) CreateResetToken(ctx context.Context, userID int, tokenHash string, expiresAt time.Time, events ...outboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows, err := r.encode(events)
+	if err != nil {
+		return err
+	}
+	for _, t := range r.resetTokens {
+		if t.userID == userID {
+			t.used = true
+		}
+	}
+	r.resetTokens = append(r.resetTokens, &memoryResetToken{userID: userID, tokenHash: tokenHash, expiresAt: expiresAt})
+	r.store(rows)
+	return nil
+}
+
+// usableResetToken returns the token and its owner if both are still valid.
+func (r *memoryAuthRepository) usableResetToken(tokenHash string) (*memoryResetToken, *credentialRow) {
+	for _, t := range r.resetTokens {
+		if t.tokenHash != tokenHash || t.used || !t.expiresAt.After(r.now()) {
+			continue
+		}
+		if u, ok := r.users[t.userID]; ok && !u.Deleted {
+			return t, u
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAuthRepository) ResetTokenOwner(ctx context.Context, tokenHash string) (credentialRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, u := r.usableResetToken(tokenHash)
+	if u == nil {
+		return credentialRow{}, errResetTokenInvalid
+	}
+	return *u, nil
+}
+
+func (r *memoryAuthRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string, events ...outboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows, err := r.encode(events)
+	if err != nil {
+		return err
+	}
+	t, u := r.usableResetToken(tokenHash)
+	if t == nil {
+		return errResetTokenInvalid
+	}
+	t.used = true
+	u.PasswordHash, u.TokensRevokedAt = passwordHash, r.now()
+	r.revokeSessions(u.ID)
+	r.store(rows)
+	return nil
+}
+
+// --- Sessions ---
+
+func (r *memoryAuthRepository) CreateSession(ctx context.Context, session sessionRow, events ...outboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows, err := r.encode(events)
+	if err != nil {
+		return err
+	}
+	session.ID = r.newID()
+	session.CreatedAt, session.LastSeenAt = r.now(), r.now()
+	r.sessions = append(r.sessions, &memorySession{row: session})
+	r.store(rows)
+	return nil
+}
+
+func (r *memoryAuthRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.blacklist[jti] {
+		return true, nil
+	}
+	for _, s := range r.sessions {
+		if s.row.JTI == jti && s.revoked {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryAuthRepository) TouchSession(ctx context.Context, jti string, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.sessions {
+		if s.row.JTI == jti && s.row.LastSeenAt.Before(before) {
+			s.row.LastSeenAt = r.now()
+		}
+	}
+	return nil
+}
+
+func (r *memoryAuthRepository) ListSessions(ctx context.Context, userID int) ([]sessionRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sessions := make([]sessionRow, 0)
+	for _, s := range r.sessions {
+		if s.row.UserID == userID && !s.revoked && s.row.ExpiresAt.After(r.now()) {
+			sessions = append(sessions, s.row)
+		}
+	}
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt) })
+	return sessions, nil
+}
+
+func (r *memoryAuthRepository) RevokeSession(ctx context.Context, id, userID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.sessions {
+		if s.row.ID == id && s.row.UserID == userID && !s.revoked {
+			s.revoked = true
+			return nil
+		}
+	}
+	return errSessionNotFound
+}
+
+func (r *memoryAuthRepository) RevokeOtherSessions(ctx context.Context, userID int, keepJTI string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var revoked int64
+	for _, s := range r.sessions {
+		if s.row.UserID == userID && s.row.JTI != keepJTI && !s.revoked {
+			s.revoked = true
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (r *memoryAuthRepository) EndToken(ctx context.Context, jti string, userID int, events ...outboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows, err := r.encode(events)
+	if err != nil {
+		return err
+	}
+	r.blacklist[jti] = true
+	for _, s := range r.sessions {
+		if s.row.JTI == jti {
+			s.revoked = true
+		}
+	}
+	for _, i := range r.impersonations {
+		if i.row.JTI == jti {
+			i.ended = true
+		}
+	}
+	r.store(rows)
+	return nil
+}
+
+func (r *memoryAuthRepository) CreateImpersonation(ctx context.Context, impersonation impersonationRow, events ...outboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows, err := r.encode(events)
+	if err != nil {
+		return err
+	}
+	r.impersonations = append(r.impersonations, &memoryImpersonation{row: impersonation})
+	r.store(rows)
+	return nil
+}
+
+// --- API keys ---
+
+func (r *memoryAuthRepository) CreateAPIKey(ctx context.Context, key apiKeyRow) (apiKeyRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key.ID = r.newID()
+	key.CreatedAt = r.now()
+	key.Scopes = append([]string(nil), key.Scopes...)
+	r.apiKeys = append(r.apiKeys, &memoryAPIKey{row: key})
+	return key, nil
+}
+
+func (r *memoryAuthRepository) ListAPIKeys(ctx context.Context, userID int) ([]apiKeyRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := make([]apiKeyRow, 0)
+	for i := len(r.apiKeys) - 1; i >= 0; i-- {
+		k := r.apiKeys[i]
+		if k.row.UserID == userID && !k.revoked && k.row.ExpiresAt.After(r.now()) {
+			keys = append(keys, k.row)
+		}
+	}
+	return keys, nil
+}
+
+func (r *memoryAuthRepository) RevokeAPIKey(ctx context.Context, id, userID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, k := range r.apiKeys {
+		if k.row.ID == id && k.row.UserID == userID && !k.revoked {
+			k.revoked = true
+			return nil
+		}
+	}
+	return errAPIKeyNotFound
+}
+
+func (r *memoryAuthRepository) ActiveAPIKey(ctx context.Context, keyHash string) (apiKeyRow, credentialRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, k := range r.apiKeys {
+		if k.row.KeyHash != keyHash || k.revoked || !k.row.ExpiresAt.After(r.now()) {
+			continue
+		}
+		u, ok := r.users[k.row.UserID]
+		if !ok || u.Deleted || (!u.TokensRevokedAt.IsZero() && !k.row.CreatedAt.After(u.TokensRevokedAt)) {
+			continue
+		}
+		return k.row, *u, nil
+	}
+	return apiKeyRow{}, credentialRow{}, errAPIKeyNotFound
+}
+
+func (r *memoryAuthRepository) TouchAPIKey(ctx context.Context, id int, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, k := range r.apiKeys {
+		if k.row.ID == id && (k.row.LastUsedAt.IsZero() || k.row.LastUsedAt.Before(before)) {
+			k.row.LastUsedAt = r.now()
+		}
+	}
+	return nil
+}
+
+// --- OAuth clients ---
+
+func (r *memoryAuthRepository) CreateClient(ctx context.Context, client clientRow) (clientRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client.CreatedAt = r.now()
+	r.clients[client.ClientID] = &memoryClient{row: client}
+	return client, nil
+}
+
+func (r *memoryAuthRepository) GetClient(ctx context.Context, clientID string) (clientRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.clients[clientID]
+	if !ok || c.revoked {
+		return clientRow{}, errClientNotFound
+	}
+	return c.row, nil
+}
+
+func (r *memoryAuthRepository) ListClients(ctx context.Context) ([]clientRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clients := make([]clientRow, 0)
+	for _, c := range r.clients {
+		if !c.revoked {
+			clients = append(clients, c.row)
+		}
+	}
+	sort.Slice(clients, func(i, j int) bool { return clients[i].CreatedAt.Before(clients[j].CreatedAt) })
+	return clients, nil
+}
+
+func (r *memoryAuthRepository) RevokeClient(ctx context.Context, clientID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.clients[clientID]
+	if !ok || c.revoked {
+		return errClientNotFound
+	}
+	c.revoked = true
+	return nil
+}
+
+// --- Roles and permissions ---
+
+func (r *memoryAuthRepository) ListPermissions(ctx context.Context) ([]permissionRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	permissions := make([]permissionRow, 0, len(r.permissions))
+	for _, p := range r.permissions {
+		permissions = append(permissions, p)
+	}
+	sort.Slice(permissions, func(i, j int) bool { return permissions[i].Name < permissions[j].Name })
+	return permissions, nil
+}
+
+func (r *memoryAuthRepository) GetPermission(ctx context.Context, name string) (permissionRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.permissions[name]
+	if !ok {
+		return permissionRow{}, errPermissionNotFound
+	}
+	return p, nil
+}
+
+func (r *memoryAuthRepository) CreatePermission(ctx context.Context, permission permissionRow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.permissions[permission.Name]; ok {
+		return errPermissionExists
+	}
+	r.permissions[permission.Name] = permission
+	return nil
+}
+
+func (r *memoryAuthRepository) MarkPermissionSystem(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p, ok := r.permissions[name]; ok {
+		p.System = true
+		r.permissions[name] = p
+	}
+	return nil
+}
+
+func (r *memoryAuthRepository) DeletePermission(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.permissions, name)
+	for _, role := range r.roles {
+		delete(role.permissions, name)
+	}
+	return nil
+}
+
+func (r *memoryAuthRepository) roleRow(role *memoryRole) roleRow {
+	row := role.row
+	row.Permissions = make([]string, 0, len(role.permissions))
+	for permission := range role.permissions {
+		row.Permissions = append(row.Permissions, permission)
+	}
+	sort.Strings(row.Permissions)
+	return row
+}
+
+func (r *memoryAuthRepository) ListRoles(ctx context.Context) ([]roleRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	roles := make([]roleRow, 0, len(r.roles))
+	for _, role := range r.roles {
+		roles = append(roles, r.roleRow(role))
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	return roles, nil
+}
+
+func (r *memoryAuthRepository) GetRole(ctx context.Context, name string) (roleRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	role, ok := r.roles[name]
+	if !ok {
+		return roleRow{}, errRoleNotFound
+	}
+	return r.roleRow(role), nil
+}
+
+func (r *memoryAuthRepository) FindRole(ctx context.Context, name string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for roleName := range r.roles {
+		if strings.EqualFold(roleName, name) {
+			return roleName, nil
+		}
+	}
+	return "", errRoleNotFound
+}
+
+func (r *memoryAuthRepository) CreateRole(ctx context.Context, role roleRow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.roles[role.Name]; ok {
+		return errRoleExists
+	}
+	stored := &memoryRole{row: roleRow{Name: role.Name, Description: role.Description, System: role.System}, permissions: make(map[string]bool)}
+	for _, permission := range role.Permissions {
+		stored.permissions[permission] = true
+	}
+	r.roles[role.Name] = stored
+	return nil
+}
+
+func (r *memoryAuthRepository) UpdateRole(ctx context.Context, name, description string, permissions []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	role, ok := r.roles[name]
+	if !ok {
+		return errRoleNotFound
+	}
+	role.row.Description = description
+	role.permissions = make(map[string]bool)
+	for _, permission := range permissions {
+		role.permissions[permission] = true
+	}
+	return nil
+}
+
+func (r *memoryAuthRepository) GrantPermissions(ctx context.Context, role string, permissions []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.roles[role]
+	if !ok {
+		return errRoleNotFound
+	}
+	for _, permission := range permissions {
+		stored.permissions[permission] = true
+	}
+	return nil
+}
+
+func (r *memoryAuthRepository) RolePermissions(ctx context.Context, role string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.roles[role]
+	if !ok {
+		return []string{}, nil
+	}
+	return r.roleRow(stored).Permissions, nil
+}
+
+func (r *memoryAuthRepository) CountRoleUsers(ctx context.Context, role string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, u := range r.users {
+		if u.Role == role && !u.Deleted {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryAuthRepository) DeleteRole(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Deleted users still reference the role, as with the foreign key
+	for _, u := range r.users {
+		if u.Role == name {
+			return errRoleInUse
+		}
+	}
+	delete(r.roles, name)
+	return nil
+}
+
+// --- Outbox ---
+
+func (r *memoryAuthRepository) Enqueue(ctx context.Context, events ...outboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows, err := r.encode(events)
+	if err != nil {
+		return err
+	}
+	r.store(rows)
+	return nil
+}
+
+func (r *memoryAuthRepository) ClaimEvents(ctx context.Context, owner string, staleBefore time.Time, limit int) ([]outboxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := make([]outboxEvent, 0)
+	for _, row := range r.outbox {
+		if len(events) == limit {
+			break
+		}
+		if !row.publishedAt.IsZero() || row.nextAttemptAt.After(r.now()) {
+			continue
+		}
+		if !row.claimedAt.IsZero() && !row.claimedAt.Before(staleBefore) {
+			continue
+		}
+		row.claimedBy, row.claimedAt = owner, r.now()
+		events = append(events, row.event)
+	}
+	return events, nil
+}
+
+func (r *memoryAuthRepository) outboxRow(id int64) *memoryOutboxEvent {
+	for _, row := range r.outbox {
+		if row.event.ID == id {
+			return row
+		}
+	}
+	return nil
+}
+
+func (r *memoryAuthRepository) MarkEventPublished(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if row := r.outboxRow(id); row != nil {
+		row.publishedAt = r.now()
+		row.event.Attempts++
+		row.claimedBy, row.claimedAt = "", time.Time{}
+	}
+	return nil
+}
+
+func (r *memoryAuthRepository) MarkEventFailed(ctx context.Context, id int64, owner string, attempts int, lastError string, nextAttempt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if row := r.outboxRow(id); row != nil && row.claimedBy == owner {
+		row.event.Attempts, row.lastError, row.nextAttemptAt = attempts, lastError, nextAttempt
+		row.claimedBy, row.claimedAt = "", time.Time{}
+	}
+	return nil
+}
+
+func (r *memoryAuthRepository) ReleaseEvents(ctx context.Context, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, row := range r.outbox {
+		if row.claimedBy == owner && row.publishedAt.IsZero() {
+			row.claimedBy, row.claimedAt = "", time.Time{}
+		}
+	}
+	return nil
+}
+
+func (r *memoryAuthRepository) DeletePublishedEvents(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	kept := r.outbox[:0]
+	for _, row := range r.outbox {
+		if !row.publishedAt.IsZero() && row.publishedAt.Before(before) {
+			deleted++
+			continue
+		}
+		kept = append(kept, row)
+	}
+	r.outbox = kept
+	return deleted, nil
+}
@@ -0,0 +1,978 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// postgresAuthRepository is the production AuthRepository.
+type postgresAuthRepository struct {
+	db *sql.DB
+}
+
+func newPostgresAuthRepository(db *sql.DB) *postgresAuthRepository {
+	return &postgresAuthRepository{db: db}
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation"
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code.Name() == "foreign_key_violation"
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// inTx runs fn in a transaction and commits it if fn succeeds.
+func (r *postgresAuthRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// enqueue stores events in outbox_events.
+func enqueue(ctx context.Context, ex execer, events []outboxMessage) error {
+	for _, event := range events {
+		body, err := json.Marshal(event.Payload)
+		if err != nil {
+			return fmt.Errorf("error marshaling %s event: %w", event.RoutingKey, err)
+		}
+		_, err = ex.ExecContext(ctx, `
+			INSERT INTO outbox_events (exchange, routing_key, payload)
+			VALUES ($1, $2, $3)
+		`, event.Exchange, event.RoutingKey, body)
+		if err != nil {
+			return fmt.Errorf("error enqueueing %s event: %w", event.RoutingKey, err)
+		}
+	}
+	return nil
+}
+
+// affected reports whether res changed at least one row.
+func affected(res sql.Result) bool {
+	n, _ := res.RowsAffected()
+	return n > 0
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// --- Credentials ---
+
+const credentialColumns = `id, first_name, last_name, email, password, role, status, created_at,
+	verification_sent_at, tokens_revoked_at, deleted_at IS NOT NULL, synced_at IS NOT NULL`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCredentials(row rowScanner) (credentialRow, error) {
+	var c credentialRow
+	var sentAt, revokedAt sql.NullTime
+	err := row.Scan(&c.ID, &c.FirstName, &c.LastName, &c.Email, &c.PasswordHash, &c.Role, &c.Status, &c.CreatedAt,
+		&sentAt, &revokedAt, &c.Deleted, &c.Synced)
+	if err == sql.ErrNoRows {
+		return credentialRow{}, errCredentialsNotFound
+	}
+	c.VerificationSentAt = sentAt.Time
+	c.TokensRevokedAt = revokedAt.Time
+	return c, err
+}
+
+func (r *postgresAuthRepository) GetCredentials(ctx context.Context, id int) (credentialRow, error) {
+	return scanCredentials(r.db.QueryRowContext(ctx, `SELECT `+credentialColumns+` FROM users WHERE id = $1`, id))
+}
+
+func (r *postgresAuthRepository) GetCredentialsByEmail(ctx context.Context, email string) (credentialRow, error) {
+	return scanCredentials(r.db.QueryRowContext(ctx, `SELECT `+credentialColumns+` FROM users WHERE email = $1`, email))
+}
+
+func (r *postgresAuthRepository) ListCredentials(ctx context.Context) ([]credentialRow, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+credentialColumns+` FROM users ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]credentialRow, 0)
+	for rows.Next() {
+		c, err := scanCredentials(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+func (r *postgresAuthRepository) CreateCredentials(ctx context.Context, c credentialRow) (bool, error) {
+	var syncedAt sql.NullTime
+	if c.Synced {
+		syncedAt = nullTime(time.Now())
+	}
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO users (id, first_name, last_name, email, password, role, status, synced_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO NOTHING
+	`, c.ID, c.FirstName, c.LastName, c.Email, c.PasswordHash, c.Role, c.Status, syncedAt)
+	if isUniqueViolation(err) {
+		return false, errUserEmailConflict
+	}
+	if err != nil {
+		return false, err
+	}
+	return affected(res), nil
+}
+
+func (r *postgresAuthRepository) UpdateProfile(ctx context.Context, id int, firstName, lastName, email, role string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE users
+		SET first_name = COALESCE(NULLIF($2, ''), first_name),
+			last_name = COALESCE(NULLIF($3, ''), last_name),
+			email = COALESCE(NULLIF($4, ''), email),
+			role = COALESCE(NULLIF($5, ''), role),
+			synced_at = NOW()
+		WHERE id = $1
+	`, id, firstName, lastName, email, role)
+	if isUniqueViolation(err) {
+		return false, errUserEmailConflict
+	}
+	if err != nil {
+		return false, err
+	}
+	return affected(res), nil
+}
+
+func (r *postgresAuthRepository) RestoreCredentials(ctx context.Context, id int, firstName, lastName, email, role string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE users
+		SET deleted_at = NULL,
+			first_name = COALESCE(NULLIF($2, ''), first_name),
+			last_name = COALESCE(NULLIF($3, ''), last_name),
+			email = COALESCE(NULLIF($4, ''), email),
+			role = COALESCE(NULLIF($5, ''), role),
+			synced_at = NOW()
+		WHERE id = $1
+	`, id, firstName, lastName, email, role)
+	if isUniqueViolation(err) {
+		return false, errUserEmailConflict
+	}
+	if err != nil {
+		return false, err
+	}
+	return affected(res), nil
+}
+
+// revokeSessions revokes every open session of a user.
+func revokeSessions(ctx context.Context, ex execer, userID int) (int64, error) {
+	res, err := ex.ExecContext(ctx, `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("error revoking sessions: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func (r *postgresAuthRepository) DeleteCredentials(ctx context.Context, id int) (bool, error) {
+	deleted := false
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE users
+			SET deleted_at = NOW(), tokens_revoked_at = NOW(), synced_at = NOW()
+			WHERE id = $1 AND deleted_at IS NULL
+		`, id)
+		if err != nil || !affected(res) {
+			return err
+		}
+		deleted = true
+		_, err = revokeSessions(ctx, tx, id)
+		return err
+	})
+	return deleted, err
+}
+
+func (r *postgresAuthRepository) ChangeRole(ctx context.Context, id int, role string) (bool, error) {
+	changed := false
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE users
+			SET role = $2, tokens_revoked_at = NOW(), synced_at = NOW()
+			WHERE id = $1 AND role IS DISTINCT FROM $2
+		`, id, role)
+		if err != nil || !affected(res) {
+			return err
+		}
+		changed = true
+		_, err = revokeSessions(ctx, tx, id)
+		return err
+	})
+	return changed, err
+}
+
+func (r *postgresAuthRepository) ChangeEmail(ctx context.Context, id int, email string) (bool, error) {
+	changed := false
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE users
+			SET email = $2, synced_at = NOW()
+			WHERE id = $1 AND email IS DISTINCT FROM $2
+		`, id, email)
+		if isUniqueViolation(err) {
+			return errUserEmailConflict
+		}
+		if err != nil || !affected(res) {
+			return err
+		}
+		changed = true
+		_, err = tx.ExecContext(ctx, `
+			UPDATE password_reset_tokens
+			SET used_at = NOW()
+			WHERE user_id = $1 AND used_at IS NULL
+		`, id)
+		return err
+	})
+	return changed, err
+}
+
+func (r *postgresAuthRepository) PurgeCredentials(ctx context.Context, id int) (bool, error) {
+	purged := false
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		for _, query := range []string{
+			`DELETE FROM password_reset_tokens WHERE user_id = $1`,
+			`DELETE FROM sessions WHERE user_id = $1`,
+			`DELETE FROM api_keys WHERE user_id = $1`,
+			`DELETE FROM impersonations WHERE user_id = $1 OR admin_id = $1`,
+		} {
+			if _, err := tx.ExecContext(ctx, query, id); err != nil {
+				return err
+			}
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+		if err != nil {
+			return err
+		}
+		purged = affected(res)
+		return nil
+	})
+	return purged, err
+}
+
+func (r *postgresAuthRepository) SetPassword(ctx context.Context, id int, passwordHash string, events ...outboxMessage) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `UPDATE users SET password = $1 WHERE id = $2`, passwordHash, id); err != nil {
+			return err
+		}
+		return enqueue(ctx, tx, events)
+	})
+}
+
+func (r *postgresAuthRepository) ReplacePasswordHash(ctx context.Context, id int, oldHash, newHash string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE users SET password = $1 WHERE id = $2 AND password = $3`, newHash, id, oldHash)
+	return err
+}
+
+func (r *postgresAuthRepository) RevokeAllTokens(ctx context.Context, id int) (int64, error) {
+	var revoked int64
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `UPDATE users SET tokens_revoked_at = NOW() WHERE id = $1`, id)
+		if err != nil {
+			return err
+		}
+		if !affected(res) {
+			return errCredentialsNotFound
+		}
+		revoked, err = revokeSessions(ctx, tx, id)
+		return err
+	})
+	return revoked, err
+}
+
+func (r *postgresAuthRepository) ActivateCredentials(ctx context.Context, id int, events ...outboxMessage) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE users
+			SET status = $1
+			WHERE id = $2 AND status = $3
+		`, userStatusActive, id, userStatusPendingVerification)
+		if err != nil {
+			return err
+		}
+		return enqueue(ctx, tx, events)
+	})
+}
+
+func (r *postgresAuthRepository) RecordVerificationSent(ctx context.Context, id int, events ...outboxMessage) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `UPDATE users SET verification_sent_at = NOW() WHERE id = $1`, id); err != nil {
+			return err
+		}
+		return enqueue(ctx, tx, events)
+	})
+}
+
+// --- Password reset tokens ---
+
+func (r *postgresAuthRepository) CreateResetToken(ctx context.Context, userID int, tokenHash string, expiresAt time.Time, events ...outboxMessage) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE password_reset_tokens
+			SET used_at = NOW()
+			WHERE user_id = $1 AND used_at IS NULL
+		`, userID)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+				VALUES ($1, $2, $3)
+			`, userID, tokenHash, expiresAt)
+		}
+		if err != nil {
+			return err
+		}
+		return enqueue(ctx, tx, events)
+	})
+}
+
+func (r *postgresAuthRepository) ResetTokenOwner(ctx context.Context, tokenHash string) (credentialRow, error) {
+	c, err := scanCredentials(r.db.QueryRowContext(ctx, `
+		SELECT `+credentialColumns+`
+		FROM users
+		WHERE deleted_at IS NULL AND id = (
+			SELECT user_id
+			FROM password_reset_tokens
+			WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		)
+	`, tokenHash))
+	if err == errCredentialsNotFound {
+		return credentialRow{}, errResetTokenInvalid
+	}
+	return c, err
+}
+
+func (r *postgresAuthRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string, events ...outboxMessage) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		// Consume the token atomically: it must be unused and not expired
+		var userID int
+		err := tx.QueryRowContext(ctx, `
+			UPDATE password_reset_tokens
+			SET used_at = NOW()
+			WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+			RETURNING user_id
+		`, tokenHash).Scan(&userID)
+		if err == sql.ErrNoRows {
+			return errResetTokenInvalid
+		}
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, `
+			UPDATE users
+			SET password = $1, tokens_revoked_at = NOW()
+			WHERE id = $2 AND deleted_at IS NULL
+		`, passwordHash, userID)
+		if err != nil {
+			return err
+		}
+		if !affected(res) {
+			return errResetTokenInvalid
+		}
+		if _, err := revokeSessions(ctx, tx, userID); err != nil {
+			return err
+		}
+		return enqueue(ctx, tx, events)
+	})
+}
+
+// --- Sessions ---
+
+func (r *postgresAuthRepository) CreateSession(ctx context.Context, session sessionRow, events ...outboxMessage) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO sessions (user_id, jti, user_agent, ip_address, expires_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`, session.UserID, session.JTI, session.UserAgent, session.IPAddress, session.ExpiresAt)
+		if err != nil {
+			return fmt.Errorf("error creating session: %w", err)
+		}
+		return enqueue(ctx, tx, events)
+	})
+}
+
+func (r *postgresAuthRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM token_blacklist WHERE jti = $1)
+			OR EXISTS (SELECT 1 FROM sessions WHERE jti = $1 AND revoked_at IS NOT NULL)
+	`, jti).Scan(&revoked)
+	return revoked, err
+}
+
+func (r *postgresAuthRepository) TouchSession(ctx context.Context, jti string, before time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE sessions
+		SET last_seen_at = NOW()
+		WHERE jti = $1 AND last_seen_at < $2
+	`, jti, before)
+	return err
+}
+
+func (r *postgresAuthRepository) ListSessions(ctx context.Context, userID int) ([]sessionRow, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, jti, user_agent, ip_address, created_at, last_seen_at, expires_at
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_seen_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]sessionRow, 0)
+	for rows.Next() {
+		var s sessionRow
+		if err := rows.Scan(&s.ID, &s.UserID, &s.JTI, &s.UserAgent, &s.IPAddress, &s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *postgresAuthRepository) RevokeSession(ctx context.Context, id, userID int) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`, id, userID)
+	if err != nil {
+		return err
+	}
+	if !affected(res) {
+		return errSessionNotFound
+	}
+	return nil
+}
+
+func (r *postgresAuthRepository) RevokeOtherSessions(ctx context.Context, userID int, keepJTI string) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND jti <> $2 AND revoked_at IS NULL
+	`, userID, keepJTI)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *postgresAuthRepository) EndToken(ctx context.Context, jti string, userID int, events ...outboxMessage) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		// A token that is already blacklisted is fine, so the insert does not
+		// abort the transaction
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO token_blacklist (jti, user_id)
+			VALUES ($1, $2)
+			ON CONFLICT (jti) DO NOTHING
+		`, jti, userID)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE sessions
+				SET revoked_at = NOW()
+				WHERE jti = $1 AND revoked_at IS NULL
+			`, jti)
+		}
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE impersonations
+				SET ended_at = NOW()
+				WHERE jti = $1 AND ended_at IS NULL
+			`, jti)
+		}
+		if err != nil {
+			return err
+		}
+		return enqueue(ctx, tx, events)
+	})
+}
+
+func (r *postgresAuthRepository) CreateImpersonation(ctx context.Context, imp impersonationRow, events ...outboxMessage) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO impersonations (admin_id, user_id, jti, reason, ip_address, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, imp.AdminID, imp.UserID, imp.JTI, imp.Reason, imp.IPAddress, imp.ExpiresAt)
+		if err != nil {
+			return err
+		}
+		return enqueue(ctx, tx, events)
+	})
+}
+
+// --- API keys ---
+
+func (r *postgresAuthRepository) CreateAPIKey(ctx context.Context, key apiKeyRow) (apiKeyRow, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, key.UserID, key.Name, key.Prefix, key.KeyHash, strings.Join(key.Scopes, " "), key.ExpiresAt).Scan(&key.ID, &key.CreatedAt)
+	return key, err
+}
+
+func (r *postgresAuthRepository) ListAPIKeys(ctx context.Context, userID int) ([]apiKeyRow, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, name, prefix, scopes, created_at, expires_at, last_used_at
+		FROM api_keys
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]apiKeyRow, 0)
+	for rows.Next() {
+		var key apiKeyRow
+		var scopeList string
+		var lastUsedAt sql.NullTime
+		if err := rows.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &scopeList, &key.CreatedAt, &key.ExpiresAt, &lastUsedAt); err != nil {
+			return nil, err
+		}
+		key.Scopes = strings.Fields(scopeList)
+		key.LastUsedAt = lastUsedAt.Time
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func (r *postgresAuthRepository) RevokeAPIKey(ctx context.Context, id, userID int) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE api_keys
+		SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`, id, userID)
+	if err != nil {
+		return err
+	}
+	if !affected(res) {
+		return errAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *postgresAuthRepository) ActiveAPIKey(ctx context.Context, keyHash string) (apiKeyRow, credentialRow, error) {
+	var key apiKeyRow
+	var owner credentialRow
+	var scopeList string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT k.id, k.scopes, k.created_at, k.expires_at, u.id, u.email, u.role
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE k.key_hash = $1
+			AND k.revoked_at IS NULL
+			AND k.expires_at > NOW()
+			AND u.deleted_at IS NULL
+			AND (u.tokens_revoked_at IS NULL OR k.created_at > u.tokens_revoked_at)
+	`, keyHash).Scan(&key.ID, &scopeList, &key.CreatedAt, &key.ExpiresAt, &owner.ID, &owner.Email, &owner.Role)
+	if err == sql.ErrNoRows {
+		return apiKeyRow{}, credentialRow{}, errAPIKeyNotFound
+	}
+	key.UserID = owner.ID
+	key.Scopes = strings.Fields(scopeList)
+	return key, owner, err
+}
+
+func (r *postgresAuthRepository) TouchAPIKey(ctx context.Context, id int, before time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE api_keys
+		SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $2)
+	`, id, before)
+	return err
+}
+
+// --- OAuth clients ---
+
+func (r *postgresAuthRepository) CreateClient(ctx context.Context, client clientRow) (clientRow, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO oauth_clients (client_id, client_secret_hash, name, scopes, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`, client.ClientID, client.SecretHash, client.Name, strings.Join(client.Scopes, " "), client.CreatedBy).Scan(&client.CreatedAt)
+	return client, err
+}
+
+func (r *postgresAuthRepository) GetClient(ctx context.Context, clientID string) (clientRow, error) {
+	client := clientRow{ClientID: clientID}
+	var scopeList string
+	var createdBy sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT client_secret_hash, name, scopes, created_by, created_at
+		FROM oauth_clients
+		WHERE client_id = $1 AND revoked_at IS NULL
+	`, clientID).Scan(&client.SecretHash, &client.Name, &scopeList, &createdBy, &client.CreatedAt)
+	if err == sql.ErrNoRows {
+		return clientRow{}, errClientNotFound
+	}
+	client.Scopes = strings.Fields(scopeList)
+	client.CreatedBy = int(createdBy.Int64)
+	return client, err
+}
+
+func (r *postgresAuthRepository) ListClients(ctx context.Context) ([]clientRow, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT client_id, name, scopes, created_at
+		FROM oauth_clients
+		WHERE revoked_at IS NULL
+		ORDER BY created_at
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clients := make([]clientRow, 0)
+	for rows.Next() {
+		var client clientRow
+		var scopeList string
+		if err := rows.Scan(&client.ClientID, &client.Name, &scopeList, &client.CreatedAt); err != nil {
+			return nil, err
+		}
+		client.Scopes = strings.Fields(scopeList)
+		clients = append(clients, client)
+	}
+	return clients, rows.Err()
+}
+
+func (r *postgresAuthRepository) RevokeClient(ctx context.Context, clientID string) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE oauth_clients
+		SET revoked_at = NOW()
+		WHERE client_id = $1 AND revoked_at IS NULL
+	`, clientID)
+	if err != nil {
+		return err
+	}
+	if !affected(res) {
+		return errClientNotFound
+	}
+	return nil
+}
+
+// --- Roles and permissions ---
+
+// linkUserRoles replaces the fixed role CHECK constraint on users with a
+// foreign key, which needs the seeded roles. It runs at startup after
+// initPermissions.
+func (r *postgresAuthRepository) linkUserRoles(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, `
+		ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+		DO $$
+		BEGIN
+			IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'users_role_fkey') THEN
+				ALTER TABLE users ADD CONSTRAINT users_role_fkey
+					FOREIGN KEY (role) REFERENCES roles(name) ON UPDATE CASCADE;
+			END IF;
+		END $$;
+	`)
+	return err
+}
+
+func (r *postgresAuthRepository) ListPermissions(ctx context.Context) ([]permissionRow, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT name, description, is_system FROM permissions ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := make([]permissionRow, 0)
+	for rows.Next() {
+		var p permissionRow
+		if err := rows.Scan(&p.Name, &p.Description, &p.System); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, p)
+	}
+	return permissions, rows.Err()
+}
+
+func (r *postgresAuthRepository) GetPermission(ctx context.Context, name string) (permissionRow, error) {
+	p := permissionRow{Name: name}
+	err := r.db.QueryRowContext(ctx, `SELECT description, is_system FROM permissions WHERE name = $1`, name).Scan(&p.Description, &p.System)
+	if err == sql.ErrNoRows {
+		return permissionRow{}, errPermissionNotFound
+	}
+	return p, err
+}
+
+func (r *postgresAuthRepository) CreatePermission(ctx context.Context, p permissionRow) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO permissions (name, description, is_system)
+		VALUES ($1, $2, $3)
+	`, p.Name, p.Description, p.System)
+	if isUniqueViolation(err) {
+		return errPermissionExists
+	}
+	return err
+}
+
+func (r *postgresAuthRepository) MarkPermissionSystem(ctx context.Context, name string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE permissions SET is_system = TRUE WHERE name = $1`, name)
+	return err
+}
+
+func (r *postgresAuthRepository) DeletePermission(ctx context.Context, name string) error {
+	// role_permissions rows go with it (ON DELETE CASCADE)
+	_, err := r.db.ExecContext(ctx, `DELETE FROM permissions WHERE name = $1`, name)
+	return err
+}
+
+const roleQuery = `
+	SELECT r.name, r.description, r.is_system,
+		COALESCE(array_agg(rp.permission_name ORDER BY rp.permission_name) FILTER (WHERE rp.permission_name IS NOT NULL), '{}')
+	FROM roles r
+	LEFT JOIN role_permissions rp ON rp.role_name = r.name
+`
+
+func scanRole(row rowScanner) (roleRow, error) {
+	var role roleRow
+	err := row.Scan(&role.Name, &role.Description, &role.System, pq.Array(&role.Permissions))
+	if err == sql.ErrNoRows {
+		return roleRow{}, errRoleNotFound
+	}
+	return role, err
+}
+
+func (r *postgresAuthRepository) ListRoles(ctx context.Context) ([]roleRow, error) {
+	rows, err := r.db.QueryContext(ctx, roleQuery+`
+		GROUP BY r.name, r.description, r.is_system
+		ORDER BY r.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make([]roleRow, 0)
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+func (r *postgresAuthRepository) GetRole(ctx context.Context, name string) (roleRow, error) {
+	return scanRole(r.db.QueryRowContext(ctx, roleQuery+`
+		WHERE r.name = $1
+		GROUP BY r.name, r.description, r.is_system
+	`, name))
+}
+
+func (r *postgresAuthRepository) FindRole(ctx context.Context, name string) (string, error) {
+	var found string
+	err := r.db.QueryRowContext(ctx, `SELECT name FROM roles WHERE LOWER(name) = LOWER($1)`, name).Scan(&found)
+	if err == sql.ErrNoRows {
+		return "", errRoleNotFound
+	}
+	return found, err
+}
+
+// replaceRolePermissions sets the permissions of role to exactly permissions.
+func replaceRolePermissions(ctx context.Context, tx *sql.Tx, role string, permissions []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM role_permissions WHERE role_name = $1`, role); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO role_permissions (role_name, permission_name)
+		SELECT $1, unnest($2::text[])
+	`, role, pq.Array(permissions))
+	return err
+}
+
+func (r *postgresAuthRepository) CreateRole(ctx context.Context, role roleRow) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO roles (name, description, is_system)
+			VALUES ($1, $2, $3)
+		`, role.Name, role.Description, role.System)
+		if isUniqueViolation(err) {
+			return errRoleExists
+		}
+		if err != nil {
+			return err
+		}
+		return replaceRolePermissions(ctx, tx, role.Name, role.Permissions)
+	})
+}
+
+func (r *postgresAuthRepository) UpdateRole(ctx context.Context, name, description string, permissions []string) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `UPDATE roles SET description = $1 WHERE name = $2`, description, name)
+		if err != nil {
+			return err
+		}
+		if !affected(res) {
+			return errRoleNotFound
+		}
+		return replaceRolePermissions(ctx, tx, name, permissions)
+	})
+}
+
+func (r *postgresAuthRepository) GrantPermissions(ctx context.Context, role string, permissions []string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO role_permissions (role_name, permission_name)
+		SELECT $1, unnest($2::text[])
+		ON CONFLICT DO NOTHING
+	`, role, pq.Array(permissions))
+	return err
+}
+
+func (r *postgresAuthRepository) RolePermissions(ctx context.Context, role string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT permission_name
+		FROM role_permissions
+		WHERE role_name = $1
+		ORDER BY permission_name
+	`, role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := make([]string, 0)
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+	return permissions, rows.Err()
+}
+
+func (r *postgresAuthRepository) CountRoleUsers(ctx context.Context, role string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE role = $1 AND deleted_at IS NULL`, role).Scan(&count)
+	return count, err
+}
+
+func (r *postgresAuthRepository) DeleteRole(ctx context.Context, name string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM roles WHERE name = $1`, name)
+	if isForeignKeyViolation(err) {
+		return errRoleInUse
+	}
+	return err
+}
+
+// --- Outbox ---
+
+func (r *postgresAuthRepository) Enqueue(ctx context.Context, events ...outboxMessage) error {
+	return enqueue(ctx, r.db, events)
+}
+
+// ClaimEvents commits the claim on its own, so the row locks last only as
+// long as the statement.
+func (r *postgresAuthRepository) ClaimEvents(ctx context.Context, owner string, staleBefore time.Time, limit int) ([]outboxEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE outbox_events
+		SET claimed_by = $1, claimed_at = NOW()
+		WHERE id IN (
+			SELECT id
+			FROM outbox_events
+			WHERE published_at IS NULL AND next_attempt_at <= NOW()
+				AND (claimed_at IS NULL OR claimed_at < $2)
+			ORDER BY id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, exchange, routing_key, payload, attempts
+	`, owner, staleBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]outboxEvent, 0)
+	for rows.Next() {
+		var event outboxEvent
+		if err := rows.Scan(&event.ID, &event.Exchange, &event.RoutingKey, &event.Payload, &event.Attempts); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING does not keep the subquery order
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events, nil
+}
+
+func (r *postgresAuthRepository) MarkEventPublished(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE outbox_events
+		SET published_at = NOW(), attempts = attempts + 1, claimed_by = NULL, claimed_at = NULL
+		WHERE id = $1
+	`, id)
+	return err
+}
+
+func (r *postgresAuthRepository) MarkEventFailed(ctx context.Context, id int64, owner string, attempts int, lastError string, nextAttempt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE outbox_events
+		SET attempts = $2, last_error = $3, next_attempt_at = $4, claimed_by = NULL, claimed_at = NULL
+		WHERE id = $1 AND claimed_by = $5
+	`, id, attempts, lastError, nextAttempt, owner)
+	return err
+}
+
+func (r *postgresAuthRepository) ReleaseEvents(ctx context.Context, owner string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE outbox_events
+		SET claimed_by = NULL, claimed_at = NULL
+		WHERE claimed_by = $1 AND published_at IS NULL
+	`, owner)
+	return err
+}
+
+func (r *postgresAuthRepository) DeletePublishedEvents(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM outbox_events
+		WHERE published_at IS NOT NULL AND published_at < $1
+	`, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Models
+type SessionResponse struct {
+	ID         int       `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// IntrospectTokenRequest is sent by the API Gateway to check a bearer token.
+type IntrospectTokenRequest struct {
+	Token string `json:"token"`
+}
+
+// IntrospectTokenResponse follows RFC 7662: inactive tokens only set Active.
+type IntrospectTokenResponse struct {
+	Active    bool  `json:"active"`
+	ExpiresAt int64 `json:"exp,omitempty"`
+}
+
+// sessionTouchInterval limits how often last_seen_at is written per session.
+const sessionTouchInterval = time.Minute
+
+// --- Session Functions ---
+
+// clientIP returns the caller address, preferring the first X-Forwarded-For
+// entry set by nginx / the gateway.
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+		return realIP
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// newSession describes the session of a token issued now to the caller of r.
+func newSession(userID int, jti string, r *http.Request) sessionRow {
+	return sessionRow{
+		UserID:    userID,
+		JTI:       jti,
+		UserAgent: r.UserAgent(),
+		IPAddress: clientIP(r),
+		ExpiresAt: accessTokenExpiry(),
+	}
+}
+
+// isSessionRevoked reports whether jti was logged out or its session revoked,
+// and refreshes last_seen_at otherwise. Tokens without a session row are
+// allowed.
+func (s *AuthService) isSessionRevoked(jti string) (bool, error) {
+	ctx := context.Background()
+	revoked, err := s.repo.IsTokenRevoked(ctx, jti)
+	if err != nil {
+		return false, fmt.Errorf("error checking session: %w", err)
+	}
+	if revoked {
+		return true, nil
+	}
+
+	if err := s.repo.TouchSession(ctx, jti, time.Now().Add(-sessionTouchInterval)); err != nil {
+		log.Printf("⚠️ Error updating last_seen_at for session %s: %v", jti, err)
+	}
+	return false, nil
+}
+
+// --- HTTP Handlers ---
+
+// introspectTokenHandler tells the API Gateway whether a user token is still
+// good. The gateway checks the signature itself but cannot see logouts,
+// revoked sessions or tokens_revoked_at.
+func (s *AuthService) introspectTokenHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	var introspectData IntrospectTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&introspectData); err != nil || introspectData.Token == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid request payload"})
+		return
+	}
+
+	claims, err := verifyToken(introspectData.Token)
+	if err != nil {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(IntrospectTokenResponse{Active: false})
+		return
+	}
+
+	revoked, err := s.isTokenRevoked(claims)
+	if err != nil {
+		log.Printf("❌ Error introspecting token %s: %v", claims.JTI, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error en el servidor"})
+		return
+	}
+	if revoked {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(IntrospectTokenResponse{Active: false})
+		return
+	}
+
+	response := IntrospectTokenResponse{Active: true}
+	if claims.ExpiresAt != nil {
+		response.ExpiresAt = claims.ExpiresAt.Unix()
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (s *AuthService) listSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	rows, err := s.repo.ListSessions(r.Context(), int(claims.UserID))
+	if err != nil {
+		log.Printf("❌ Database error listing sessions: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al listar sesiones"})
+		return
+	}
+
+	sessions := make([]SessionResponse, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, SessionResponse{
+			ID:         row.ID,
+			UserAgent:  row.UserAgent,
+			IPAddress:  row.IPAddress,
+			CreatedAt:  row.CreatedAt,
+			LastSeenAt: row.LastSeenAt,
+			ExpiresAt:  row.ExpiresAt,
+			Current:    row.JTI == claims.JTI,
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{"sessions": sessions})
+}
+
+func (s *AuthService) revokeSessionHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sessionID, err := strconv.Atoi(mux.Vars(r)["session_id"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid session ID format"})
+		return
+	}
+
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	err = s.repo.RevokeSession(r.Context(), sessionID, int(claims.UserID))
+	if err == errSessionNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Sesión no encontrada"})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error revoking session: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al cerrar sesión"})
+		return
+	}
+
+	s.publishEvent("USER_SESSION_REVOKED", map[string]interface{}{
+		"user_id":    claims.UserID,
+		"email":      claims.Email,
+		"role":       claims.Role,
+		"session_id": sessionID,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Sesión cerrada exitosamente"})
+	log.Printf("✅ Session %d revoked by user %d", sessionID, claims.UserID)
+}
+
+func (s *AuthService) revokeOtherSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	revoked, err := s.repo.RevokeOtherSessions(r.Context(), int(claims.UserID), claims.JTI)
+	if err != nil {
+		log.Printf("❌ Database error revoking sessions: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al cerrar sesiones"})
+		return
+	}
+
+	s.publishEvent("USER_SESSIONS_REVOKED", map[string]interface{}{
+		"user_id": claims.UserID,
+		"email":   claims.Email,
+		"role":    claims.Role,
+		"revoked": revoked,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{"message": "Otras sesiones cerradas exitosamente", "revoked": revoked})
+	log.Printf("✅ %d other sessions revoked by user %d", revoked, claims.UserID)
+}
+
+// revokeUserSessionsHandler lets an admin log a compromised user out of every
+// session, including tokens issued before sessions were recorded.
+func (s *AuthService) revokeUserSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	targetUserID, err := strconv.Atoi(mux.Vars(r)["user_id"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Invalid user ID format"})
+		return
+	}
+
+	claims := r.Context().Value("userClaims").(*Claims) // Get claims from context
+
+	revoked, err := s.repo.RevokeAllTokens(r.Context(), targetUserID)
+	if err == errCredentialsNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Usuario no encontrado"})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Database error revoking user sessions: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{Detail: "Error interno al cerrar sesiones"})
+		return
+	}
+
+	s.publishEvent("USER_SESSIONS_REVOKED_BY_ADMIN", map[string]interface{}{
+		"user_id":        claims.UserID, // ID of the admin performing the action
+		"email":          claims.Email,
+		"role":           claims.Role,
+		"target_user_id": targetUserID,
+		"revoked":        revoked,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{"message": "Sesiones del usuario cerradas exitosamente", "revoked": revoked})
+	log.Printf("✅ All sessions of user %d revoked by admin %d", targetUserID, claims.UserID)
+}
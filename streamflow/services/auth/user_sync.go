@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Credential rows are mirrored from users-service, which owns user profiles.
+// A row uses the users-service ID as its primary key so token "sub" claims
+// match the IDs every other service stores.
+
+// userSyncQueue receives the users-service lifecycle events.
+const userSyncQueue = "auth_user_sync_queue"
+
+// userCreatedKey is user.created with the password hash. users-service
+// publishes it for this queue only; the broadcast user.created carries no
+// credentials, so auth does not bind it.
+const userCreatedKey = "user.created.auth"
+
+// userSyncRetryDelay is the pause before reconnecting to RabbitMQ or
+// requeueing an event that failed on a database error.
+const userSyncRetryDelay = 5 * time.Second
+
+// userEventSchemaVersion is the newest users-service event schema this
+// consumer understands. Events without schema_version predate versioning and
+// are read as version 1.
+const userEventSchemaVersion = 1
+
+// UserEvent is the payload of user.created.auth, user.updated, user.deleted,
+// user.restored, user.role_changed, user.email_changed and user.purged.
+// user.updated carries the whole profile, so
+// changed_fields is not needed here; user.purged only carries the ID.
+type UserEvent struct {
+	SchemaVersion int    `json:"schema_version"`
+	EventID       string `json:"event_id"`
+	ID            int    `json:"id"`
+	Email         string `json:"email"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	Role          string `json:"role"`
+	PasswordHash  string `json:"password_hash"`
+	Status        string `json:"status"` // pending_verification | active
+}
+
+var (
+	// errInvalidUserEvent marks events that can never be applied.
+	errInvalidUserEvent = errors.New("invalid user event")
+	// errUserEmailConflict means the email already belongs to another ID;
+	// reconcile reports these for manual review.
+	errUserEmailConflict = errors.New("email belongs to another user")
+)
+
+// --- Event Application ---
+// Each apply function is idempotent so redelivered events are harmless.
+
+func (s *AuthService) applyUserCreated(event UserEvent) error {
+	if event.Email == "" || event.PasswordHash == "" {
+		return fmt.Errorf("%w: %s without email or password_hash", errInvalidUserEvent, userCreatedKey)
+	}
+	role, err := s.resolveRole(event.Role)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		role = DEFAULT_ROLE
+	}
+	accountStatus := event.Status
+	if accountStatus != userStatusPendingVerification {
+		accountStatus = userStatusActive
+	}
+
+	ctx := context.Background()
+	created, err := s.repo.CreateCredentials(ctx, credentialRow{
+		ID:           event.ID,
+		FirstName:    event.FirstName,
+		LastName:     event.LastName,
+		Email:        event.Email,
+		PasswordHash: event.PasswordHash,
+		Role:         role,
+		Status:       accountStatus,
+		Synced:       true,
+	})
+	if err == errUserEmailConflict {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("error creating credentials: %w", err)
+	}
+	if !created {
+		log.Printf("⚠️ Credentials for user %d already exist, skipping %s", event.ID, userCreatedKey)
+		return nil
+	}
+
+	if accountStatus == userStatusPendingVerification {
+		return s.sendVerificationEmail(ctx, event.ID, event.Email, event.FirstName+" "+event.LastName)
+	}
+	return nil
+}
+
+func (s *AuthService) applyUserUpdated(event UserEvent) error {
+	role, err := s.resolveRole(event.Role)
+	if err != nil {
+		return err
+	}
+
+	found, err := s.repo.UpdateProfile(context.Background(), event.ID, event.FirstName, event.LastName, event.Email, role)
+	if err == errUserEmailConflict {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("error updating credentials: %w", err)
+	}
+	if !found {
+		log.Printf("⚠️ No credentials for user %d, skipping user.updated (run reconcile)", event.ID)
+	}
+	return nil
+}
+
+// applyUserDeleted soft-deletes the credentials and revokes every token.
+func (s *AuthService) applyUserDeleted(event UserEvent) error {
+	deleted, err := s.repo.DeleteCredentials(context.Background(), event.ID)
+	if err != nil {
+		return fmt.Errorf("error deleting credentials: %w", err)
+	}
+	if !deleted {
+		log.Printf("⚠️ Credentials for user %d missing or already deleted, skipping user.deleted", event.ID)
+	}
+	return nil
+}
+
+// applyUserRoleChanged stores the new role and revokes every token and
+// session, since access tokens embed the permissions of the old role. A
+// redelivered event finds the role already set and revokes nothing.
+func (s *AuthService) applyUserRoleChanged(event UserEvent) error {
+	role, err := s.resolveRole(event.Role)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return fmt.Errorf("%w: user.role_changed without role", errInvalidUserEvent)
+	}
+
+	changed, err := s.repo.ChangeRole(context.Background(), event.ID, role)
+	if err != nil {
+		return fmt.Errorf("error changing role: %w", err)
+	}
+	if !changed {
+		log.Printf("⚠️ Credentials for user %d missing or already have role %s, skipping user.role_changed", event.ID, role)
+		return nil
+	}
+	log.Printf("🔐 Role of user %d changed to %s, tokens revoked", event.ID, role)
+	return nil
+}
+
+// applyUserEmailChanged stores the confirmed email, which login matches on.
+// Password reset links still unused were mailed to the old address, so they
+// stop working.
+func (s *AuthService) applyUserEmailChanged(event UserEvent) error {
+	if event.Email == "" {
+		return fmt.Errorf("%w: user.email_changed without email", errInvalidUserEvent)
+	}
+
+	changed, err := s.repo.ChangeEmail(context.Background(), event.ID, event.Email)
+	if err == errUserEmailConflict {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("error changing email: %w", err)
+	}
+	if !changed {
+		log.Printf("⚠️ Credentials for user %d missing or already have email %s, skipping user.email_changed", event.ID, event.Email)
+		return nil
+	}
+	log.Printf("✅ Email of user %d changed to %s", event.ID, event.Email)
+	return nil
+}
+
+// applyUserRestored reactivates the credentials of a user taken out of the
+// trash. tokens_revoked_at is kept, so tokens issued before the deletion stay
+// invalid and the user has to log in again.
+func (s *AuthService) applyUserRestored(event UserEvent) error {
+	role, err := s.resolveRole(event.Role)
+	if err != nil {
+		return err
+	}
+
+	found, err := s.repo.RestoreCredentials(context.Background(), event.ID, event.FirstName, event.LastName, event.Email, role)
+	if err == errUserEmailConflict {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("error restoring credentials: %w", err)
+	}
+	if !found {
+		log.Printf("⚠️ No credentials for user %d, skipping user.restored (run reconcile)", event.ID)
+	}
+	return nil
+}
+
+// applyUserPurged erases the credentials and every row that references them.
+// Tokens still in circulation fail validation once the row is gone.
+func (s *AuthService) applyUserPurged(event UserEvent) error {
+	purged, err := s.repo.PurgeCredentials(context.Background(), event.ID)
+	if err != nil {
+		return fmt.Errorf("error purging credentials: %w", err)
+	}
+	if !purged {
+		log.Printf("⚠️ No credentials for user %d, nothing to purge", event.ID)
+	}
+	return nil
+}
+
+// --- RabbitMQ Consumer ---
+
+// startUserSyncConsumer consumes user events until the process exits,
+// reconnecting whenever the RabbitMQ connection drops.
+func (s *AuthService) startUserSyncConsumer() {
+	for {
+		if err := s.consumeUserEvents(); err != nil {
+			log.Printf("❌ User sync consumer stopped: %v", err)
+		}
+		time.Sleep(userSyncRetryDelay)
+	}
+}
+
+func (s *AuthService) consumeUserEvents() error {
+	rabbitMQURL := fmt.Sprintf("amqp://%s:%s@%s/", RABBITMQ_USER, RABBITMQ_PASS, RABBITMQ_HOST)
+
+	conn, err := amqp.Dial(rabbitMQURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open a channel: %w", err)
+	}
+	defer ch.Close()
+
+	err = ch.ExchangeDeclare(EVENTS_EXCHANGE, "direct", true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	q, err := ch.QueueDeclare(userSyncQueue, true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+	for _, routingKey := range []string{userCreatedKey, "user.updated", "user.deleted", "user.restored", "user.role_changed", "user.email_changed", "user.purged"} {
+		if err := ch.QueueBind(q.Name, routingKey, EVENTS_EXCHANGE, false, nil); err != nil {
+			return fmt.Errorf("failed to bind %s: %w", routingKey, err)
+		}
+	}
+
+	// One event at a time keeps the events of a user in order
+	if err := ch.Qos(1, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	msgs, err := ch.Consume(q.Name, "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to register consumer: %w", err)
+	}
+
+	log.Printf("✅ Listening for user events on queue '%s'", q.Name)
+	for d := range msgs {
+		s.handleUserEvent(d)
+	}
+	return errors.New("delivery channel closed")
+}
+
+func (s *AuthService) handleUserEvent(d amqp.Delivery) {
+	var event UserEvent
+	if err := json.Unmarshal(d.Body, &event); err != nil || event.ID == 0 {
+		log.Printf("❌ Discarding malformed %s event: %s", d.RoutingKey, d.Body)
+		d.Nack(false, false)
+		return
+	}
+	if event.SchemaVersion > userEventSchemaVersion {
+		log.Printf("❌ Discarding %s event %s: schema_version %d is newer than %d", d.RoutingKey, event.EventID, event.SchemaVersion, userEventSchemaVersion)
+		d.Nack(false, false)
+		return
+	}
+
+	var err error
+	switch d.RoutingKey {
+	case userCreatedKey:
+		err = s.applyUserCreated(event)
+	case "user.updated":
+		err = s.applyUserUpdated(event)
+	case "user.deleted":
+		err = s.applyUserDeleted(event)
+	case "user.restored":
+		err = s.applyUserRestored(event)
+	case "user.role_changed":
+		err = s.applyUserRoleChanged(event)
+	case "user.email_changed":
+		err = s.applyUserEmailChanged(event)
+	case "user.purged":
+		err = s.applyUserPurged(event)
+	default:
+		log.Printf("⚠️ Ignoring unexpected routing key %s", d.RoutingKey)
+		d.Ack(false)
+		return
+	}
+
+	switch {
+	case err == nil:
+		log.Printf("✅ Applied %s for user %d", d.RoutingKey, event.ID)
+		d.Ack(false)
+	case errors.Is(err, errInvalidUserEvent), errors.Is(err, errUserEmailConflict):
+		log.Printf("❌ Discarding %s for user %d: %v", d.RoutingKey, event.ID, err)
+		d.Nack(false, false)
+	default:
+		// Database hiccup: retry later instead of losing the event
+		log.Printf("❌ Error applying %s for user %d: %v", d.RoutingKey, event.ID, err)
+		time.Sleep(userSyncRetryDelay)
+		d.Nack(false, true)
+	}
+}
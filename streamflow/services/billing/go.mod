@@ -6,9 +6,11 @@ toolchain go1.24.4
 
 require (
 	github.com/go-sql-driver/mysql v1.9.3
+	github.com/rabbitmq/amqp091-go v1.10.0
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250603155806-513f23925822 // indirect
 	google.golang.org/grpc v1.73.0
 	google.golang.org/protobuf v1.36.6
+	streamflow/shared v0.0.0
 )
 
 require (
@@ -17,3 +19,5 @@ require (
 	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/text v0.26.0 // indirect
 )
+
+replace streamflow/shared => ../../shared
@@ -10,6 +10,7 @@ import (
 	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -20,6 +21,7 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"streamflow/shared/dbmigrate"
 	pb "billing-service/pb"
 )
 
@@ -27,8 +29,6 @@ import (
 // CONFIG & DB SETUP
 //=====================================================================
 
-var db *sql.DB // global connection pool
-
 // dbConfig holds database connection parameters from env vars.
 type dbConfig struct {
 	Host string
@@ -60,36 +60,23 @@ func (c dbConfig) dsn() string {
 		c.User, c.Pass, c.Host, c.Port, c.Name)
 }
 
-func initDB() error {
-	const query = `CREATE TABLE IF NOT EXISTS invoices (
-        id INT AUTO_INCREMENT PRIMARY KEY,
-        user_id INT NOT NULL,
-        amount DECIMAL(10,2) NOT NULL,
-        issue_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-        payment_date TIMESTAMP NULL,
-        status ENUM('Pendiente','Pagado','Vencido') NOT NULL DEFAULT 'Pendiente',
-        deleted_at TIMESTAMP NULL,
-        INDEX idx_user_id (user_id),
-        INDEX idx_status (status),
-        INDEX idx_deleted (deleted_at)
-    ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
-	_, err := db.Exec(query)
-	return err
-}
-
 //=====================================================================
 // AUTH & UTILITIES
 //=====================================================================
 
 const (
-	mdUserID = "user_id"        // authenticated user id (string/int64)
-	mdRole   = "role"           // "admin" or "client"
-	mdTarget = "target_user_id" // optional: admin listing invoices of another user
+	mdUserID      = "user_id"        // authenticated user id (string/int64)
+	mdPermissions = "permissions"    // space-separated permissions, e.g. "invoices:read invoices:write"
+	mdTarget      = "target_user_id" // optional: listing invoices of another user (invoices:read_all)
 )
 
 type authCtx struct {
-	userID int64
-	role   string // "admin" | "client"
+	userID      int64
+	permissions map[string]bool
+}
+
+func (a authCtx) can(permission string) bool {
+	return a.permissions[permission]
 }
 
 func getAuth(ctx context.Context) (authCtx, error) {
@@ -99,8 +86,7 @@ func getAuth(ctx context.Context) (authCtx, error) {
 	}
 
 	uidVals := md.Get(mdUserID)
-	roleVals := md.Get(mdRole)
-	if len(uidVals) == 0 || len(roleVals) == 0 {
+	if len(uidVals) == 0 {
 		return authCtx{}, status.Error(codes.Unauthenticated, "user not logged in")
 	}
 
@@ -109,16 +95,18 @@ func getAuth(ctx context.Context) (authCtx, error) {
 		return authCtx{}, status.Error(codes.Unauthenticated, "invalid user_id metadata")
 	}
 
-	role := roleVals[0]
-	if role != "admin" && role != "client" {
-		return authCtx{}, status.Error(codes.PermissionDenied, "unknown role")
+	permissions := make(map[string]bool)
+	for _, value := range md.Get(mdPermissions) {
+		for _, permission := range strings.Fields(value) {
+			permissions[permission] = true
+		}
 	}
-	return authCtx{userID: uid, role: role}, nil
+	return authCtx{userID: uid, permissions: permissions}, nil
 }
 
-func requireAdmin(a authCtx) error {
-	if a.role != "admin" {
-		return status.Error(codes.PermissionDenied, "admin privileges required")
+func requirePermission(a authCtx, permission string) error {
+	if !a.can(permission) {
+		return status.Errorf(codes.PermissionDenied, "permission %s required", permission)
 	}
 	return nil
 }
@@ -134,6 +122,7 @@ type invoiceRow struct {
 	IssueDate   time.Time
 	PaymentDate sql.NullTime
 	Status      string
+	Version     int64
 }
 
 func mapStatusEnumToString(s pb.InvoiceStatus) (string, error) {
@@ -176,6 +165,7 @@ func rowToProto(r invoiceRow) *pb.Invoice {
 		Amount:      int64(math.Round(r.Amount * 100)), // cents
 		IssueDate:   timestamppb.New(r.IssueDate),
 		PaymentDate: payTS,
+		Version:     r.Version,
 	}
 }
 
@@ -185,6 +175,11 @@ func rowToProto(r invoiceRow) *pb.Invoice {
 
 type billingServer struct {
 	pb.UnimplementedBillingServiceServer
+	invoices InvoiceRepository
+}
+
+func newBillingServer(invoices InvoiceRepository) *billingServer {
+	return &billingServer{invoices: invoices}
 }
 
 // CreateInvoice implements business rules for invoice creation.
@@ -193,7 +188,7 @@ func (s *billingServer) CreateInvoice(ctx context.Context, req *pb.CreateInvoice
 	if err != nil {
 		return nil, err
 	}
-	if err := requireAdmin(auth); err != nil {
+	if err := requirePermission(auth, "invoices:write"); err != nil {
 		return nil, err
 	}
 
@@ -212,17 +207,11 @@ func (s *billingServer) CreateInvoice(ctx context.Context, req *pb.CreateInvoice
 
 	amountFloat := float64(req.Amount) / 100.0 // convert cents to decimal
 
-	res, err := db.ExecContext(ctx, `INSERT INTO invoices (user_id, amount, status) VALUES (?,?,?)`, req.UserId, amountFloat, statusStr)
+	row, err := s.invoices.Create(ctx, req.UserId, amountFloat, statusStr)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create invoice: %v", err)
 	}
-	id, _ := res.LastInsertId()
-
-	inv, err := fetchInvoiceByID(ctx, id)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to retrieve invoice: %v", err)
-	}
-	return &pb.CreateInvoiceResponse{Invoice: inv}, nil
+	return &pb.CreateInvoiceResponse{Invoice: rowToProto(row)}, nil
 }
 
 // GetInvoiceById returns invoice if requester authorized.
@@ -231,28 +220,31 @@ func (s *billingServer) GetInvoiceById(ctx context.Context, req *pb.GetInvoiceBy
 	if err != nil {
 		return nil, err
 	}
+	if err := requirePermission(auth, "invoices:read"); err != nil {
+		return nil, err
+	}
 
-	inv, err := fetchInvoiceByID(ctx, req.Id)
-	if err == sql.ErrNoRows {
+	row, err := s.invoices.Get(ctx, req.Id)
+	if errors.Is(err, errInvoiceNotFound) {
 		return nil, status.Error(codes.NotFound, "invoice not found")
 	} else if err != nil {
 		return nil, status.Errorf(codes.Internal, "query error: %v", err)
 	}
 
-	if auth.role != "admin" && inv.UserId != auth.userID {
+	if !auth.can("invoices:read_all") && row.UserID != auth.userID {
 		return nil, status.Error(codes.PermissionDenied, "not authorized to view this invoice")
 	}
 
-	return &pb.GetInvoiceByIdResponse{Invoice: inv}, nil
+	return &pb.GetInvoiceByIdResponse{Invoice: rowToProto(row)}, nil
 }
 
-// UpdateInvoiceState allows only admins to change status.
+// UpdateInvoiceState requires invoices:write.
 func (s *billingServer) UpdateInvoiceState(ctx context.Context, req *pb.UpdateInvoiceStateRequest) (*pb.UpdateInvoiceStateResponse, error) {
 	auth, err := getAuth(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if err := requireAdmin(auth); err != nil {
+	if err := requirePermission(auth, "invoices:write"); err != nil {
 		return nil, err
 	}
 
@@ -261,71 +253,59 @@ func (s *billingServer) UpdateInvoiceState(ctx context.Context, req *pb.UpdateIn
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	var query string
-	var args []interface{}
-	if statusStr == "Pagado" {
-		query = `UPDATE invoices SET status = ?, payment_date = NOW() WHERE id = ? AND deleted_at IS NULL`
-		args = []interface{}{statusStr, req.Id}
-	} else {
-		query = `UPDATE invoices SET status = ? WHERE id = ? AND deleted_at IS NULL`
-		args = []interface{}{statusStr, req.Id}
-	}
-
-	res, err := db.ExecContext(ctx, query, args...)
+	// Paying an invoice records when it was paid
+	row, err := s.invoices.UpdateStatus(ctx, req.Id, statusStr, statusStr == "Pagado", req.ExpectedVersion)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "update failed: %v", err)
-	}
-	affected, _ := res.RowsAffected()
-	if affected == 0 {
-		return nil, status.Error(codes.NotFound, "invoice not found or deleted")
+		return nil, writeError(err, req.ExpectedVersion, "update failed")
 	}
-
-	inv, err := fetchInvoiceByID(ctx, req.Id)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "fetch error: %v", err)
-	}
-	return &pb.UpdateInvoiceStateResponse{Invoice: inv}, nil
+	return &pb.UpdateInvoiceStateResponse{Invoice: rowToProto(row)}, nil
 }
 
-// DeleteInvoice performs soft delete; only admins.
+// DeleteInvoice performs soft delete; requires invoices:write.
 func (s *billingServer) DeleteInvoice(ctx context.Context, req *pb.DeleteInvoiceRequest) (*emptypb.Empty, error) {
 	auth, err := getAuth(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if err := requireAdmin(auth); err != nil {
+	if err := requirePermission(auth, "invoices:write"); err != nil {
 		return nil, err
 	}
 
-	var statusStr string
-	err = db.QueryRowContext(ctx, `SELECT status FROM invoices WHERE id = ? AND deleted_at IS NULL`, req.Id).Scan(&statusStr)
-	if err == sql.ErrNoRows {
+	row, err := s.invoices.Get(ctx, req.Id)
+	if errors.Is(err, errInvoiceNotFound) {
 		return nil, status.Error(codes.NotFound, "invoice not found")
 	} else if err != nil {
 		return nil, status.Errorf(codes.Internal, "query error: %v", err)
 	}
+	if req.ExpectedVersion > 0 && row.Version != req.ExpectedVersion {
+		return nil, versionMismatch(row.Version, req.ExpectedVersion)
+	}
 
-	if statusStr == "Pagado" {
+	if row.Status == "Pagado" {
 		return nil, status.Error(codes.FailedPrecondition, "cannot delete a paid invoice")
 	}
 
-	_, err = db.ExecContext(ctx, `UPDATE invoices SET deleted_at = NOW() WHERE id = ?`, req.Id)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "delete failed: %v", err)
+	// Only if nothing changed since the status check above
+	if err := s.invoices.SoftDelete(ctx, req.Id, row.Version); err != nil {
+		return nil, writeError(err, row.Version, "delete failed")
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
-// ListInvoicesByUser lists invoices; role determines scope.
+// ListInvoicesByUser lists invoices; invoices:read_all allows other users.
 func (s *billingServer) ListInvoicesByUser(ctx context.Context, req *pb.ListInvoicesByUserRequest) (*pb.ListInvoicesByUserResponse, error) {
 	auth, err := getAuth(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := requirePermission(auth, "invoices:read"); err != nil {
+		return nil, err
+	}
+
 	var targetUserID int64
-	if auth.role == "admin" {
+	if auth.can("invoices:read_all") {
 		md, _ := metadata.FromIncomingContext(ctx)
 		tgt := md.Get(mdTarget)
 		if len(tgt) > 0 {
@@ -340,34 +320,19 @@ func (s *billingServer) ListInvoicesByUser(ctx context.Context, req *pb.ListInvo
 		targetUserID = auth.userID
 	}
 
-	query := `SELECT id, user_id, amount, issue_date, payment_date, status FROM invoices WHERE deleted_at IS NULL AND user_id = ?`
-	args := []interface{}{targetUserID}
-
+	var statusStr string
 	if req.StatusFilter != nil && *req.StatusFilter != pb.InvoiceStatus_INVOICE_STATUS_UNSPECIFIED {
-		statusStr, _ := mapStatusEnumToString(*req.StatusFilter)
-		query += " AND status = ?"
-		args = append(args, statusStr)
+		statusStr, _ = mapStatusEnumToString(*req.StatusFilter)
 	}
 
-	query += " ORDER BY issue_date DESC"
-
-	rows, err := db.QueryContext(ctx, query, args...)
+	rows, err := s.invoices.ListByUser(ctx, targetUserID, statusStr)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "query error: %v", err)
 	}
-	defer rows.Close()
-
-	invoices := make([]*pb.Invoice, 0)
-	for rows.Next() {
-		var r invoiceRow
-		if err := rows.Scan(&r.ID, &r.UserID, &r.Amount, &r.IssueDate, &r.PaymentDate, &r.Status); err != nil {
-			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
-		}
+	invoices := make([]*pb.Invoice, 0, len(rows))
+	for _, r := range rows {
 		invoices = append(invoices, rowToProto(r))
 	}
-	if err := rows.Err(); err != nil {
-		return nil, status.Errorf(codes.Internal, "row error: %v", err)
-	}
 
 	return &pb.ListInvoicesByUserResponse{Invoices: invoices}, nil
 }
@@ -376,14 +341,24 @@ func (s *billingServer) ListInvoicesByUser(ctx context.Context, req *pb.ListInvo
 // HELPERS
 //=====================================================================
 
-func fetchInvoiceByID(ctx context.Context, id int64) (*pb.Invoice, error) {
-	var r invoiceRow
-	err := db.QueryRowContext(ctx, `SELECT id, user_id, amount, issue_date, payment_date, status FROM invoices WHERE id = ? AND deleted_at IS NULL`, id).
-		Scan(&r.ID, &r.UserID, &r.Amount, &r.IssueDate, &r.PaymentDate, &r.Status)
-	if err != nil {
-		return nil, err
+// versionMismatch is returned when the invoice changed since the client read
+// it; the gateway maps codes.Aborted to 412 Precondition Failed.
+func versionMismatch(current, expected int64) error {
+	return status.Errorf(codes.Aborted, "invoice was modified by another request (current version %d, expected %d)", current, expected)
+}
+
+// writeError maps a failed conditional write: the invoice is gone
+// (NotFound) or its version is no longer expectedVersion (Aborted).
+func writeError(err error, expectedVersion int64, action string) error {
+	var conflict *versionConflictError
+	switch {
+	case errors.Is(err, errInvoiceNotFound):
+		return status.Error(codes.NotFound, "invoice not found or deleted")
+	case errors.As(err, &conflict):
+		return versionMismatch(conflict.Current, expectedVersion)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", action, err)
 	}
-	return rowToProto(r), nil
 }
 
 //=====================================================================
@@ -392,8 +367,7 @@ func fetchInvoiceByID(ctx context.Context, id int64) (*pb.Invoice, error) {
 
 func main() {
 	cfg := getDBConfig()
-	var err error
-	db, err = sql.Open("mysql", cfg.dsn())
+	db, err := sql.Open("mysql", cfg.dsn())
 	if err != nil {
 		log.Fatalf("cannot open DB connection: %v", err)
 	}
@@ -404,18 +378,34 @@ func main() {
 	db.SetMaxIdleConns(5)
 	db.SetConnMaxLifetime(time.Hour)
 
-	if err = initDB(); err != nil {
+	migrator, err := newMigrator(db)
+	if err != nil {
+		log.Fatalf("cannot load migrations: %v", err)
+	}
+	// "migrate" reports or changes the schema version and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := dbmigrate.RunCommand(context.Background(), migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+	if err = migrateSchema(migrator); err != nil {
 		log.Fatalf("cannot init DB: %v", err)
 	}
 	log.Println("Billing database initialized")
 
+	invoices := newMySQLInvoiceRepository(db)
+
+	// Anonymize invoices of erased users (requested by users-service)
+	go startErasureConsumer(invoices)
+
 	lis, err := net.Listen("tcp", ":"+getenv("PORT", "50052"))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
 	grpcServer := grpc.NewServer()
-	pb.RegisterBillingServiceServer(grpcServer, &billingServer{})
+	pb.RegisterBillingServiceServer(grpcServer, newBillingServer(invoices))
 
 	log.Printf("gRPC BillingService listening on %s", lis.Addr())
 	if err := grpcServer.Serve(lis); err != nil {
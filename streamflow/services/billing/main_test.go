@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "billing-service/pb"
+)
+
+const (
+	adminID    = 1
+	customerID = 2
+	otherID    = 3
+)
+
+// asUser builds the metadata the gateway sends for an authenticated user.
+func asUser(userID int64, permissions ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		mdUserID, strconv.FormatInt(userID, 10),
+		mdPermissions, strings.Join(permissions, " "),
+	))
+}
+
+func asAdmin() context.Context {
+	return asUser(adminID, "invoices:read", "invoices:write", "invoices:read_all")
+}
+
+func asCustomer(userID int64) context.Context {
+	return asUser(userID, "invoices:read")
+}
+
+func assertCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Fatalf("expected %s, got %s (%v)", want, got, err)
+	}
+}
+
+func createInvoice(t *testing.T, s *billingServer, userID, amount int64, st pb.InvoiceStatus) *pb.Invoice {
+	t.Helper()
+	resp, err := s.CreateInvoice(asAdmin(), &pb.CreateInvoiceRequest{UserId: userID, Amount: amount, Status: st})
+	if err != nil {
+		t.Fatalf("CreateInvoice: %v", err)
+	}
+	return resp.Invoice
+}
+
+func TestCreateInvoiceRequiresWritePermission(t *testing.T) {
+	s := newBillingServer(newMemoryInvoiceRepository())
+
+	_, err := s.CreateInvoice(asCustomer(customerID), &pb.CreateInvoiceRequest{UserId: customerID, Amount: 1000})
+	assertCode(t, err, codes.PermissionDenied)
+
+	_, err = s.CreateInvoice(context.Background(), &pb.CreateInvoiceRequest{UserId: customerID, Amount: 1000})
+	assertCode(t, err, codes.Unauthenticated)
+}
+
+func TestCreateInvoiceRejectsNonPositiveAmount(t *testing.T) {
+	s := newBillingServer(newMemoryInvoiceRepository())
+
+	for _, amount := range []int64{0, -500} {
+		_, err := s.CreateInvoice(asAdmin(), &pb.CreateInvoiceRequest{UserId: customerID, Amount: amount})
+		assertCode(t, err, codes.InvalidArgument)
+	}
+}
+
+func TestCreateInvoiceDefaultsToPending(t *testing.T) {
+	s := newBillingServer(newMemoryInvoiceRepository())
+
+	inv := createInvoice(t, s, customerID, 1999, pb.InvoiceStatus_INVOICE_STATUS_UNSPECIFIED)
+	if inv.Status != pb.InvoiceStatus_PENDIENTE {
+		t.Fatalf("expected PENDIENTE, got %s", inv.Status)
+	}
+	if inv.Amount != 1999 {
+		t.Fatalf("expected 1999 cents, got %d", inv.Amount)
+	}
+	if inv.Version != 1 {
+		t.Fatalf("expected version 1, got %d", inv.Version)
+	}
+}
+
+func TestCannotDeletePaidInvoice(t *testing.T) {
+	s := newBillingServer(newMemoryInvoiceRepository())
+	inv := createInvoice(t, s, customerID, 1000, pb.InvoiceStatus_PAGADO)
+
+	_, err := s.DeleteInvoice(asAdmin(), &pb.DeleteInvoiceRequest{Id: inv.Id})
+	assertCode(t, err, codes.FailedPrecondition)
+
+	if _, err := s.GetInvoiceById(asAdmin(), &pb.GetInvoiceByIdRequest{Id: inv.Id}); err != nil {
+		t.Fatalf("paid invoice should still exist: %v", err)
+	}
+}
+
+func TestDeletePendingInvoice(t *testing.T) {
+	s := newBillingServer(newMemoryInvoiceRepository())
+	inv := createInvoice(t, s, customerID, 1000, pb.InvoiceStatus_PENDIENTE)
+
+	if _, err := s.DeleteInvoice(asAdmin(), &pb.DeleteInvoiceRequest{Id: inv.Id, ExpectedVersion: inv.Version}); err != nil {
+		t.Fatalf("DeleteInvoice: %v", err)
+	}
+
+	_, err := s.GetInvoiceById(asAdmin(), &pb.GetInvoiceByIdRequest{Id: inv.Id})
+	assertCode(t, err, codes.NotFound)
+
+	_, err = s.DeleteInvoice(asAdmin(), &pb.DeleteInvoiceRequest{Id: inv.Id})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestDeleteWithStaleVersionIsAborted(t *testing.T) {
+	s := newBillingServer(newMemoryInvoiceRepository())
+	inv := createInvoice(t, s, customerID, 1000, pb.InvoiceStatus_PENDIENTE)
+
+	_, err := s.UpdateInvoiceState(asAdmin(), &pb.UpdateInvoiceStateRequest{Id: inv.Id, NewStatus: pb.InvoiceStatus_VENCIDO})
+	if err != nil {
+		t.Fatalf("UpdateInvoiceState: %v", err)
+	}
+
+	_, err = s.DeleteInvoice(asAdmin(), &pb.DeleteInvoiceRequest{Id: inv.Id, ExpectedVersion: inv.Version})
+	assertCode(t, err, codes.Aborted)
+}
+
+func TestPayingInvoiceSetsPaymentDate(t *testing.T) {
+	s := newBillingServer(newMemoryInvoiceRepository())
+	inv := createInvoice(t, s, customerID, 1000, pb.InvoiceStatus_PENDIENTE)
+
+	resp, err := s.UpdateInvoiceState(asAdmin(), &pb.UpdateInvoiceStateRequest{
+		Id:              inv.Id,
+		NewStatus:       pb.InvoiceStatus_PAGADO,
+		ExpectedVersion: inv.Version,
+	})
+	if err != nil {
+		t.Fatalf("UpdateInvoiceState: %v", err)
+	}
+	if resp.Invoice.PaymentDate == nil {
+		t.Fatal("paid invoice should have a payment date")
+	}
+	if resp.Invoice.Version != inv.Version+1 {
+		t.Fatalf("expected version %d, got %d", inv.Version+1, resp.Invoice.Version)
+	}
+
+	// The same If-Match cannot be used twice
+	_, err = s.UpdateInvoiceState(asAdmin(), &pb.UpdateInvoiceStateRequest{
+		Id:              inv.Id,
+		NewStatus:       pb.InvoiceStatus_VENCIDO,
+		ExpectedVersion: inv.Version,
+	})
+	assertCode(t, err, codes.Aborted)
+}
+
+func TestUpdateInvoiceStateRejectsUnspecifiedStatus(t *testing.T) {
+	s := newBillingServer(newMemoryInvoiceRepository())
+	inv := createInvoice(t, s, customerID, 1000, pb.InvoiceStatus_PENDIENTE)
+
+	_, err := s.UpdateInvoiceState(asAdmin(), &pb.UpdateInvoiceStateRequest{Id: inv.Id})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestCustomerOnlySeesOwnInvoices(t *testing.T) {
+	s := newBillingServer(newMemoryInvoiceRepository())
+	own := createInvoice(t, s, customerID, 1000, pb.InvoiceStatus_PENDIENTE)
+	other := createInvoice(t, s, otherID, 2000, pb.InvoiceStatus_PENDIENTE)
+
+	if _, err := s.GetInvoiceById(asCustomer(customerID), &pb.GetInvoiceByIdRequest{Id: own.Id}); err != nil {
+		t.Fatalf("own invoice: %v", err)
+	}
+	_, err := s.GetInvoiceById(asCustomer(customerID), &pb.GetInvoiceByIdRequest{Id: other.Id})
+	assertCode(t, err, codes.PermissionDenied)
+
+	// target_user_id is ignored without invoices:read_all
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		mdUserID, strconv.Itoa(customerID),
+		mdPermissions, "invoices:read",
+		mdTarget, strconv.Itoa(otherID),
+	))
+	resp, err := s.ListInvoicesByUser(ctx, &pb.ListInvoicesByUserRequest{})
+	if err != nil {
+		t.Fatalf("ListInvoicesByUser: %v", err)
+	}
+	if len(resp.Invoices) != 1 || resp.Invoices[0].Id != own.Id {
+		t.Fatalf("expected only invoice %d, got %v", own.Id, resp.Invoices)
+	}
+}
+
+func TestListInvoicesFiltersByStatus(t *testing.T) {
+	s := newBillingServer(newMemoryInvoiceRepository())
+	createInvoice(t, s, customerID, 1000, pb.InvoiceStatus_PENDIENTE)
+	paid := createInvoice(t, s, customerID, 2000, pb.InvoiceStatus_PAGADO)
+
+	filter := pb.InvoiceStatus_PAGADO
+	resp, err := s.ListInvoicesByUser(asCustomer(customerID), &pb.ListInvoicesByUserRequest{StatusFilter: &filter})
+	if err != nil {
+		t.Fatalf("ListInvoicesByUser: %v", err)
+	}
+	if len(resp.Invoices) != 1 || resp.Invoices[0].Id != paid.Id {
+		t.Fatalf("expected only paid invoice %d, got %v", paid.Id, resp.Invoices)
+	}
+}
+
+func TestAnonymizeUserDetachesInvoices(t *testing.T) {
+	repo := newMemoryInvoiceRepository()
+	s := newBillingServer(repo)
+	createInvoice(t, s, customerID, 1000, pb.InvoiceStatus_PAGADO)
+	createInvoice(t, s, customerID, 2000, pb.InvoiceStatus_PENDIENTE)
+	createInvoice(t, s, otherID, 3000, pb.InvoiceStatus_PENDIENTE)
+
+	changed, err := repo.AnonymizeUser(context.Background(), customerID)
+	if err != nil {
+		t.Fatalf("AnonymizeUser: %v", err)
+	}
+	if changed != 2 {
+		t.Fatalf("expected 2 invoices anonymized, got %d", changed)
+	}
+
+	resp, err := s.ListInvoicesByUser(asCustomer(customerID), &pb.ListInvoicesByUserRequest{})
+	if err != nil {
+		t.Fatalf("ListInvoicesByUser: %v", err)
+	}
+	if len(resp.Invoices) != 0 {
+		t.Fatalf("expected no invoices left for the user, got %d", len(resp.Invoices))
+	}
+}
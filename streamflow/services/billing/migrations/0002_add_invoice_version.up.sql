@@ -0,0 +1,2 @@
+-- version backs optimistic concurrency (ETag / If-Match); older rows start at 1
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
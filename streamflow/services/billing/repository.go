@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+//=====================================================================
+// INVOICE REPOSITORY
+//=====================================================================
+//
+// The gRPC handlers keep the business rules (permissions, ownership, which
+// invoices can be deleted) and reach storage only through InvoiceRepository.
+// mysqlInvoiceRepository is the production backend; memoryInvoiceRepository
+// backs the unit tests.
+
+// errInvoiceNotFound is returned for missing or soft-deleted invoices.
+var errInvoiceNotFound = errors.New("invoice not found")
+
+// versionConflictError is returned by conditional writes when the invoice
+// exists but its version is no longer the expected one.
+type versionConflictError struct {
+	Current int64
+}
+
+func (e *versionConflictError) Error() string {
+	return fmt.Sprintf("invoice is at version %d", e.Current)
+}
+
+// InvoiceRepository stores invoices. Every method ignores soft-deleted
+// invoices except AnonymizeUser, which must reach all of them.
+type InvoiceRepository interface {
+	// Create inserts a new invoice at version 1.
+	Create(ctx context.Context, userID int64, amount float64, status string) (invoiceRow, error)
+	// Get returns errInvoiceNotFound when the invoice does not exist.
+	Get(ctx context.Context, id int64) (invoiceRow, error)
+	// UpdateStatus changes the status and, if markPaid, sets the payment
+	// date. An expectedVersion of 0 skips the version check.
+	UpdateStatus(ctx context.Context, id int64, status string, markPaid bool, expectedVersion int64) (invoiceRow, error)
+	// SoftDelete deletes the invoice only if it is still at version.
+	SoftDelete(ctx context.Context, id, version int64) error
+	// ListByUser returns the user's invoices, newest first; an empty status
+	// returns all of them.
+	ListByUser(ctx context.Context, userID int64, status string) ([]invoiceRow, error)
+	// AnonymizeUser detaches every invoice of the user and returns how many
+	// changed.
+	AnonymizeUser(ctx context.Context, userID int64) (int64, error)
+}
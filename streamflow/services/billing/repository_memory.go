@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memoryInvoiceRepository keeps invoices in memory. It mirrors the MySQL
+// semantics (versions, soft delete, ordering) so the rules can be tested
+// without a database.
+type memoryInvoiceRepository struct {
+	mu       sync.Mutex
+	nextID   int64
+	invoices map[int64]*memoryInvoice
+	now      func() time.Time
+}
+
+type memoryInvoice struct {
+	row     invoiceRow
+	deleted bool
+}
+
+func newMemoryInvoiceRepository() *memoryInvoiceRepository {
+	return &memoryInvoiceRepository{
+		nextID:   1,
+		invoices: make(map[int64]*memoryInvoice),
+		now:      time.Now,
+	}
+}
+
+func (r *memoryInvoiceRepository) Create(ctx context.Context, userID int64, amount float64, status string) (invoiceRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	row := invoiceRow{
+		ID:        r.nextID,
+		UserID:    userID,
+		Amount:    amount,
+		IssueDate: r.now(),
+		Status:    status,
+		Version:   1,
+	}
+	r.nextID++
+	r.invoices[row.ID] = &memoryInvoice{row: row}
+	return row, nil
+}
+
+func (r *memoryInvoiceRepository) Get(ctx context.Context, id int64) (invoiceRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inv, ok := r.invoices[id]
+	if !ok || inv.deleted {
+		return invoiceRow{}, errInvoiceNotFound
+	}
+	return inv.row, nil
+}
+
+func (r *memoryInvoiceRepository) UpdateStatus(ctx context.Context, id int64, status string, markPaid bool, expectedVersion int64) (invoiceRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inv, err := r.forWrite(id, expectedVersion)
+	if err != nil {
+		return invoiceRow{}, err
+	}
+	inv.row.Status = status
+	if markPaid {
+		inv.row.PaymentDate = sql.NullTime{Time: r.now(), Valid: true}
+	}
+	inv.row.Version++
+	return inv.row, nil
+}
+
+func (r *memoryInvoiceRepository) SoftDelete(ctx context.Context, id, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inv, err := r.forWrite(id, version)
+	if err != nil {
+		return err
+	}
+	inv.deleted = true
+	inv.row.Version++
+	return nil
+}
+
+func (r *memoryInvoiceRepository) ListByUser(ctx context.Context, userID int64, status string) ([]invoiceRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invoices := make([]invoiceRow, 0)
+	for _, inv := range r.invoices {
+		if inv.deleted || inv.row.UserID != userID || (status != "" && inv.row.Status != status) {
+			continue
+		}
+		invoices = append(invoices, inv.row)
+	}
+	sort.Slice(invoices, func(i, j int) bool {
+		if invoices[i].IssueDate.Equal(invoices[j].IssueDate) {
+			return invoices[i].ID > invoices[j].ID
+		}
+		return invoices[i].IssueDate.After(invoices[j].IssueDate)
+	})
+	return invoices, nil
+}
+
+func (r *memoryInvoiceRepository) AnonymizeUser(ctx context.Context, userID int64) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var changed int64
+	for _, inv := range r.invoices {
+		if inv.row.UserID == userID {
+			inv.row.UserID = anonymizedInvoiceUser
+			inv.row.Version++
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// forWrite returns the invoice if it exists and is at expectedVersion (0
+// skips the check). The caller holds the lock.
+func (r *memoryInvoiceRepository) forWrite(id, expectedVersion int64) (*memoryInvoice, error) {
+	inv, ok := r.invoices[id]
+	if !ok || inv.deleted {
+		return nil, errInvoiceNotFound
+	}
+	if expectedVersion > 0 && inv.row.Version != expectedVersion {
+		return nil, &versionConflictError{Current: inv.row.Version}
+	}
+	return inv, nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// mysqlInvoiceRepository stores invoices in the invoices table.
+type mysqlInvoiceRepository struct {
+	db *sql.DB
+}
+
+func newMySQLInvoiceRepository(db *sql.DB) *mysqlInvoiceRepository {
+	return &mysqlInvoiceRepository{db: db}
+}
+
+const invoiceColumns = `id, user_id, amount, issue_date, payment_date, status, version`
+
+func scanInvoice(scan func(dest ...interface{}) error) (invoiceRow, error) {
+	var r invoiceRow
+	err := scan(&r.ID, &r.UserID, &r.Amount, &r.IssueDate, &r.PaymentDate, &r.Status, &r.Version)
+	return r, err
+}
+
+func (r *mysqlInvoiceRepository) Create(ctx context.Context, userID int64, amount float64, status string) (invoiceRow, error) {
+	res, err := r.db.ExecContext(ctx, `INSERT INTO invoices (user_id, amount, status) VALUES (?,?,?)`, userID, amount, status)
+	if err != nil {
+		return invoiceRow{}, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return invoiceRow{}, err
+	}
+	return r.Get(ctx, id)
+}
+
+func (r *mysqlInvoiceRepository) Get(ctx context.Context, id int64) (invoiceRow, error) {
+	row, err := scanInvoice(r.db.QueryRowContext(ctx,
+		`SELECT `+invoiceColumns+` FROM invoices WHERE id = ? AND deleted_at IS NULL`, id).Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return invoiceRow{}, errInvoiceNotFound
+	}
+	return row, err
+}
+
+func (r *mysqlInvoiceRepository) UpdateStatus(ctx context.Context, id int64, status string, markPaid bool, expectedVersion int64) (invoiceRow, error) {
+	query := `UPDATE invoices SET status = ?, version = version + 1`
+	if markPaid {
+		query += `, payment_date = NOW()`
+	}
+	query += ` WHERE id = ? AND deleted_at IS NULL`
+	args := []interface{}{status, id}
+	if expectedVersion > 0 {
+		query += ` AND version = ?`
+		args = append(args, expectedVersion)
+	}
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return invoiceRow{}, err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return invoiceRow{}, r.conflict(ctx, id, expectedVersion)
+	}
+	return r.Get(ctx, id)
+}
+
+func (r *mysqlInvoiceRepository) SoftDelete(ctx context.Context, id, version int64) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE invoices SET deleted_at = NOW(), version = version + 1 WHERE id = ? AND deleted_at IS NULL AND version = ?`,
+		id, version)
+	if err != nil {
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return r.conflict(ctx, id, version)
+	}
+	return nil
+}
+
+func (r *mysqlInvoiceRepository) ListByUser(ctx context.Context, userID int64, status string) ([]invoiceRow, error) {
+	query := `SELECT ` + invoiceColumns + ` FROM invoices WHERE deleted_at IS NULL AND user_id = ?`
+	args := []interface{}{userID}
+	if status != "" {
+		query += ` AND status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY issue_date DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invoices := make([]invoiceRow, 0)
+	for rows.Next() {
+		row, err := scanInvoice(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, row)
+	}
+	return invoices, rows.Err()
+}
+
+func (r *mysqlInvoiceRepository) AnonymizeUser(ctx context.Context, userID int64) (int64, error) {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE invoices SET user_id = ?, version = version + 1 WHERE user_id = ?`,
+		anonymizedInvoiceUser, userID)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// conflict tells why a conditional write matched no row: the invoice is gone
+// or its version is no longer expectedVersion.
+func (r *mysqlInvoiceRepository) conflict(ctx context.Context, id, expectedVersion int64) error {
+	var current int64
+	err := r.db.QueryRowContext(ctx, `SELECT version FROM invoices WHERE id = ? AND deleted_at IS NULL`, id).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && expectedVersion == 0) {
+		return errInvoiceNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return &versionConflictError{Current: current}
+}
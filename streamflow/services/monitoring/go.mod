@@ -3,6 +3,7 @@ module monitoring-service
 go 1.23.1
 
 require (
+	github.com/rabbitmq/amqp091-go v1.10.0
 	go.mongodb.org/mongo-driver v1.17.1
 	google.golang.org/grpc v1.73.0
 	google.golang.org/protobuf v1.36.6
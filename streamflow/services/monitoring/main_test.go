@@ -0,0 +1,151 @@
+package main
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/types/known/emptypb"
+
+    pb "monitoring-service/pb"
+)
+
+func assertCode(t *testing.T, err error, want codes.Code) {
+    t.Helper()
+    if got := status.Code(err); got != want {
+        t.Fatalf("expected %s, got %s (%v)", want, got, err)
+    }
+}
+
+func insertAction(t *testing.T, logs LogRepository, doc ActionDoc) {
+    t.Helper()
+    if err := logs.InsertAction(context.Background(), doc); err != nil {
+        t.Fatalf("InsertAction: %v", err)
+    }
+}
+
+func TestLogActionRequiresAction(t *testing.T) {
+    s := newMonitoringServer(newMemoryLogRepository())
+
+    _, err := s.LogAction(context.Background(), &pb.LogActionRequest{UserId: 1})
+    assertCode(t, err, codes.InvalidArgument)
+
+    _, err = s.LogAction(context.Background(), &pb.LogActionRequest{UserId: 1, Action: "uploaded_video", Service: "videos-service"})
+    if err != nil {
+        t.Fatalf("LogAction: %v", err)
+    }
+    resp, err := s.ListActions(context.Background(), &emptypb.Empty{})
+    if err != nil {
+        t.Fatalf("ListActions: %v", err)
+    }
+    if len(resp.Actions) != 1 || resp.Actions[0].Action != "uploaded_video" {
+        t.Fatalf("expected the logged action, got %v", resp.Actions)
+    }
+}
+
+func TestListActionsIsNewestFirstAndCapped(t *testing.T) {
+    logs := newMemoryLogRepository()
+    s := newMonitoringServer(logs)
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    for i := 0; i < recentLogsLimit+5; i++ {
+        insertAction(t, logs, ActionDoc{UserID: 1, Action: "listed_users", CreatedAt: start.Add(time.Duration(i) * time.Minute)})
+    }
+
+    resp, err := s.ListActions(context.Background(), &emptypb.Empty{})
+    if err != nil {
+        t.Fatalf("ListActions: %v", err)
+    }
+    if len(resp.Actions) != recentLogsLimit {
+        t.Fatalf("expected %d actions, got %d", recentLogsLimit, len(resp.Actions))
+    }
+    if !resp.Actions[0].Timestamp.AsTime().After(resp.Actions[1].Timestamp.AsTime()) {
+        t.Fatal("actions should be newest first")
+    }
+}
+
+func TestListUserActionsIncludesImpersonation(t *testing.T) {
+    logs := newMemoryLogRepository()
+    s := newMonitoringServer(logs)
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    insertAction(t, logs, ActionDoc{UserID: 2, ActorUserID: 1, Action: "updated_profile", CreatedAt: start.Add(time.Hour)})
+    insertAction(t, logs, ActionDoc{UserID: 1, Action: "logged_in", CreatedAt: start})
+    insertAction(t, logs, ActionDoc{UserID: 3, Action: "logged_in", CreatedAt: start})
+
+    _, err := s.ListUserActions(context.Background(), &pb.ListUserActionsRequest{})
+    assertCode(t, err, codes.InvalidArgument)
+
+    resp, err := s.ListUserActions(context.Background(), &pb.ListUserActionsRequest{UserId: 1})
+    if err != nil {
+        t.Fatalf("ListUserActions: %v", err)
+    }
+    if len(resp.Actions) != 2 || resp.Actions[0].Action != "logged_in" || resp.Actions[1].ActorUserId != 1 {
+        t.Fatalf("expected own action then impersonation, oldest first, got %v", resp.Actions)
+    }
+}
+
+func TestRoleChangeIsRecordedOnce(t *testing.T) {
+    logs := newMemoryLogRepository()
+    event := roleChangedEvent{
+        EventID:      "evt-1",
+        ID:           7,
+        Email:        "user@streamflow.com",
+        Role:         "admin",
+        PreviousRole: "customer",
+        ChangedBy:    1,
+        ChangedAt:    "2024-01-01T10:00:00Z",
+        Version:      3,
+    }
+    for i := 0; i < 2; i++ {
+        if err := recordRoleChange(context.Background(), logs, event); err != nil {
+            t.Fatalf("recordRoleChange: %v", err)
+        }
+    }
+
+    actions, _ := logs.UserActions(context.Background(), 7)
+    if len(actions) != 1 {
+        t.Fatalf("a redelivered event must be recorded once, got %d actions", len(actions))
+    }
+    a := actions[0]
+    if a.Action != roleChangedAction || a.Details["role"] != "admin" || a.Details["previous_role"] != "customer" {
+        t.Fatalf("unexpected action: %+v", a)
+    }
+}
+
+func TestEraseUserKeepsOthersAndDropsActorEmail(t *testing.T) {
+    logs := newMemoryLogRepository()
+    insertAction(t, logs, ActionDoc{UserID: 1, Action: "logged_in"})
+    insertAction(t, logs, ActionDoc{UserID: 2, ActorUserID: 1, ActorEmail: "admin@streamflow.com", Action: "updated_profile"})
+    insertAction(t, logs, ActionDoc{UserID: 2, Action: "logged_in"})
+    if err := logs.InsertError(context.Background(), ErrorDoc{UserID: 1, ErrorMessage: "boom"}); err != nil {
+        t.Fatalf("InsertError: %v", err)
+    }
+
+    changed, err := logs.EraseUser(context.Background(), 1)
+    if err != nil {
+        t.Fatalf("EraseUser: %v", err)
+    }
+    if changed != 3 {
+        t.Fatalf("expected 3 changes, got %d", changed)
+    }
+
+    actions, _ := logs.UserActions(context.Background(), 2)
+    if len(actions) != 2 {
+        t.Fatalf("user 2's actions must remain, got %d", len(actions))
+    }
+    for _, a := range actions {
+        if a.ActorEmail != "" {
+            t.Fatalf("actor email should be removed: %+v", a)
+        }
+    }
+    errs, _ := logs.RecentErrors(context.Background(), recentLogsLimit)
+    if len(errs) != 0 {
+        t.Fatalf("user 1's errors should be deleted, got %v", errs)
+    }
+
+    // Erasing twice is harmless
+    if changed, _ := logs.EraseUser(context.Background(), 1); changed != 0 {
+        t.Fatalf("second erasure changed %d", changed)
+    }
+}
@@ -1,8 +1,8 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
-// 	protoc        v4.24.4
-// source: monitoring.proto
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: protos/monitoring.proto
 
 package pb
 
@@ -13,6 +13,7 @@ import (
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -23,26 +24,26 @@ const (
 )
 
 type ActionLog struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`                  // optional
+	Method        string                 `protobuf:"bytes,5,opt,name=method,proto3" json:"method,omitempty"`
+	Url           string                 `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`
+	Action        string                 `protobuf:"bytes,7,opt,name=action,proto3" json:"action,omitempty"`
+	ActorUserId   int64                  `protobuf:"varint,8,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`                                              // optional: admin impersonating user_id
+	ActorEmail    string                 `protobuf:"bytes,9,opt,name=actor_email,json=actorEmail,proto3" json:"actor_email,omitempty"`                                                    // optional
+	Details       map[string]string      `protobuf:"bytes,10,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // optional: action-specific data, e.g. previous_role
 	unknownFields protoimpl.UnknownFields
-
-	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	UserId    int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional
-	Email     string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`                  // optional
-	Method    string                 `protobuf:"bytes,5,opt,name=method,proto3" json:"method,omitempty"`
-	Url       string                 `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`
-	Action    string                 `protobuf:"bytes,7,opt,name=action,proto3" json:"action,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ActionLog) Reset() {
 	*x = ActionLog{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_monitoring_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_protos_monitoring_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ActionLog) String() string {
@@ -52,8 +53,8 @@ func (x *ActionLog) String() string {
 func (*ActionLog) ProtoMessage() {}
 
 func (x *ActionLog) ProtoReflect() protoreflect.Message {
-	mi := &file_monitoring_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_protos_monitoring_proto_msgTypes[0]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -65,7 +66,7 @@ func (x *ActionLog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActionLog.ProtoReflect.Descriptor instead.
 func (*ActionLog) Descriptor() ([]byte, []int) {
-	return file_monitoring_proto_rawDescGZIP(), []int{0}
+	return file_protos_monitoring_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *ActionLog) GetId() int64 {
@@ -117,25 +118,43 @@ func (x *ActionLog) GetAction() string {
 	return ""
 }
 
+func (x *ActionLog) GetActorUserId() int64 {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return 0
+}
+
+func (x *ActionLog) GetActorEmail() string {
+	if x != nil {
+		return x.ActorEmail
+	}
+	return ""
+}
+
+func (x *ActionLog) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
 type ErrorLog struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`                  // optional
+	ErrorMessage  string                 `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Id           int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Timestamp    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	UserId       int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional
-	Email        string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`                  // optional
-	ErrorMessage string                 `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ErrorLog) Reset() {
 	*x = ErrorLog{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_monitoring_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_protos_monitoring_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ErrorLog) String() string {
@@ -145,8 +164,8 @@ func (x *ErrorLog) String() string {
 func (*ErrorLog) ProtoMessage() {}
 
 func (x *ErrorLog) ProtoReflect() protoreflect.Message {
-	mi := &file_monitoring_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_protos_monitoring_proto_msgTypes[1]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -158,7 +177,7 @@ func (x *ErrorLog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ErrorLog.ProtoReflect.Descriptor instead.
 func (*ErrorLog) Descriptor() ([]byte, []int) {
-	return file_monitoring_proto_rawDescGZIP(), []int{1}
+	return file_protos_monitoring_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *ErrorLog) GetId() int64 {
@@ -197,20 +216,17 @@ func (x *ErrorLog) GetErrorMessage() string {
 }
 
 type ListActionsResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actions       []*ActionLog           `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Actions []*ActionLog `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListActionsResponse) Reset() {
 	*x = ListActionsResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_monitoring_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_protos_monitoring_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ListActionsResponse) String() string {
@@ -220,8 +236,8 @@ func (x *ListActionsResponse) String() string {
 func (*ListActionsResponse) ProtoMessage() {}
 
 func (x *ListActionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_monitoring_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_protos_monitoring_proto_msgTypes[2]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -233,7 +249,7 @@ func (x *ListActionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListActionsResponse.ProtoReflect.Descriptor instead.
 func (*ListActionsResponse) Descriptor() ([]byte, []int) {
-	return file_monitoring_proto_rawDescGZIP(), []int{2}
+	return file_protos_monitoring_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ListActionsResponse) GetActions() []*ActionLog {
@@ -244,20 +260,17 @@ func (x *ListActionsResponse) GetActions() []*ActionLog {
 }
 
 type ListErrorsResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Errors        []*ErrorLog            `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Errors []*ErrorLog `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListErrorsResponse) Reset() {
 	*x = ListErrorsResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_monitoring_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_protos_monitoring_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ListErrorsResponse) String() string {
@@ -267,8 +280,8 @@ func (x *ListErrorsResponse) String() string {
 func (*ListErrorsResponse) ProtoMessage() {}
 
 func (x *ListErrorsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_monitoring_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_protos_monitoring_proto_msgTypes[3]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -280,7 +293,7 @@ func (x *ListErrorsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListErrorsResponse.ProtoReflect.Descriptor instead.
 func (*ListErrorsResponse) Descriptor() ([]byte, []int) {
-	return file_monitoring_proto_rawDescGZIP(), []int{3}
+	return file_protos_monitoring_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ListErrorsResponse) GetErrors() []*ErrorLog {
@@ -290,172 +303,320 @@ func (x *ListErrorsResponse) GetErrors() []*ErrorLog {
 	return nil
 }
 
-var File_monitoring_proto protoreflect.FileDescriptor
-
-var file_monitoring_proto_rawDesc = []byte{
-	0x0a, 0x10, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x11, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0xc6, 0x01, 0x0a, 0x09, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f,
-	0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x17, 0x0a, 0x07, 0x75,
-	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73,
-	0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65,
-	0x74, 0x68, 0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68,
-	0x6f, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x75, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa8, 0x01, 0x0a,
-	0x08, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4c, 0x6f, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
-	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61,
-	0x69, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4d, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36,
-	0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1c, 0x2e, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x67, 0x52, 0x07, 0x61,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x49, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x06,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x4d,
-	0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4c, 0x6f, 0x67, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x73, 0x32, 0xaf, 0x01, 0x0a, 0x11, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4d, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x26,
-	0x2e, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x25, 0x2e, 0x4d,
-	0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x17, 0x5a, 0x15, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e,
-	0x67, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+// Actions performed by (or on) a user, for the export of their data
+type ListUserActionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
+func (x *ListUserActionsRequest) Reset() {
+	*x = ListUserActionsRequest{}
+	mi := &file_protos_monitoring_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserActionsRequest) ProtoMessage() {}
+
+func (x *ListUserActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_monitoring_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserActionsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserActionsRequest) Descriptor() ([]byte, []int) {
+	return file_protos_monitoring_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListUserActionsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type LogActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	UserEmail     string                 `protobuf:"bytes,3,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`
+	UrlMethod     string                 `protobuf:"bytes,4,opt,name=url_method,json=urlMethod,proto3" json:"url_method,omitempty"`
+	UserId        int64                  `protobuf:"varint,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Url           string                 `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`
+	ActorUserId   int64                  `protobuf:"varint,7,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"` // admin impersonating the user, if any
+	ActorEmail    string                 `protobuf:"bytes,8,opt,name=actor_email,json=actorEmail,proto3" json:"actor_email,omitempty"`
+	Details       map[string]string      `protobuf:"bytes,9,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogActionRequest) Reset() {
+	*x = LogActionRequest{}
+	mi := &file_protos_monitoring_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogActionRequest) ProtoMessage() {}
+
+func (x *LogActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_monitoring_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogActionRequest.ProtoReflect.Descriptor instead.
+func (*LogActionRequest) Descriptor() ([]byte, []int) {
+	return file_protos_monitoring_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LogActionRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *LogActionRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *LogActionRequest) GetUserEmail() string {
+	if x != nil {
+		return x.UserEmail
+	}
+	return ""
+}
+
+func (x *LogActionRequest) GetUrlMethod() string {
+	if x != nil {
+		return x.UrlMethod
+	}
+	return ""
+}
+
+func (x *LogActionRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *LogActionRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *LogActionRequest) GetActorUserId() int64 {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return 0
+}
+
+func (x *LogActionRequest) GetActorEmail() string {
+	if x != nil {
+		return x.ActorEmail
+	}
+	return ""
+}
+
+func (x *LogActionRequest) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type LogActionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogActionResponse) Reset() {
+	*x = LogActionResponse{}
+	mi := &file_protos_monitoring_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogActionResponse) ProtoMessage() {}
+
+func (x *LogActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_monitoring_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogActionResponse.ProtoReflect.Descriptor instead.
+func (*LogActionResponse) Descriptor() ([]byte, []int) {
+	return file_protos_monitoring_proto_rawDescGZIP(), []int{6}
+}
+
+var File_protos_monitoring_proto protoreflect.FileDescriptor
+
+const file_protos_monitoring_proto_rawDesc = "" +
+	"\n" +
+	"\x17protos/monitoring.proto\x12\x11MonitoringService\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x8c\x03\n" +
+	"\tActionLog\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x16\n" +
+	"\x06method\x18\x05 \x01(\tR\x06method\x12\x10\n" +
+	"\x03url\x18\x06 \x01(\tR\x03url\x12\x16\n" +
+	"\x06action\x18\a \x01(\tR\x06action\x12\"\n" +
+	"\ractor_user_id\x18\b \x01(\x03R\vactorUserId\x12\x1f\n" +
+	"\vactor_email\x18\t \x01(\tR\n" +
+	"actorEmail\x12C\n" +
+	"\adetails\x18\n" +
+	" \x03(\v2).MonitoringService.ActionLog.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa8\x01\n" +
+	"\bErrorLog\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12#\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\"M\n" +
+	"\x13ListActionsResponse\x126\n" +
+	"\aactions\x18\x01 \x03(\v2\x1c.MonitoringService.ActionLogR\aactions\"I\n" +
+	"\x12ListErrorsResponse\x123\n" +
+	"\x06errors\x18\x01 \x03(\v2\x1b.MonitoringService.ErrorLogR\x06errors\"1\n" +
+	"\x16ListUserActionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\xfa\x02\n" +
+	"\x10LogActionRequest\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x1d\n" +
+	"\n" +
+	"user_email\x18\x03 \x01(\tR\tuserEmail\x12\x1d\n" +
+	"\n" +
+	"url_method\x18\x04 \x01(\tR\turlMethod\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\x03R\x06userId\x12\x10\n" +
+	"\x03url\x18\x06 \x01(\tR\x03url\x12\"\n" +
+	"\ractor_user_id\x18\a \x01(\x03R\vactorUserId\x12\x1f\n" +
+	"\vactor_email\x18\b \x01(\tR\n" +
+	"actorEmail\x12J\n" +
+	"\adetails\x18\t \x03(\v20.MonitoringService.LogActionRequest.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x13\n" +
+	"\x11LogActionResponse2\xed\x02\n" +
+	"\x11MonitoringService\x12M\n" +
+	"\vListActions\x12\x16.google.protobuf.Empty\x1a&.MonitoringService.ListActionsResponse\x12K\n" +
+	"\n" +
+	"ListErrors\x12\x16.google.protobuf.Empty\x1a%.MonitoringService.ListErrorsResponse\x12V\n" +
+	"\tLogAction\x12#.MonitoringService.LogActionRequest\x1a$.MonitoringService.LogActionResponse\x12d\n" +
+	"\x0fListUserActions\x12).MonitoringService.ListUserActionsRequest\x1a&.MonitoringService.ListActionsResponseB\x17Z\x15monitoring-service/pbb\x06proto3"
+
 var (
-	file_monitoring_proto_rawDescOnce sync.Once
-	file_monitoring_proto_rawDescData = file_monitoring_proto_rawDesc
+	file_protos_monitoring_proto_rawDescOnce sync.Once
+	file_protos_monitoring_proto_rawDescData []byte
 )
 
-func file_monitoring_proto_rawDescGZIP() []byte {
-	file_monitoring_proto_rawDescOnce.Do(func() {
-		file_monitoring_proto_rawDescData = protoimpl.X.CompressGZIP(file_monitoring_proto_rawDescData)
+func file_protos_monitoring_proto_rawDescGZIP() []byte {
+	file_protos_monitoring_proto_rawDescOnce.Do(func() {
+		file_protos_monitoring_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_protos_monitoring_proto_rawDesc), len(file_protos_monitoring_proto_rawDesc)))
 	})
-	return file_monitoring_proto_rawDescData
-}
-
-var file_monitoring_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_monitoring_proto_goTypes = []interface{}{
-	(*ActionLog)(nil),             // 0: MonitoringService.ActionLog
-	(*ErrorLog)(nil),              // 1: MonitoringService.ErrorLog
-	(*ListActionsResponse)(nil),   // 2: MonitoringService.ListActionsResponse
-	(*ListErrorsResponse)(nil),    // 3: MonitoringService.ListErrorsResponse
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 5: google.protobuf.Empty
-}
-var file_monitoring_proto_depIdxs = []int32{
-	4, // 0: MonitoringService.ActionLog.timestamp:type_name -> google.protobuf.Timestamp
-	4, // 1: MonitoringService.ErrorLog.timestamp:type_name -> google.protobuf.Timestamp
-	0, // 2: MonitoringService.ListActionsResponse.actions:type_name -> MonitoringService.ActionLog
-	1, // 3: MonitoringService.ListErrorsResponse.errors:type_name -> MonitoringService.ErrorLog
-	5, // 4: MonitoringService.MonitoringService.ListActions:input_type -> google.protobuf.Empty
-	5, // 5: MonitoringService.MonitoringService.ListErrors:input_type -> google.protobuf.Empty
-	2, // 6: MonitoringService.MonitoringService.ListActions:output_type -> MonitoringService.ListActionsResponse
-	3, // 7: MonitoringService.MonitoringService.ListErrors:output_type -> MonitoringService.ListErrorsResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
-}
-
-func init() { file_monitoring_proto_init() }
-func file_monitoring_proto_init() {
-	if File_monitoring_proto != nil {
+	return file_protos_monitoring_proto_rawDescData
+}
+
+var file_protos_monitoring_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_protos_monitoring_proto_goTypes = []any{
+	(*ActionLog)(nil),              // 0: MonitoringService.ActionLog
+	(*ErrorLog)(nil),               // 1: MonitoringService.ErrorLog
+	(*ListActionsResponse)(nil),    // 2: MonitoringService.ListActionsResponse
+	(*ListErrorsResponse)(nil),     // 3: MonitoringService.ListErrorsResponse
+	(*ListUserActionsRequest)(nil), // 4: MonitoringService.ListUserActionsRequest
+	(*LogActionRequest)(nil),       // 5: MonitoringService.LogActionRequest
+	(*LogActionResponse)(nil),      // 6: MonitoringService.LogActionResponse
+	nil,                            // 7: MonitoringService.ActionLog.DetailsEntry
+	nil,                            // 8: MonitoringService.LogActionRequest.DetailsEntry
+	(*timestamppb.Timestamp)(nil),  // 9: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),          // 10: google.protobuf.Empty
+}
+var file_protos_monitoring_proto_depIdxs = []int32{
+	9,  // 0: MonitoringService.ActionLog.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 1: MonitoringService.ActionLog.details:type_name -> MonitoringService.ActionLog.DetailsEntry
+	9,  // 2: MonitoringService.ErrorLog.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 3: MonitoringService.ListActionsResponse.actions:type_name -> MonitoringService.ActionLog
+	1,  // 4: MonitoringService.ListErrorsResponse.errors:type_name -> MonitoringService.ErrorLog
+	8,  // 5: MonitoringService.LogActionRequest.details:type_name -> MonitoringService.LogActionRequest.DetailsEntry
+	10, // 6: MonitoringService.MonitoringService.ListActions:input_type -> google.protobuf.Empty
+	10, // 7: MonitoringService.MonitoringService.ListErrors:input_type -> google.protobuf.Empty
+	5,  // 8: MonitoringService.MonitoringService.LogAction:input_type -> MonitoringService.LogActionRequest
+	4,  // 9: MonitoringService.MonitoringService.ListUserActions:input_type -> MonitoringService.ListUserActionsRequest
+	2,  // 10: MonitoringService.MonitoringService.ListActions:output_type -> MonitoringService.ListActionsResponse
+	3,  // 11: MonitoringService.MonitoringService.ListErrors:output_type -> MonitoringService.ListErrorsResponse
+	6,  // 12: MonitoringService.MonitoringService.LogAction:output_type -> MonitoringService.LogActionResponse
+	2,  // 13: MonitoringService.MonitoringService.ListUserActions:output_type -> MonitoringService.ListActionsResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_protos_monitoring_proto_init() }
+func file_protos_monitoring_proto_init() {
+	if File_protos_monitoring_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_monitoring_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActionLog); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_monitoring_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ErrorLog); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_monitoring_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListActionsResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_monitoring_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListErrorsResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_monitoring_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protos_monitoring_proto_rawDesc), len(file_protos_monitoring_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
-		GoTypes:           file_monitoring_proto_goTypes,
-		DependencyIndexes: file_monitoring_proto_depIdxs,
-		MessageInfos:      file_monitoring_proto_msgTypes,
+		GoTypes:           file_protos_monitoring_proto_goTypes,
+		DependencyIndexes: file_protos_monitoring_proto_depIdxs,
+		MessageInfos:      file_protos_monitoring_proto_msgTypes,
 	}.Build()
-	File_monitoring_proto = out.File
-	file_monitoring_proto_rawDesc = nil
-	file_monitoring_proto_goTypes = nil
-	file_monitoring_proto_depIdxs = nil
+	File_protos_monitoring_proto = out.File
+	file_protos_monitoring_proto_goTypes = nil
+	file_protos_monitoring_proto_depIdxs = nil
 }
@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: protos/monitoring.proto
 
 package pb
 
@@ -12,7 +16,15 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion7
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MonitoringService_ListActions_FullMethodName     = "/MonitoringService.MonitoringService/ListActions"
+	MonitoringService_ListErrors_FullMethodName      = "/MonitoringService.MonitoringService/ListErrors"
+	MonitoringService_LogAction_FullMethodName       = "/MonitoringService.MonitoringService/LogAction"
+	MonitoringService_ListUserActions_FullMethodName = "/MonitoringService.MonitoringService/ListUserActions"
+)
 
 // MonitoringServiceClient is the client API for MonitoringService service.
 //
@@ -20,6 +32,8 @@ const _ = grpc.SupportPackageIsVersion7
 type MonitoringServiceClient interface {
 	ListActions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListActionsResponse, error)
 	ListErrors(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListErrorsResponse, error)
+	LogAction(ctx context.Context, in *LogActionRequest, opts ...grpc.CallOption) (*LogActionResponse, error)
+	ListUserActions(ctx context.Context, in *ListUserActionsRequest, opts ...grpc.CallOption) (*ListActionsResponse, error)
 }
 
 type monitoringServiceClient struct {
@@ -31,8 +45,9 @@ func NewMonitoringServiceClient(cc grpc.ClientConnInterface) MonitoringServiceCl
 }
 
 func (c *monitoringServiceClient) ListActions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListActionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListActionsResponse)
-	err := c.cc.Invoke(ctx, "/MonitoringService.MonitoringService/ListActions", in, out, opts...)
+	err := c.cc.Invoke(ctx, MonitoringService_ListActions_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -40,8 +55,29 @@ func (c *monitoringServiceClient) ListActions(ctx context.Context, in *emptypb.E
 }
 
 func (c *monitoringServiceClient) ListErrors(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListErrorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListErrorsResponse)
-	err := c.cc.Invoke(ctx, "/MonitoringService.MonitoringService/ListErrors", in, out, opts...)
+	err := c.cc.Invoke(ctx, MonitoringService_ListErrors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *monitoringServiceClient) LogAction(ctx context.Context, in *LogActionRequest, opts ...grpc.CallOption) (*LogActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogActionResponse)
+	err := c.cc.Invoke(ctx, MonitoringService_LogAction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *monitoringServiceClient) ListUserActions(ctx context.Context, in *ListUserActionsRequest, opts ...grpc.CallOption) (*ListActionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListActionsResponse)
+	err := c.cc.Invoke(ctx, MonitoringService_ListUserActions_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -50,16 +86,21 @@ func (c *monitoringServiceClient) ListErrors(ctx context.Context, in *emptypb.Em
 
 // MonitoringServiceServer is the server API for MonitoringService service.
 // All implementations must embed UnimplementedMonitoringServiceServer
-// for forward compatibility
+// for forward compatibility.
 type MonitoringServiceServer interface {
 	ListActions(context.Context, *emptypb.Empty) (*ListActionsResponse, error)
 	ListErrors(context.Context, *emptypb.Empty) (*ListErrorsResponse, error)
+	LogAction(context.Context, *LogActionRequest) (*LogActionResponse, error)
+	ListUserActions(context.Context, *ListUserActionsRequest) (*ListActionsResponse, error)
 	mustEmbedUnimplementedMonitoringServiceServer()
 }
 
-// UnimplementedMonitoringServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedMonitoringServiceServer struct {
-}
+// UnimplementedMonitoringServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMonitoringServiceServer struct{}
 
 func (UnimplementedMonitoringServiceServer) ListActions(context.Context, *emptypb.Empty) (*ListActionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListActions not implemented")
@@ -67,7 +108,14 @@ func (UnimplementedMonitoringServiceServer) ListActions(context.Context, *emptyp
 func (UnimplementedMonitoringServiceServer) ListErrors(context.Context, *emptypb.Empty) (*ListErrorsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListErrors not implemented")
 }
+func (UnimplementedMonitoringServiceServer) LogAction(context.Context, *LogActionRequest) (*LogActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogAction not implemented")
+}
+func (UnimplementedMonitoringServiceServer) ListUserActions(context.Context, *ListUserActionsRequest) (*ListActionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserActions not implemented")
+}
 func (UnimplementedMonitoringServiceServer) mustEmbedUnimplementedMonitoringServiceServer() {}
+func (UnimplementedMonitoringServiceServer) testEmbeddedByValue()                           {}
 
 // UnsafeMonitoringServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to MonitoringServiceServer will
@@ -76,8 +124,15 @@ type UnsafeMonitoringServiceServer interface {
 	mustEmbedUnimplementedMonitoringServiceServer()
 }
 
-func RegisterMonitoringServiceServer(s *grpc.Server, srv MonitoringServiceServer) {
-	s.RegisterService(&_MonitoringService_serviceDesc, srv)
+func RegisterMonitoringServiceServer(s grpc.ServiceRegistrar, srv MonitoringServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMonitoringServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MonitoringService_ServiceDesc, srv)
 }
 
 func _MonitoringService_ListActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
@@ -90,7 +145,7 @@ func _MonitoringService_ListActions_Handler(srv interface{}, ctx context.Context
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/MonitoringService.MonitoringService/ListActions",
+		FullMethod: MonitoringService_ListActions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(MonitoringServiceServer).ListActions(ctx, req.(*emptypb.Empty))
@@ -108,7 +163,7 @@ func _MonitoringService_ListErrors_Handler(srv interface{}, ctx context.Context,
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/MonitoringService.MonitoringService/ListErrors",
+		FullMethod: MonitoringService_ListErrors_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(MonitoringServiceServer).ListErrors(ctx, req.(*emptypb.Empty))
@@ -116,7 +171,46 @@ func _MonitoringService_ListErrors_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-var _MonitoringService_serviceDesc = grpc.ServiceDesc{
+func _MonitoringService_LogAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MonitoringServiceServer).LogAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MonitoringService_LogAction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MonitoringServiceServer).LogAction(ctx, req.(*LogActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MonitoringService_ListUserActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserActionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MonitoringServiceServer).ListUserActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MonitoringService_ListUserActions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MonitoringServiceServer).ListUserActions(ctx, req.(*ListUserActionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MonitoringService_ServiceDesc is the grpc.ServiceDesc for MonitoringService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MonitoringService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "MonitoringService.MonitoringService",
 	HandlerType: (*MonitoringServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
@@ -128,7 +222,15 @@ var _MonitoringService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListErrors",
 			Handler:    _MonitoringService_ListErrors_Handler,
 		},
+		{
+			MethodName: "LogAction",
+			Handler:    _MonitoringService_LogAction_Handler,
+		},
+		{
+			MethodName: "ListUserActions",
+			Handler:    _MonitoringService_ListUserActions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "monitoring.proto",
+	Metadata: "protos/monitoring.proto",
 }
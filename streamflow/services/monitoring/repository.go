@@ -0,0 +1,32 @@
+package main
+
+import (
+    "context"
+)
+
+// ----------- Log repository ---------------------------------------------------
+//
+// The gRPC handlers and consumers reach the actions and errors collections
+// only through LogRepository. mongoLogRepository is the production backend;
+// memoryLogRepository backs the unit tests.
+
+// recentLogsLimit caps ListActions and ListErrors
+const recentLogsLimit = 100
+
+// LogRepository stores the actions and errors reported by other services
+type LogRepository interface {
+    InsertAction(ctx context.Context, doc ActionDoc) error
+    InsertError(ctx context.Context, doc ErrorDoc) error
+    // RecordActionOnce inserts the action unless one with the same EventID
+    // was already stored, so redelivered events are recorded once
+    RecordActionOnce(ctx context.Context, doc ActionDoc) error
+    // RecentActions and RecentErrors return the newest entries first
+    RecentActions(ctx context.Context, limit int) ([]ActionDoc, error)
+    RecentErrors(ctx context.Context, limit int) ([]ErrorDoc, error)
+    // UserActions returns, oldest first, the actions of the user and the
+    // ones they performed while impersonating someone else
+    UserActions(ctx context.Context, userID int64) ([]ActionDoc, error)
+    // EraseUser deletes the user's actions and errors, removes their email
+    // from actions they performed as actor and returns how many changed
+    EraseUser(ctx context.Context, userID int64) (int64, error)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+    "context"
+    "sort"
+    "sync"
+
+    "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryLogRepository keeps logs in memory with the same semantics as the
+// Mongo collections
+type memoryLogRepository struct {
+    mu      sync.Mutex
+    actions []ActionDoc
+    errors  []ErrorDoc
+}
+
+func newMemoryLogRepository() *memoryLogRepository {
+    return &memoryLogRepository{}
+}
+
+func (r *memoryLogRepository) InsertAction(ctx context.Context, doc ActionDoc) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    doc.ID = primitive.NewObjectID()
+    r.actions = append(r.actions, doc)
+    return nil
+}
+
+func (r *memoryLogRepository) InsertError(ctx context.Context, doc ErrorDoc) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    doc.ID = primitive.NewObjectID()
+    r.errors = append(r.errors, doc)
+    return nil
+}
+
+func (r *memoryLogRepository) RecordActionOnce(ctx context.Context, doc ActionDoc) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    for _, a := range r.actions {
+        if a.EventID != "" && a.EventID == doc.EventID {
+            return nil
+        }
+    }
+    doc.ID = primitive.NewObjectID()
+    r.actions = append(r.actions, doc)
+    return nil
+}
+
+func (r *memoryLogRepository) RecentActions(ctx context.Context, limit int) ([]ActionDoc, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    actions := append([]ActionDoc{}, r.actions...)
+    sort.SliceStable(actions, func(i, j int) bool {
+        return actions[i].CreatedAt.After(actions[j].CreatedAt)
+    })
+    if len(actions) > limit {
+        actions = actions[:limit]
+    }
+    return actions, nil
+}
+
+func (r *memoryLogRepository) RecentErrors(ctx context.Context, limit int) ([]ErrorDoc, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    errs := append([]ErrorDoc{}, r.errors...)
+    sort.SliceStable(errs, func(i, j int) bool {
+        return errs[i].CreatedAt.After(errs[j].CreatedAt)
+    })
+    if len(errs) > limit {
+        errs = errs[:limit]
+    }
+    return errs, nil
+}
+
+func (r *memoryLogRepository) UserActions(ctx context.Context, userID int64) ([]ActionDoc, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    actions := []ActionDoc{}
+    for _, a := range r.actions {
+        if a.UserID == userID || a.ActorUserID == userID {
+            actions = append(actions, a)
+        }
+    }
+    sort.SliceStable(actions, func(i, j int) bool {
+        return actions[i].CreatedAt.Before(actions[j].CreatedAt)
+    })
+    return actions, nil
+}
+
+func (r *memoryLogRepository) EraseUser(ctx context.Context, userID int64) (int64, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    var changed int64
+    actions := r.actions[:0]
+    for _, a := range r.actions {
+        if a.UserID == userID {
+            changed++
+            continue
+        }
+        if a.ActorUserID == userID && a.ActorEmail != "" {
+            a.ActorEmail = ""
+            changed++
+        }
+        actions = append(actions, a)
+    }
+    r.actions = actions
+
+    errs := r.errors[:0]
+    for _, e := range r.errors {
+        if e.UserID == userID {
+            changed++
+            continue
+        }
+        errs = append(errs, e)
+    }
+    r.errors = errs
+    return changed, nil
+}
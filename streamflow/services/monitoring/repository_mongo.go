@@ -0,0 +1,116 @@
+package main
+
+import (
+    "context"
+    "fmt"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoLogRepository stores logs in the actions and errors collections
+type mongoLogRepository struct {
+    actions *mongo.Collection
+    errors  *mongo.Collection
+}
+
+func newMongoLogRepository(db *mongo.Database) *mongoLogRepository {
+    return &mongoLogRepository{
+        actions: db.Collection("actions"),
+        errors:  db.Collection("errors"),
+    }
+}
+
+// ensureIndexes creates the unique event_id index RecordActionOnce relies on
+func (r *mongoLogRepository) ensureIndexes(ctx context.Context) error {
+    _, err := r.actions.Indexes().CreateOne(ctx, mongo.IndexModel{
+        Keys:    bson.D{{Key: "event_id", Value: 1}},
+        Options: options.Index().SetName("event_id_unique").SetUnique(true).SetSparse(true),
+    })
+    return err
+}
+
+func (r *mongoLogRepository) InsertAction(ctx context.Context, doc ActionDoc) error {
+    _, err := r.actions.InsertOne(ctx, doc)
+    return err
+}
+
+func (r *mongoLogRepository) InsertError(ctx context.Context, doc ErrorDoc) error {
+    _, err := r.errors.InsertOne(ctx, doc)
+    return err
+}
+
+func (r *mongoLogRepository) RecordActionOnce(ctx context.Context, doc ActionDoc) error {
+    _, err := r.actions.UpdateOne(ctx,
+        bson.M{"event_id": doc.EventID},
+        bson.M{"$setOnInsert": doc},
+        options.Update().SetUpsert(true),
+    )
+    if mongo.IsDuplicateKeyError(err) {
+        return nil
+    }
+    return err
+}
+
+func (r *mongoLogRepository) RecentActions(ctx context.Context, limit int) ([]ActionDoc, error) {
+    cursor, err := r.actions.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(int64(limit)))
+    if err != nil {
+        return nil, err
+    }
+    return decodeAll[ActionDoc](ctx, cursor)
+}
+
+func (r *mongoLogRepository) RecentErrors(ctx context.Context, limit int) ([]ErrorDoc, error) {
+    cursor, err := r.errors.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(int64(limit)))
+    if err != nil {
+        return nil, err
+    }
+    return decodeAll[ErrorDoc](ctx, cursor)
+}
+
+func (r *mongoLogRepository) UserActions(ctx context.Context, userID int64) ([]ActionDoc, error) {
+    filter := bson.M{"$or": bson.A{
+        bson.M{"user_id": userID},
+        bson.M{"actor_user_id": userID},
+    }}
+    cursor, err := r.actions.Find(ctx, filter, options.Find().SetSort(bson.M{"created_at": 1}))
+    if err != nil {
+        return nil, err
+    }
+    return decodeAll[ActionDoc](ctx, cursor)
+}
+
+func (r *mongoLogRepository) EraseUser(ctx context.Context, userID int64) (int64, error) {
+    actions, err := r.actions.DeleteMany(ctx, bson.M{"user_id": userID})
+    if err != nil {
+        return 0, fmt.Errorf("deleting actions: %w", err)
+    }
+    errs, err := r.errors.DeleteMany(ctx, bson.M{"user_id": userID})
+    if err != nil {
+        return 0, fmt.Errorf("deleting errors: %w", err)
+    }
+    acted, err := r.actions.UpdateMany(ctx,
+        bson.M{"actor_user_id": userID, "actor_email": bson.M{"$exists": true}},
+        bson.M{"$unset": bson.M{"actor_email": ""}},
+    )
+    if err != nil {
+        return 0, fmt.Errorf("anonymizing impersonation actions: %w", err)
+    }
+    return actions.DeletedCount + errs.DeletedCount + acted.ModifiedCount, nil
+}
+
+// decodeAll reads every document of the cursor, skipping invalid ones
+func decodeAll[T any](ctx context.Context, cursor *mongo.Cursor) ([]T, error) {
+    defer cursor.Close(ctx)
+
+    docs := []T{}
+    for cursor.Next(ctx) {
+        var doc T
+        if err := cursor.Decode(&doc); err != nil {
+            continue // Skip invalid documents
+        }
+        docs = append(docs, doc)
+    }
+    return docs, cursor.Err()
+}
@@ -9,7 +9,11 @@ require (
 	google.golang.org/protobuf v1.36.6
 )
 
-require github.com/lib/pq v1.10.9
+require (
+	github.com/lib/pq v1.10.9
+	github.com/rabbitmq/amqp091-go v1.10.0
+	streamflow/shared v0.0.0
+)
 
 require (
 	golang.org/x/net v0.38.0 // indirect
@@ -17,3 +21,5 @@ require (
 	golang.org/x/text v0.23.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250324211829-b45e905df463 // indirect
 )
+
+replace streamflow/shared => ../../shared
@@ -11,12 +11,12 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"strconv"
-	"time"
 
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
@@ -26,6 +26,8 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"streamflow/shared/dbmigrate"
+
 	// Update the import path below to match your actual Go module path for the generated protobuf files.
 	// For example, if your module is "streamflow" and the generated files are in "protos/playlist.streamflow/services/playlists/pb", use:
 	pb "playlists-service/pb" // => generated files (playlists_pb2.go etc.)
@@ -57,49 +59,6 @@ func (c dbCfg) dsn() string {
 	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", c.Host, c.Port, c.User, c.Pass, c.Name)
 }
 
-var db *sql.DB
-
-func initSchema() error {
-	const q1 = `CREATE TABLE IF NOT EXISTS playlists (
-        id SERIAL PRIMARY KEY,
-        owner_id INTEGER NOT NULL,
-        name VARCHAR(255) NOT NULL,
-        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-        deleted_at TIMESTAMP NULL
-    )`
-	const q2 = `CREATE TABLE IF NOT EXISTS playlist_videos (
-        id SERIAL PRIMARY KEY,
-        playlist_id INTEGER NOT NULL,
-        video_id INTEGER NOT NULL,
-        added_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-        deleted_at TIMESTAMP NULL,
-        UNIQUE (playlist_id, video_id)
-    )`
-	const q3 = `CREATE INDEX IF NOT EXISTS idx_playlists_owner ON playlists (owner_id)`
-	const q4 = `CREATE INDEX IF NOT EXISTS idx_playlists_deleted ON playlists (deleted_at)`
-	const q5 = `CREATE INDEX IF NOT EXISTS idx_playlist_videos_playlist ON playlist_videos (playlist_id)`
-	const q6 = `CREATE INDEX IF NOT EXISTS idx_playlist_videos_deleted ON playlist_videos (deleted_at)`
-	if _, err := db.Exec(q1); err != nil {
-		return err
-	}
-	if _, err := db.Exec(q2); err != nil {
-		return err
-	}
-	if _, err := db.Exec(q3); err != nil {
-		return err
-	}
-	if _, err := db.Exec(q4); err != nil {
-		return err
-	}
-	if _, err := db.Exec(q5); err != nil {
-		return err
-	}
-	if _, err := db.Exec(q6); err != nil {
-		return err
-	}
-	return nil
-}
-
 //======================== AUTH =========================
 
 const mdUserID = "user_id"
@@ -126,6 +85,15 @@ func getAuth(ctx context.Context) (auth, error) {
 
 type srv struct {
 	pb.UnimplementedPlaylistsServiceServer
+	playlists PlaylistRepository
+}
+
+func newServer(playlists PlaylistRepository) *srv {
+	return &srv{playlists: playlists}
+}
+
+func toProto(p playlistRow) *pb.Playlist {
+	return &pb.Playlist{Id: p.ID, OwnerId: p.OwnerID, Name: p.Name, CreatedAt: timestamppb.New(p.CreatedAt)}
 }
 
 // CreatePlaylist
@@ -137,13 +105,11 @@ func (s *srv) CreatePlaylist(ctx context.Context, req *pb.CreatePlaylistRequest)
 	if req.Name == "" {
 		return nil, status.Error(codes.InvalidArgument, "name required")
 	}
-	var id int64
-	err = db.QueryRowContext(ctx, `INSERT INTO playlists (owner_id, name) VALUES ($1, $2) RETURNING id`, auth.userID, req.Name).Scan(&id)
+	p, err := s.playlists.Create(ctx, auth.userID, req.Name)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "insert: %v", err)
 	}
-	p := &pb.Playlist{Id: id, OwnerId: auth.userID, Name: req.Name, CreatedAt: timestamppb.Now()}
-	return &pb.CreatePlaylistResponse{Playlist: p}, nil
+	return &pb.CreatePlaylistResponse{Playlist: toProto(p)}, nil
 }
 
 // AddVideo
@@ -152,26 +118,18 @@ func (s *srv) AddVideo(ctx context.Context, req *pb.AddVideoRequest) (*pb.AddVid
 	if err != nil {
 		return nil, err
 	}
-	// Verify ownership
-	var ownerID int64
-	err = db.QueryRowContext(ctx, `SELECT owner_id FROM playlists WHERE id = $1 AND deleted_at IS NULL`, req.PlaylistId).Scan(&ownerID)
-	if err == sql.ErrNoRows {
-		return nil, status.Error(codes.NotFound, "playlist not found")
-	} else if err != nil {
-		return nil, status.Errorf(codes.Internal, "query: %v", err)
+	p, err := s.ownedPlaylist(ctx, auth, req.PlaylistId)
+	if err != nil {
+		return nil, err
 	}
-	if ownerID != auth.userID {
-		return nil, status.Error(codes.PermissionDenied, "not owner")
+	err = s.playlists.AddVideo(ctx, req.PlaylistId, req.VideoId)
+	if errors.Is(err, errVideoInPlaylist) {
+		return nil, status.Error(codes.AlreadyExists, "video already in playlist")
 	}
-	_, err = db.ExecContext(ctx, `INSERT INTO playlist_videos (playlist_id, video_id) VALUES ($1, $2)`, req.PlaylistId, req.VideoId)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "insert: %v", err)
 	}
-	playlistResp, err := s.buildPlaylistResponse(ctx, req.PlaylistId)
-	if err != nil {
-		return nil, err
-	}
-	return &pb.AddVideoResponse{Playlist: playlistResp.Playlist}, nil
+	return &pb.AddVideoResponse{Playlist: toProto(p)}, nil
 }
 
 // RemoveVideo
@@ -180,27 +138,14 @@ func (s *srv) RemoveVideo(ctx context.Context, req *pb.RemoveVideoRequest) (*pb.
 	if err != nil {
 		return nil, err
 	}
-	// Verify ownership
-	var ownerID int64
-	err = db.QueryRowContext(ctx, `SELECT owner_id FROM playlists WHERE id = $1 AND deleted_at IS NULL`, req.PlaylistId).Scan(&ownerID)
-	if err == sql.ErrNoRows {
-		return nil, status.Error(codes.NotFound, "playlist not found")
-	}
+	p, err := s.ownedPlaylist(ctx, auth, req.PlaylistId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "query: %v", err)
-	}
-	if ownerID != auth.userID {
-		return nil, status.Error(codes.PermissionDenied, "not owner")
+		return nil, err
 	}
-	_, err = db.ExecContext(ctx, `UPDATE playlist_videos SET deleted_at = NOW() WHERE playlist_id = $1 AND video_id = $2 AND deleted_at IS NULL`, req.PlaylistId, req.VideoId)
-	if err != nil {
+	if err := s.playlists.RemoveVideo(ctx, req.PlaylistId, req.VideoId); err != nil {
 		return nil, status.Errorf(codes.Internal, "delete: %v", err)
 	}
-	playlistResp, err := s.buildPlaylistResponse(ctx, req.PlaylistId)
-	if err != nil {
-		return nil, err
-	}
-	return &pb.RemoveVideoResponse{Playlist: playlistResp.Playlist}, nil
+	return &pb.RemoveVideoResponse{Playlist: toProto(p)}, nil
 }
 
 // ListPlaylists
@@ -209,20 +154,13 @@ func (s *srv) ListPlaylists(ctx context.Context, _ *emptypb.Empty) (*pb.ListPlay
 	if err != nil {
 		return nil, err
 	}
-	rows, err := db.QueryContext(ctx, `SELECT id, name, created_at FROM playlists WHERE owner_id = $1 AND deleted_at IS NULL ORDER BY created_at DESC`, auth.userID)
+	playlists, err := s.playlists.ListByOwner(ctx, auth.userID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "query: %v", err)
 	}
-	defer rows.Close()
 	var resp pb.ListPlaylistsResponse
-	for rows.Next() {
-		var id int64
-		var name string
-		var ts time.Time
-		if err := rows.Scan(&id, &name, &ts); err != nil {
-			return nil, status.Errorf(codes.Internal, "scan: %v", err)
-		}
-		resp.Playlists = append(resp.Playlists, &pb.Playlist{Id: id, OwnerId: auth.userID, Name: name, CreatedAt: timestamppb.New(ts)})
+	for _, p := range playlists {
+		resp.Playlists = append(resp.Playlists, toProto(p))
 	}
 	return &resp, nil
 }
@@ -233,30 +171,15 @@ func (s *srv) ListVideos(ctx context.Context, req *pb.ListVideosRequest) (*pb.Li
 	if err != nil {
 		return nil, err
 	}
-	// verify owner
-	var ownerID int64
-	err = db.QueryRowContext(ctx, `SELECT owner_id FROM playlists WHERE id = $1 AND deleted_at IS NULL`, req.PlaylistId).Scan(&ownerID)
-	if err == sql.ErrNoRows {
-		return nil, status.Error(codes.NotFound, "playlist not found")
-	}
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "query: %v", err)
-	}
-	if ownerID != auth.userID {
-		return nil, status.Error(codes.PermissionDenied, "not owner")
+	if _, err := s.ownedPlaylist(ctx, auth, req.PlaylistId); err != nil {
+		return nil, err
 	}
-
-	rows, err := db.QueryContext(ctx, `SELECT video_id FROM playlist_videos WHERE playlist_id = $1 AND deleted_at IS NULL`, req.PlaylistId)
+	videos, err := s.playlists.ListVideos(ctx, req.PlaylistId)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "query: %v", err)
 	}
-	defer rows.Close()
 	var resp pb.ListVideosResponse
-	for rows.Next() {
-		var vid int64
-		if err := rows.Scan(&vid); err != nil {
-			return nil, status.Errorf(codes.Internal, "scan: %v", err)
-		}
+	for _, vid := range videos {
 		resp.Videos = append(resp.Videos, &pb.VideoInPlaylist{VideoId: vid})
 	}
 	return &resp, nil
@@ -268,54 +191,71 @@ func (s *srv) DeletePlaylist(ctx context.Context, req *pb.DeletePlaylistRequest)
 	if err != nil {
 		return nil, err
 	}
-	// verify owner
-	res, err := db.ExecContext(ctx, `UPDATE playlists SET deleted_at = NOW() WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL`, req.PlaylistId, auth.userID)
+	// Only deletes a playlist of the caller
+	err = s.playlists.Delete(ctx, req.PlaylistId, auth.userID)
+	if errors.Is(err, errPlaylistNotFound) {
+		return nil, status.Error(codes.PermissionDenied, "not owner or playlist not found")
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "delete: %v", err)
 	}
-	affected, _ := res.RowsAffected()
-	if affected == 0 {
-		return nil, status.Error(codes.PermissionDenied, "not owner or playlist not found")
-	}
 	return &emptypb.Empty{}, nil
 }
 
-// buildPlaylistResponse fetches minimal playlist row after modification
-func (s *srv) buildPlaylistResponse(ctx context.Context, pid int64) (*pb.AddVideoResponse, error) {
-	var name string
-	var ts time.Time
-	var owner int64
-	err := db.QueryRowContext(ctx, `SELECT owner_id, name, created_at FROM playlists WHERE id = $1`, pid).Scan(&owner, &name, &ts)
+// ownedPlaylist returns the playlist if it exists and belongs to the caller
+func (s *srv) ownedPlaylist(ctx context.Context, auth auth, id int64) (playlistRow, error) {
+	p, err := s.playlists.Get(ctx, id)
+	if errors.Is(err, errPlaylistNotFound) {
+		return playlistRow{}, status.Error(codes.NotFound, "playlist not found")
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "select: %v", err)
+		return playlistRow{}, status.Errorf(codes.Internal, "query: %v", err)
 	}
-	return &pb.AddVideoResponse{Playlist: &pb.Playlist{Id: pid, OwnerId: owner, Name: name, CreatedAt: timestamppb.New(ts)}}, nil
+	if p.OwnerID != auth.userID {
+		return playlistRow{}, status.Error(codes.PermissionDenied, "not owner")
+	}
+	return p, nil
 }
 
 //======================== MAIN =========================
 
 func main() {
 	c := cfg()
-	var err error
-	db, err = sql.Open("postgres", c.dsn())
+	db, err := sql.Open("postgres", c.dsn())
 	if err != nil {
 		log.Fatalf("db open: %v", err)
 	}
 	if err = db.Ping(); err != nil {
 		log.Fatalf("db ping: %v", err)
 	}
-	if err = initSchema(); err != nil {
+	migrator, err := newMigrator(db)
+	if err != nil {
+		log.Fatalf("migrations: %v", err)
+	}
+	// "migrate" reports or changes the schema version and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := dbmigrate.RunCommand(context.Background(), migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+	if err = migrateSchema(migrator); err != nil {
 		log.Fatalf("schema: %v", err)
 	}
 	log.Println("Playlists DB ready")
 
+	playlists := newPostgresPlaylistRepository(db)
+
+	// Delete playlists of erased users (requested by users-service)
+	go startErasureConsumer(playlists)
+
 	lis, err := net.Listen("tcp", ":"+getenv("PORT", "50055"))
 	if err != nil {
 		log.Fatalf("listen: %v", err)
 	}
 
 	grpcServer := grpc.NewServer()
-	pb.RegisterPlaylistsServiceServer(grpcServer, &srv{})
+	pb.RegisterPlaylistsServiceServer(grpcServer, newServer(playlists))
 
 	log.Printf("PlaylistsService listening on %s", lis.Addr())
 	if err := grpcServer.Serve(lis); err != nil {
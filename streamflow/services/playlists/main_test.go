@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "playlists-service/pb"
+)
+
+const (
+	ownerID    = 10
+	strangerID = 20
+)
+
+func asUser(userID int64) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(mdUserID, strconv.FormatInt(userID, 10)))
+}
+
+func assertCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Fatalf("expected %s, got %s (%v)", want, got, err)
+	}
+}
+
+func createPlaylist(t *testing.T, s *srv, userID int64, name string) *pb.Playlist {
+	t.Helper()
+	resp, err := s.CreatePlaylist(asUser(userID), &pb.CreatePlaylistRequest{Name: name})
+	if err != nil {
+		t.Fatalf("CreatePlaylist: %v", err)
+	}
+	return resp.Playlist
+}
+
+func listVideos(t *testing.T, s *srv, userID, playlistID int64) []int64 {
+	t.Helper()
+	resp, err := s.ListVideos(asUser(userID), &pb.ListVideosRequest{PlaylistId: playlistID})
+	if err != nil {
+		t.Fatalf("ListVideos: %v", err)
+	}
+	ids := make([]int64, 0, len(resp.Videos))
+	for _, v := range resp.Videos {
+		ids = append(ids, v.VideoId)
+	}
+	return ids
+}
+
+func TestCallsRequireAuthenticatedUser(t *testing.T) {
+	s := newServer(newMemoryPlaylistRepository())
+
+	_, err := s.CreatePlaylist(context.Background(), &pb.CreatePlaylistRequest{Name: "Favoritos"})
+	assertCode(t, err, codes.Unauthenticated)
+
+	_, err = s.ListPlaylists(context.Background(), &emptypb.Empty{})
+	assertCode(t, err, codes.Unauthenticated)
+}
+
+func TestCreatePlaylistRequiresName(t *testing.T) {
+	s := newServer(newMemoryPlaylistRepository())
+
+	_, err := s.CreatePlaylist(asUser(ownerID), &pb.CreatePlaylistRequest{})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestOnlyOwnerMayAddVideos(t *testing.T) {
+	s := newServer(newMemoryPlaylistRepository())
+	p := createPlaylist(t, s, ownerID, "Favoritos")
+
+	_, err := s.AddVideo(asUser(strangerID), &pb.AddVideoRequest{PlaylistId: p.Id, VideoId: 1})
+	assertCode(t, err, codes.PermissionDenied)
+
+	resp, err := s.AddVideo(asUser(ownerID), &pb.AddVideoRequest{PlaylistId: p.Id, VideoId: 1})
+	if err != nil {
+		t.Fatalf("AddVideo as owner: %v", err)
+	}
+	if resp.Playlist.Id != p.Id || resp.Playlist.OwnerId != ownerID {
+		t.Fatalf("unexpected playlist in response: %v", resp.Playlist)
+	}
+	if got := listVideos(t, s, ownerID, p.Id); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("expected [1], got %v", got)
+	}
+}
+
+func TestOnlyOwnerMayRemoveVideos(t *testing.T) {
+	s := newServer(newMemoryPlaylistRepository())
+	p := createPlaylist(t, s, ownerID, "Favoritos")
+	if _, err := s.AddVideo(asUser(ownerID), &pb.AddVideoRequest{PlaylistId: p.Id, VideoId: 1}); err != nil {
+		t.Fatalf("AddVideo: %v", err)
+	}
+
+	_, err := s.RemoveVideo(asUser(strangerID), &pb.RemoveVideoRequest{PlaylistId: p.Id, VideoId: 1})
+	assertCode(t, err, codes.PermissionDenied)
+	if got := listVideos(t, s, ownerID, p.Id); len(got) != 1 {
+		t.Fatalf("video should still be there, got %v", got)
+	}
+
+	if _, err := s.RemoveVideo(asUser(ownerID), &pb.RemoveVideoRequest{PlaylistId: p.Id, VideoId: 1}); err != nil {
+		t.Fatalf("RemoveVideo as owner: %v", err)
+	}
+	if got := listVideos(t, s, ownerID, p.Id); len(got) != 0 {
+		t.Fatalf("expected no videos, got %v", got)
+	}
+}
+
+func TestAddingVideoTwiceIsRejected(t *testing.T) {
+	s := newServer(newMemoryPlaylistRepository())
+	p := createPlaylist(t, s, ownerID, "Favoritos")
+	if _, err := s.AddVideo(asUser(ownerID), &pb.AddVideoRequest{PlaylistId: p.Id, VideoId: 1}); err != nil {
+		t.Fatalf("AddVideo: %v", err)
+	}
+
+	_, err := s.AddVideo(asUser(ownerID), &pb.AddVideoRequest{PlaylistId: p.Id, VideoId: 1})
+	assertCode(t, err, codes.AlreadyExists)
+}
+
+func TestOnlyOwnerMayListVideos(t *testing.T) {
+	s := newServer(newMemoryPlaylistRepository())
+	p := createPlaylist(t, s, ownerID, "Favoritos")
+
+	_, err := s.ListVideos(asUser(strangerID), &pb.ListVideosRequest{PlaylistId: p.Id})
+	assertCode(t, err, codes.PermissionDenied)
+
+	_, err = s.ListVideos(asUser(ownerID), &pb.ListVideosRequest{PlaylistId: p.Id + 100})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestOnlyOwnerMayDeletePlaylist(t *testing.T) {
+	s := newServer(newMemoryPlaylistRepository())
+	p := createPlaylist(t, s, ownerID, "Favoritos")
+
+	_, err := s.DeletePlaylist(asUser(strangerID), &pb.DeletePlaylistRequest{PlaylistId: p.Id})
+	assertCode(t, err, codes.PermissionDenied)
+
+	if _, err := s.DeletePlaylist(asUser(ownerID), &pb.DeletePlaylistRequest{PlaylistId: p.Id}); err != nil {
+		t.Fatalf("DeletePlaylist as owner: %v", err)
+	}
+
+	// A deleted playlist is gone for its owner too
+	_, err = s.AddVideo(asUser(ownerID), &pb.AddVideoRequest{PlaylistId: p.Id, VideoId: 1})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestListPlaylistsOnlyReturnsOwnNewestFirst(t *testing.T) {
+	repo := newMemoryPlaylistRepository()
+	s := newServer(repo)
+	first := createPlaylist(t, s, ownerID, "Primera")
+	second := createPlaylist(t, s, ownerID, "Segunda")
+	createPlaylist(t, s, strangerID, "Ajena")
+	deleted := createPlaylist(t, s, ownerID, "Borrada")
+	if _, err := s.DeletePlaylist(asUser(ownerID), &pb.DeletePlaylistRequest{PlaylistId: deleted.Id}); err != nil {
+		t.Fatalf("DeletePlaylist: %v", err)
+	}
+
+	resp, err := s.ListPlaylists(asUser(ownerID), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("ListPlaylists: %v", err)
+	}
+	if len(resp.Playlists) != 2 || resp.Playlists[0].Id != second.Id || resp.Playlists[1].Id != first.Id {
+		t.Fatalf("expected playlists %d and %d, got %v", second.Id, first.Id, resp.Playlists)
+	}
+}
+
+func TestDeleteByOwnerErasesPlaylistsAndVideos(t *testing.T) {
+	repo := newMemoryPlaylistRepository()
+	s := newServer(repo)
+	p := createPlaylist(t, s, ownerID, "Favoritos")
+	for _, vid := range []int64{1, 2} {
+		if _, err := s.AddVideo(asUser(ownerID), &pb.AddVideoRequest{PlaylistId: p.Id, VideoId: vid}); err != nil {
+			t.Fatalf("AddVideo: %v", err)
+		}
+	}
+	createPlaylist(t, s, strangerID, "Ajena")
+
+	deleted, err := repo.DeleteByOwner(context.Background(), ownerID)
+	if err != nil {
+		t.Fatalf("DeleteByOwner: %v", err)
+	}
+	if deleted != 3 {
+		t.Fatalf("expected 3 rows deleted, got %d", deleted)
+	}
+	if _, err := repo.Get(context.Background(), p.Id); err != errPlaylistNotFound {
+		t.Fatalf("expected errPlaylistNotFound, got %v", err)
+	}
+
+	others, err := repo.ListByOwner(context.Background(), strangerID)
+	if err != nil || len(others) != 1 {
+		t.Fatalf("other users' playlists must survive, got %v (%v)", others, err)
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS playlist_videos;
+DROP TABLE IF EXISTS playlists;
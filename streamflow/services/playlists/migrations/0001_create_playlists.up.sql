@@ -0,0 +1,23 @@
+-- IF NOT EXISTS lets databases created before versioned migrations adopt
+-- this version without changes.
+CREATE TABLE IF NOT EXISTS playlists (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+
+CREATE TABLE IF NOT EXISTS playlist_videos (
+    id SERIAL PRIMARY KEY,
+    playlist_id INTEGER NOT NULL,
+    video_id INTEGER NOT NULL,
+    added_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    UNIQUE (playlist_id, video_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_playlists_owner ON playlists (owner_id);
+CREATE INDEX IF NOT EXISTS idx_playlists_deleted ON playlists (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_playlist_videos_playlist ON playlist_videos (playlist_id);
+CREATE INDEX IF NOT EXISTS idx_playlist_videos_deleted ON playlist_videos (deleted_at);
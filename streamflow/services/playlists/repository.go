@@ -0,0 +1,48 @@
+package main
+
+// Storage of playlists. The gRPC handlers keep the rules (authentication,
+// only the owner may change or read a playlist) and reach the data only
+// through PlaylistRepository: postgresPlaylistRepository in production,
+// memoryPlaylistRepository in the unit tests.
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+//======================== REPOSITORY =========================
+
+// errPlaylistNotFound is returned for missing or deleted playlists.
+var errPlaylistNotFound = errors.New("playlist not found")
+
+// errVideoInPlaylist is returned when the video was already added to the
+// playlist, even if it was removed later (UNIQUE (playlist_id, video_id)).
+var errVideoInPlaylist = errors.New("video already added to playlist")
+
+type playlistRow struct {
+	ID        int64
+	OwnerID   int64
+	Name      string
+	CreatedAt time.Time
+}
+
+// PlaylistRepository stores playlists and the videos in them. Deleted
+// playlists and removed videos are soft-deleted and never returned.
+type PlaylistRepository interface {
+	Create(ctx context.Context, ownerID int64, name string) (playlistRow, error)
+	// Get returns errPlaylistNotFound when the playlist does not exist.
+	Get(ctx context.Context, id int64) (playlistRow, error)
+	// ListByOwner returns the owner's playlists, newest first.
+	ListByOwner(ctx context.Context, ownerID int64) ([]playlistRow, error)
+	// Delete deletes the playlist only if it belongs to ownerID; otherwise
+	// it returns errPlaylistNotFound.
+	Delete(ctx context.Context, id, ownerID int64) error
+	AddVideo(ctx context.Context, playlistID, videoID int64) error
+	// RemoveVideo is a no-op if the video is not in the playlist.
+	RemoveVideo(ctx context.Context, playlistID, videoID int64) error
+	ListVideos(ctx context.Context, playlistID int64) ([]int64, error)
+	// DeleteByOwner removes for good every playlist of the owner (deleted
+	// ones included) and their videos, returning how many rows went away.
+	DeleteByOwner(ctx context.Context, ownerID int64) (int64, error)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memoryPlaylistRepository keeps playlists in memory with the same
+// semantics as the Postgres tables (soft deletes, one row per video).
+type memoryPlaylistRepository struct {
+	mu        sync.Mutex
+	nextID    int64
+	playlists map[int64]*memoryPlaylist
+	now       func() time.Time
+}
+
+type memoryPlaylist struct {
+	row     playlistRow
+	deleted bool
+	videos  []memoryPlaylistVideo // in insertion order
+}
+
+type memoryPlaylistVideo struct {
+	videoID int64
+	removed bool
+}
+
+func newMemoryPlaylistRepository() *memoryPlaylistRepository {
+	return &memoryPlaylistRepository{
+		nextID:    1,
+		playlists: make(map[int64]*memoryPlaylist),
+		now:       time.Now,
+	}
+}
+
+func (r *memoryPlaylistRepository) Create(ctx context.Context, ownerID int64, name string) (playlistRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := playlistRow{ID: r.nextID, OwnerID: ownerID, Name: name, CreatedAt: r.now()}
+	r.nextID++
+	r.playlists[p.ID] = &memoryPlaylist{row: p}
+	return p, nil
+}
+
+func (r *memoryPlaylistRepository) Get(ctx context.Context, id int64) (playlistRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.playlists[id]
+	if !ok || p.deleted {
+		return playlistRow{}, errPlaylistNotFound
+	}
+	return p.row, nil
+}
+
+func (r *memoryPlaylistRepository) ListByOwner(ctx context.Context, ownerID int64) ([]playlistRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var playlists []playlistRow
+	for _, p := range r.playlists {
+		if !p.deleted && p.row.OwnerID == ownerID {
+			playlists = append(playlists, p.row)
+		}
+	}
+	sort.Slice(playlists, func(i, j int) bool {
+		if playlists[i].CreatedAt.Equal(playlists[j].CreatedAt) {
+			return playlists[i].ID > playlists[j].ID
+		}
+		return playlists[i].CreatedAt.After(playlists[j].CreatedAt)
+	})
+	return playlists, nil
+}
+
+func (r *memoryPlaylistRepository) Delete(ctx context.Context, id, ownerID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.playlists[id]
+	if !ok || p.deleted || p.row.OwnerID != ownerID {
+		return errPlaylistNotFound
+	}
+	p.deleted = true
+	return nil
+}
+
+func (r *memoryPlaylistRepository) AddVideo(ctx context.Context, playlistID, videoID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.playlists[playlistID]
+	if !ok {
+		return errPlaylistNotFound
+	}
+	for _, v := range p.videos {
+		if v.videoID == videoID {
+			return errVideoInPlaylist
+		}
+	}
+	p.videos = append(p.videos, memoryPlaylistVideo{videoID: videoID})
+	return nil
+}
+
+func (r *memoryPlaylistRepository) RemoveVideo(ctx context.Context, playlistID, videoID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p, ok := r.playlists[playlistID]; ok {
+		for i := range p.videos {
+			if p.videos[i].videoID == videoID {
+				p.videos[i].removed = true
+			}
+		}
+	}
+	return nil
+}
+
+func (r *memoryPlaylistRepository) ListVideos(ctx context.Context, playlistID int64) ([]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var videos []int64
+	if p, ok := r.playlists[playlistID]; ok {
+		for _, v := range p.videos {
+			if !v.removed {
+				videos = append(videos, v.videoID)
+			}
+		}
+	}
+	return videos, nil
+}
+
+func (r *memoryPlaylistRepository) DeleteByOwner(ctx context.Context, ownerID int64) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for id, p := range r.playlists {
+		if p.row.OwnerID == ownerID {
+			deleted += int64(len(p.videos)) + 1
+			delete(r.playlists, id)
+		}
+	}
+	return deleted, nil
+}
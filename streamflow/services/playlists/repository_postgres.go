@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// postgresPlaylistRepository stores playlists in the playlists and
+// playlist_videos tables.
+type postgresPlaylistRepository struct {
+	db *sql.DB
+}
+
+func newPostgresPlaylistRepository(db *sql.DB) *postgresPlaylistRepository {
+	return &postgresPlaylistRepository{db: db}
+}
+
+func (r *postgresPlaylistRepository) Create(ctx context.Context, ownerID int64, name string) (playlistRow, error) {
+	p := playlistRow{OwnerID: ownerID, Name: name}
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO playlists (owner_id, name) VALUES ($1, $2) RETURNING id, created_at`,
+		ownerID, name).Scan(&p.ID, &p.CreatedAt)
+	return p, err
+}
+
+func (r *postgresPlaylistRepository) Get(ctx context.Context, id int64) (playlistRow, error) {
+	p := playlistRow{ID: id}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT owner_id, name, created_at FROM playlists WHERE id = $1 AND deleted_at IS NULL`,
+		id).Scan(&p.OwnerID, &p.Name, &p.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return playlistRow{}, errPlaylistNotFound
+	}
+	return p, err
+}
+
+func (r *postgresPlaylistRepository) ListByOwner(ctx context.Context, ownerID int64) ([]playlistRow, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, name, created_at FROM playlists WHERE owner_id = $1 AND deleted_at IS NULL ORDER BY created_at DESC`,
+		ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var playlists []playlistRow
+	for rows.Next() {
+		p := playlistRow{OwnerID: ownerID}
+		if err := rows.Scan(&p.ID, &p.Name, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		playlists = append(playlists, p)
+	}
+	return playlists, rows.Err()
+}
+
+func (r *postgresPlaylistRepository) Delete(ctx context.Context, id, ownerID int64) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE playlists SET deleted_at = NOW() WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL`,
+		id, ownerID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errPlaylistNotFound
+	}
+	return nil
+}
+
+func (r *postgresPlaylistRepository) AddVideo(ctx context.Context, playlistID, videoID int64) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO playlist_videos (playlist_id, video_id) VALUES ($1, $2)`,
+		playlistID, videoID)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+		return errVideoInPlaylist
+	}
+	return err
+}
+
+func (r *postgresPlaylistRepository) RemoveVideo(ctx context.Context, playlistID, videoID int64) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE playlist_videos SET deleted_at = NOW() WHERE playlist_id = $1 AND video_id = $2 AND deleted_at IS NULL`,
+		playlistID, videoID)
+	return err
+}
+
+func (r *postgresPlaylistRepository) ListVideos(ctx context.Context, playlistID int64) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT video_id FROM playlist_videos WHERE playlist_id = $1 AND deleted_at IS NULL`,
+		playlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var videos []int64
+	for rows.Next() {
+		var vid int64
+		if err := rows.Scan(&vid); err != nil {
+			return nil, err
+		}
+		videos = append(videos, vid)
+	}
+	return videos, rows.Err()
+}
+
+func (r *postgresPlaylistRepository) DeleteByOwner(ctx context.Context, ownerID int64) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	videos, err := tx.ExecContext(ctx, `DELETE FROM playlist_videos WHERE playlist_id IN (SELECT id FROM playlists WHERE owner_id = $1)`, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	playlists, err := tx.ExecContext(ctx, `DELETE FROM playlists WHERE owner_id = $1`, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	nVideos, _ := videos.RowsAffected()
+	nPlaylists, _ := playlists.RowsAffected()
+	return nVideos + nPlaylists, nil
+}
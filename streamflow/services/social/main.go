@@ -4,7 +4,6 @@ import (
     "context"
     "log"
     "net"
-    "sync"
      
     "github.com/google/uuid"
     "google.golang.org/grpc"
@@ -20,30 +19,17 @@ import (
 )
 
 
-// ================================
-//  In-memory store (thread-safe)
-// ================================
-
-type store struct {
-    mu       sync.RWMutex
-    likes    map[string][]*pb.Like    // key: video_id
-    comments map[string][]*pb.Comment // key: video_id
-}
-
-func newStore() *store {
-    return &store{
-        likes:    make(map[string][]*pb.Like),
-        comments: make(map[string][]*pb.Comment),
-    }
-}
-
 // ================================
 //  gRPC server implementation
 // ================================
 
 type server struct {
     pb.UnimplementedSocialInteractionsServer
-    db *store
+    interactions InteractionRepository
+}
+
+func newServer(interactions InteractionRepository) *server {
+    return &server{interactions: interactions}
 }
 
 func (s *server) LikeVideo(ctx context.Context, req *pb.LikeVideoRequest) (*pb.LikeVideoResponse, error) {
@@ -56,11 +42,12 @@ func (s *server) LikeVideo(ctx context.Context, req *pb.LikeVideoRequest) (*pb.L
         LikeId:   uuid.New().String(),
         UserId:   userID,
         CreatedAt: timestamppb.Now(),
+        VideoId:  req.GetVideoId(),
     }
 
-    s.db.mu.Lock()
-    s.db.likes[req.GetVideoId()] = append(s.db.likes[req.GetVideoId()], like)
-    s.db.mu.Unlock()
+    if err := s.interactions.AddLike(ctx, like); err != nil {
+        return nil, status.Errorf(codes.Internal, "store like: %v", err)
+    }
 
     return &pb.LikeVideoResponse{Like: like}, nil
 }
@@ -76,11 +63,12 @@ func (s *server) CommentVideo(ctx context.Context, req *pb.CommentVideoRequest)
         UserId:    userID,
         Content:   req.GetContent(),
         CreatedAt: timestamppb.Now(),
+        VideoId:   req.GetVideoId(),
     }
 
-    s.db.mu.Lock()
-    s.db.comments[req.GetVideoId()] = append(s.db.comments[req.GetVideoId()], comment)
-    s.db.mu.Unlock()
+    if err := s.interactions.AddComment(ctx, comment); err != nil {
+        return nil, status.Errorf(codes.Internal, "store comment: %v", err)
+    }
 
     return &pb.CommentVideoResponse{Comment: comment}, nil
 }
@@ -91,19 +79,31 @@ func (s *server) GetVideoInteractions(ctx context.Context, req *pb.GetVideoInter
         return nil, status.Error(codes.Unauthenticated, "user not authenticated")
     }
 
-    s.db.mu.RLock()
-    likes := s.db.likes[req.GetVideoId()]
-    comments := s.db.comments[req.GetVideoId()]
-    s.db.mu.RUnlock()
+    likes, comments, err := s.interactions.ListByVideo(ctx, req.GetVideoId())
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "list interactions: %v", err)
+    }
+
+    return &pb.GetVideoInteractionsResponse{
+        Likes:    likes,
+        Comments: comments,
+    }, nil
+}
 
-    if likes == nil {
-        likes = []*pb.Like{}
+// ListUserInteractions returns every like and comment made by a user, across
+// all videos (used by users-service to export the user's data)
+func (s *server) ListUserInteractions(ctx context.Context, req *pb.ListUserInteractionsRequest) (*pb.ListUserInteractionsResponse, error) {
+    userID := req.GetUserId()
+    if userID == "" {
+        return nil, status.Error(codes.InvalidArgument, "user_id is required")
     }
-    if comments == nil {
-        comments = []*pb.Comment{}
+
+    likes, comments, err := s.interactions.ListByUser(ctx, userID)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "list interactions: %v", err)
     }
 
-    return &pb.GetVideoInteractionsResponse{
+    return &pb.ListUserInteractionsResponse{
         Likes:    likes,
         Comments: comments,
     }, nil
@@ -155,8 +155,11 @@ func main() {
         grpc.UnaryInterceptor(authUnaryInterceptor),
     )
 
-    srv := &server{db: newStore()}
-    pb.RegisterSocialInteractionsServer(grpcServer, srv)
+    interactions := newMemoryInteractionRepository()
+    pb.RegisterSocialInteractionsServer(grpcServer, newServer(interactions))
+
+    // Erase likes and comments of purged users (requested by users-service)
+    go startErasureConsumer(interactions)
 
     log.Println("gRPC server listening on :50051")
     if err := grpcServer.Serve(lis); err != nil {
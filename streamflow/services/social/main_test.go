@@ -0,0 +1,155 @@
+package main
+
+import (
+    "context"
+    "testing"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+
+    pb "social-service/pb"
+)
+
+func assertCode(t *testing.T, err error, want codes.Code) {
+    t.Helper()
+    if got := status.Code(err); got != want {
+        t.Fatalf("expected %s, got %s (%v)", want, got, err)
+    }
+}
+
+func like(t *testing.T, s *server, userID, videoID string) {
+    t.Helper()
+    if _, err := s.LikeVideo(context.Background(), &pb.LikeVideoRequest{UserId: userID, VideoId: videoID}); err != nil {
+        t.Fatalf("LikeVideo: %v", err)
+    }
+}
+
+func comment(t *testing.T, s *server, userID, videoID, content string) {
+    t.Helper()
+    _, err := s.CommentVideo(context.Background(), &pb.CommentVideoRequest{UserId: userID, VideoId: videoID, Content: content})
+    if err != nil {
+        t.Fatalf("CommentVideo: %v", err)
+    }
+}
+
+func TestInteractionsRequireUser(t *testing.T) {
+    s := newServer(newMemoryInteractionRepository())
+    ctx := context.Background()
+
+    _, err := s.LikeVideo(ctx, &pb.LikeVideoRequest{VideoId: "v1"})
+    assertCode(t, err, codes.Unauthenticated)
+
+    _, err = s.CommentVideo(ctx, &pb.CommentVideoRequest{VideoId: "v1", Content: "hola"})
+    assertCode(t, err, codes.Unauthenticated)
+
+    _, err = s.GetVideoInteractions(ctx, &pb.GetVideoInteractionsRequest{VideoId: "v1"})
+    assertCode(t, err, codes.Unauthenticated)
+
+    _, err = s.ListUserInteractions(ctx, &pb.ListUserInteractionsRequest{})
+    assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestInterceptorRequiresAuthorizationMetadata(t *testing.T) {
+    called := false
+    handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+        called = true
+        return nil, nil
+    }
+    info := &grpc.UnaryServerInfo{FullMethod: "/social.SocialInteractions/LikeVideo"}
+
+    _, err := authUnaryInterceptor(context.Background(), nil, info, handler)
+    assertCode(t, err, codes.Unauthenticated)
+
+    ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs())
+    _, err = authUnaryInterceptor(ctx, nil, info, handler)
+    assertCode(t, err, codes.Unauthenticated)
+    if called {
+        t.Fatal("handler must not run without a token")
+    }
+
+    ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+    if _, err := authUnaryInterceptor(ctx, nil, info, handler); err != nil || !called {
+        t.Fatalf("handler should run with a token (err %v)", err)
+    }
+}
+
+func TestGetVideoInteractionsOnlyReturnsThatVideo(t *testing.T) {
+    s := newServer(newMemoryInteractionRepository())
+    like(t, s, "1", "v1")
+    like(t, s, "2", "v1")
+    comment(t, s, "1", "v1", "buenísimo")
+    like(t, s, "1", "v2")
+
+    resp, err := s.GetVideoInteractions(context.Background(), &pb.GetVideoInteractionsRequest{UserId: "1", VideoId: "v1"})
+    if err != nil {
+        t.Fatalf("GetVideoInteractions: %v", err)
+    }
+    if len(resp.Likes) != 2 || len(resp.Comments) != 1 {
+        t.Fatalf("expected 2 likes and 1 comment, got %d and %d", len(resp.Likes), len(resp.Comments))
+    }
+    if resp.Comments[0].Content != "buenísimo" || resp.Comments[0].CommentId == "" {
+        t.Fatalf("unexpected comment: %v", resp.Comments[0])
+    }
+
+    // A video without interactions returns empty lists, not nil
+    resp, err = s.GetVideoInteractions(context.Background(), &pb.GetVideoInteractionsRequest{UserId: "1", VideoId: "v3"})
+    if err != nil {
+        t.Fatalf("GetVideoInteractions: %v", err)
+    }
+    if resp.Likes == nil || resp.Comments == nil {
+        t.Fatal("expected empty lists for a video without interactions")
+    }
+}
+
+func TestListUserInteractionsSpansVideos(t *testing.T) {
+    s := newServer(newMemoryInteractionRepository())
+    like(t, s, "1", "v1")
+    like(t, s, "1", "v2")
+    comment(t, s, "1", "v2", "genial")
+    like(t, s, "2", "v1")
+
+    resp, err := s.ListUserInteractions(context.Background(), &pb.ListUserInteractionsRequest{UserId: "1"})
+    if err != nil {
+        t.Fatalf("ListUserInteractions: %v", err)
+    }
+    if len(resp.Likes) != 2 || len(resp.Comments) != 1 {
+        t.Fatalf("expected 2 likes and 1 comment, got %d and %d", len(resp.Likes), len(resp.Comments))
+    }
+    for _, l := range resp.Likes {
+        if l.UserId != "1" {
+            t.Fatalf("like of another user returned: %v", l)
+        }
+    }
+}
+
+func TestEraseUserOnlyRemovesThatUser(t *testing.T) {
+    repo := newMemoryInteractionRepository()
+    s := newServer(repo)
+    like(t, s, "1", "v1")
+    comment(t, s, "1", "v1", "hola")
+    like(t, s, "2", "v1")
+    comment(t, s, "2", "v1", "chao")
+
+    removed, err := repo.EraseUser(context.Background(), "1")
+    if err != nil {
+        t.Fatalf("EraseUser: %v", err)
+    }
+    if removed != 2 {
+        t.Fatalf("expected 2 interactions removed, got %d", removed)
+    }
+
+    resp, err := s.GetVideoInteractions(context.Background(), &pb.GetVideoInteractionsRequest{UserId: "2", VideoId: "v1"})
+    if err != nil {
+        t.Fatalf("GetVideoInteractions: %v", err)
+    }
+    if len(resp.Likes) != 1 || resp.Likes[0].UserId != "2" || len(resp.Comments) != 1 || resp.Comments[0].UserId != "2" {
+        t.Fatalf("only user 2's interactions should remain, got %v / %v", resp.Likes, resp.Comments)
+    }
+
+    // Erasing twice is harmless
+    if removed, _ := repo.EraseUser(context.Background(), "1"); removed != 0 {
+        t.Fatalf("second erasure removed %d", removed)
+    }
+}
@@ -1,8 +1,8 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
-// 	protoc        v4.24.4
-// source: social.proto
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: protos/social.proto
 
 package pb
 
@@ -12,6 +12,7 @@ import (
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -22,22 +23,20 @@ const (
 )
 
 type Like struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LikeId        string                 `protobuf:"bytes,1,opt,name=like_id,json=likeId,proto3" json:"like_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	VideoId       string                 `protobuf:"bytes,4,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	LikeId    string                 `protobuf:"bytes,1,opt,name=like_id,json=likeId,proto3" json:"like_id,omitempty"`
-	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Like) Reset() {
 	*x = Like{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_social_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_protos_social_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Like) String() string {
@@ -47,8 +46,8 @@ func (x *Like) String() string {
 func (*Like) ProtoMessage() {}
 
 func (x *Like) ProtoReflect() protoreflect.Message {
-	mi := &file_social_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_protos_social_proto_msgTypes[0]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -60,7 +59,7 @@ func (x *Like) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Like.ProtoReflect.Descriptor instead.
 func (*Like) Descriptor() ([]byte, []int) {
-	return file_social_proto_rawDescGZIP(), []int{0}
+	return file_protos_social_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *Like) GetLikeId() string {
@@ -84,24 +83,29 @@ func (x *Like) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Like) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
 type Comment struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommentId     string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	VideoId       string                 `protobuf:"bytes,5,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	CommentId string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
-	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Content   string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Comment) Reset() {
 	*x = Comment{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_social_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_protos_social_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Comment) String() string {
@@ -111,8 +115,8 @@ func (x *Comment) String() string {
 func (*Comment) ProtoMessage() {}
 
 func (x *Comment) ProtoReflect() protoreflect.Message {
-	mi := &file_social_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_protos_social_proto_msgTypes[1]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -124,7 +128,7 @@ func (x *Comment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Comment.ProtoReflect.Descriptor instead.
 func (*Comment) Descriptor() ([]byte, []int) {
-	return file_social_proto_rawDescGZIP(), []int{1}
+	return file_protos_social_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Comment) GetCommentId() string {
@@ -155,22 +159,26 @@ func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Comment) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
 type LikeVideoRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VideoId       string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	UserId  string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	VideoId string `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LikeVideoRequest) Reset() {
 	*x = LikeVideoRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_social_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_protos_social_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *LikeVideoRequest) String() string {
@@ -180,8 +188,8 @@ func (x *LikeVideoRequest) String() string {
 func (*LikeVideoRequest) ProtoMessage() {}
 
 func (x *LikeVideoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_social_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_protos_social_proto_msgTypes[2]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -193,7 +201,7 @@ func (x *LikeVideoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LikeVideoRequest.ProtoReflect.Descriptor instead.
 func (*LikeVideoRequest) Descriptor() ([]byte, []int) {
-	return file_social_proto_rawDescGZIP(), []int{2}
+	return file_protos_social_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *LikeVideoRequest) GetUserId() string {
@@ -211,20 +219,17 @@ func (x *LikeVideoRequest) GetVideoId() string {
 }
 
 type LikeVideoResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Like          *Like                  `protobuf:"bytes,1,opt,name=like,proto3" json:"like,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Like *Like `protobuf:"bytes,1,opt,name=like,proto3" json:"like,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LikeVideoResponse) Reset() {
 	*x = LikeVideoResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_social_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_protos_social_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *LikeVideoResponse) String() string {
@@ -234,8 +239,8 @@ func (x *LikeVideoResponse) String() string {
 func (*LikeVideoResponse) ProtoMessage() {}
 
 func (x *LikeVideoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_social_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_protos_social_proto_msgTypes[3]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -247,7 +252,7 @@ func (x *LikeVideoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LikeVideoResponse.ProtoReflect.Descriptor instead.
 func (*LikeVideoResponse) Descriptor() ([]byte, []int) {
-	return file_social_proto_rawDescGZIP(), []int{3}
+	return file_protos_social_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *LikeVideoResponse) GetLike() *Like {
@@ -258,22 +263,19 @@ func (x *LikeVideoResponse) GetLike() *Like {
 }
 
 type CommentVideoRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VideoId       string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	UserId  string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	VideoId string `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
-	Content string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CommentVideoRequest) Reset() {
 	*x = CommentVideoRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_social_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_protos_social_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *CommentVideoRequest) String() string {
@@ -283,8 +285,8 @@ func (x *CommentVideoRequest) String() string {
 func (*CommentVideoRequest) ProtoMessage() {}
 
 func (x *CommentVideoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_social_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_protos_social_proto_msgTypes[4]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -296,7 +298,7 @@ func (x *CommentVideoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommentVideoRequest.ProtoReflect.Descriptor instead.
 func (*CommentVideoRequest) Descriptor() ([]byte, []int) {
-	return file_social_proto_rawDescGZIP(), []int{4}
+	return file_protos_social_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CommentVideoRequest) GetUserId() string {
@@ -321,20 +323,17 @@ func (x *CommentVideoRequest) GetContent() string {
 }
 
 type CommentVideoResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Comment *Comment `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CommentVideoResponse) Reset() {
 	*x = CommentVideoResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_social_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_protos_social_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *CommentVideoResponse) String() string {
@@ -344,8 +343,8 @@ func (x *CommentVideoResponse) String() string {
 func (*CommentVideoResponse) ProtoMessage() {}
 
 func (x *CommentVideoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_social_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_protos_social_proto_msgTypes[5]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -357,7 +356,7 @@ func (x *CommentVideoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommentVideoResponse.ProtoReflect.Descriptor instead.
 func (*CommentVideoResponse) Descriptor() ([]byte, []int) {
-	return file_social_proto_rawDescGZIP(), []int{5}
+	return file_protos_social_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CommentVideoResponse) GetComment() *Comment {
@@ -368,21 +367,18 @@ func (x *CommentVideoResponse) GetComment() *Comment {
 }
 
 type GetVideoInteractionsRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VideoId       string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	UserId  string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	VideoId string `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetVideoInteractionsRequest) Reset() {
 	*x = GetVideoInteractionsRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_social_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_protos_social_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GetVideoInteractionsRequest) String() string {
@@ -392,8 +388,8 @@ func (x *GetVideoInteractionsRequest) String() string {
 func (*GetVideoInteractionsRequest) ProtoMessage() {}
 
 func (x *GetVideoInteractionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_social_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_protos_social_proto_msgTypes[6]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -405,7 +401,7 @@ func (x *GetVideoInteractionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVideoInteractionsRequest.ProtoReflect.Descriptor instead.
 func (*GetVideoInteractionsRequest) Descriptor() ([]byte, []int) {
-	return file_social_proto_rawDescGZIP(), []int{6}
+	return file_protos_social_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetVideoInteractionsRequest) GetUserId() string {
@@ -423,21 +419,18 @@ func (x *GetVideoInteractionsRequest) GetVideoId() string {
 }
 
 type GetVideoInteractionsResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Likes         []*Like                `protobuf:"bytes,1,rep,name=likes,proto3" json:"likes,omitempty"`
+	Comments      []*Comment             `protobuf:"bytes,2,rep,name=comments,proto3" json:"comments,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Likes    []*Like    `protobuf:"bytes,1,rep,name=likes,proto3" json:"likes,omitempty"`
-	Comments []*Comment `protobuf:"bytes,2,rep,name=comments,proto3" json:"comments,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetVideoInteractionsResponse) Reset() {
 	*x = GetVideoInteractionsResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_social_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_protos_social_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GetVideoInteractionsResponse) String() string {
@@ -447,8 +440,8 @@ func (x *GetVideoInteractionsResponse) String() string {
 func (*GetVideoInteractionsResponse) ProtoMessage() {}
 
 func (x *GetVideoInteractionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_social_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_protos_social_proto_msgTypes[7]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -460,7 +453,7 @@ func (x *GetVideoInteractionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVideoInteractionsResponse.ProtoReflect.Descriptor instead.
 func (*GetVideoInteractionsResponse) Descriptor() ([]byte, []int) {
-	return file_social_proto_rawDescGZIP(), []int{7}
+	return file_protos_social_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetVideoInteractionsResponse) GetLikes() []*Like {
@@ -477,100 +470,164 @@ func (x *GetVideoInteractionsResponse) GetComments() []*Comment {
 	return nil
 }
 
-var File_social_proto protoreflect.FileDescriptor
-
-var file_social_proto_rawDesc = []byte{
-	0x0a, 0x0c, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d,
-	0x53, 0x6f, 0x63, 0x69, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x1f, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x73,
-	0x0a, 0x04, 0x4c, 0x69, 0x6b, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x69, 0x6b, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x69, 0x6b, 0x65, 0x49, 0x64, 0x12,
-	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x64, 0x41, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12,
-	0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x17,
-	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x46, 0x0a, 0x10,
-	0x4c, 0x69, 0x6b, 0x65, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x76, 0x69, 0x64,
-	0x65, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x69, 0x64,
-	0x65, 0x6f, 0x49, 0x64, 0x22, 0x3c, 0x0a, 0x11, 0x4c, 0x69, 0x6b, 0x65, 0x56, 0x69, 0x64, 0x65,
-	0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x6c, 0x69, 0x6b,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x53, 0x6f, 0x63, 0x69, 0x61, 0x6c,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x04, 0x6c, 0x69,
-	0x6b, 0x65, 0x22, 0x63, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x56, 0x69, 0x64,
-	0x65, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
-	0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x49, 0x64, 0x12, 0x18, 0x0a,
-	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x48, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x30, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x53, 0x6f, 0x63, 0x69, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
-	0x74, 0x22, 0x51, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x49, 0x6e, 0x74,
-	0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x76, 0x69, 0x64,
-	0x65, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x69, 0x64,
-	0x65, 0x6f, 0x49, 0x64, 0x22, 0x7d, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x56, 0x69, 0x64, 0x65, 0x6f,
-	0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x53, 0x6f, 0x63, 0x69, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x12,
-	0x32, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x16, 0x2e, 0x53, 0x6f, 0x63, 0x69, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x32, 0xae, 0x02, 0x0a, 0x12, 0x53, 0x6f, 0x63, 0x69, 0x61, 0x6c, 0x49, 0x6e,
-	0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x4e, 0x0a, 0x09, 0x4c, 0x69,
-	0x6b, 0x65, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x12, 0x1f, 0x2e, 0x53, 0x6f, 0x63, 0x69, 0x61, 0x6c,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x56, 0x69, 0x64, 0x65,
-	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x53, 0x6f, 0x63, 0x69, 0x61,
-	0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x56, 0x69, 0x64,
-	0x65, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x43, 0x6f,
-	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x12, 0x22, 0x2e, 0x53, 0x6f, 0x63,
-	0x69, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23,
-	0x2e, 0x53, 0x6f, 0x63, 0x69, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43,
-	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x6f, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x49,
-	0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2a, 0x2e, 0x53, 0x6f,
-	0x63, 0x69, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x56,
-	0x69, 0x64, 0x65, 0x6f, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x53, 0x6f, 0x63, 0x69, 0x61, 0x6c,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x69, 0x64, 0x65, 0x6f,
-	0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x22, 0x5a, 0x20, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x66, 0x6c,
-	0x6f, 0x77, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x73, 0x6f, 0x63, 0x69,
-	0x61, 0x6c, 0x2f, 0x70, 0x62, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+// Todas las interacciones de un usuario, para la exportación de sus datos
+type ListUserInteractionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserInteractionsRequest) Reset() {
+	*x = ListUserInteractionsRequest{}
+	mi := &file_protos_social_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserInteractionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserInteractionsRequest) ProtoMessage() {}
+
+func (x *ListUserInteractionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_social_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserInteractionsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserInteractionsRequest) Descriptor() ([]byte, []int) {
+	return file_protos_social_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListUserInteractionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListUserInteractionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Likes         []*Like                `protobuf:"bytes,1,rep,name=likes,proto3" json:"likes,omitempty"`
+	Comments      []*Comment             `protobuf:"bytes,2,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserInteractionsResponse) Reset() {
+	*x = ListUserInteractionsResponse{}
+	mi := &file_protos_social_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserInteractionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserInteractionsResponse) ProtoMessage() {}
+
+func (x *ListUserInteractionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_social_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserInteractionsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserInteractionsResponse) Descriptor() ([]byte, []int) {
+	return file_protos_social_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListUserInteractionsResponse) GetLikes() []*Like {
+	if x != nil {
+		return x.Likes
+	}
+	return nil
+}
+
+func (x *ListUserInteractionsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
 }
 
+var File_protos_social_proto protoreflect.FileDescriptor
+
+const file_protos_social_proto_rawDesc = "" +
+	"\n" +
+	"\x13protos/social.proto\x12\rSocialService\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8e\x01\n" +
+	"\x04Like\x12\x17\n" +
+	"\alike_id\x18\x01 \x01(\tR\x06likeId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x19\n" +
+	"\bvideo_id\x18\x04 \x01(\tR\avideoId\"\xb1\x01\n" +
+	"\aComment\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x19\n" +
+	"\bvideo_id\x18\x05 \x01(\tR\avideoId\"F\n" +
+	"\x10LikeVideoRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bvideo_id\x18\x02 \x01(\tR\avideoId\"<\n" +
+	"\x11LikeVideoResponse\x12'\n" +
+	"\x04like\x18\x01 \x01(\v2\x13.SocialService.LikeR\x04like\"c\n" +
+	"\x13CommentVideoRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bvideo_id\x18\x02 \x01(\tR\avideoId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\"H\n" +
+	"\x14CommentVideoResponse\x120\n" +
+	"\acomment\x18\x01 \x01(\v2\x16.SocialService.CommentR\acomment\"Q\n" +
+	"\x1bGetVideoInteractionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bvideo_id\x18\x02 \x01(\tR\avideoId\"}\n" +
+	"\x1cGetVideoInteractionsResponse\x12)\n" +
+	"\x05likes\x18\x01 \x03(\v2\x13.SocialService.LikeR\x05likes\x122\n" +
+	"\bcomments\x18\x02 \x03(\v2\x16.SocialService.CommentR\bcomments\"6\n" +
+	"\x1bListUserInteractionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"}\n" +
+	"\x1cListUserInteractionsResponse\x12)\n" +
+	"\x05likes\x18\x01 \x03(\v2\x13.SocialService.LikeR\x05likes\x122\n" +
+	"\bcomments\x18\x02 \x03(\v2\x16.SocialService.CommentR\bcomments2\x9f\x03\n" +
+	"\x12SocialInteractions\x12N\n" +
+	"\tLikeVideo\x12\x1f.SocialService.LikeVideoRequest\x1a .SocialService.LikeVideoResponse\x12W\n" +
+	"\fCommentVideo\x12\".SocialService.CommentVideoRequest\x1a#.SocialService.CommentVideoResponse\x12o\n" +
+	"\x14GetVideoInteractions\x12*.SocialService.GetVideoInteractionsRequest\x1a+.SocialService.GetVideoInteractionsResponse\x12o\n" +
+	"\x14ListUserInteractions\x12*.SocialService.ListUserInteractionsRequest\x1a+.SocialService.ListUserInteractionsResponseB3Z1Desktop/streamflow/streamflow/services/social/pb/b\x06proto3"
+
 var (
-	file_social_proto_rawDescOnce sync.Once
-	file_social_proto_rawDescData = file_social_proto_rawDesc
+	file_protos_social_proto_rawDescOnce sync.Once
+	file_protos_social_proto_rawDescData []byte
 )
 
-func file_social_proto_rawDescGZIP() []byte {
-	file_social_proto_rawDescOnce.Do(func() {
-		file_social_proto_rawDescData = protoimpl.X.CompressGZIP(file_social_proto_rawDescData)
+func file_protos_social_proto_rawDescGZIP() []byte {
+	file_protos_social_proto_rawDescOnce.Do(func() {
+		file_protos_social_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_protos_social_proto_rawDesc), len(file_protos_social_proto_rawDesc)))
 	})
-	return file_social_proto_rawDescData
+	return file_protos_social_proto_rawDescData
 }
 
-var file_social_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_social_proto_goTypes = []interface{}{
+var file_protos_social_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_protos_social_proto_goTypes = []any{
 	(*Like)(nil),                         // 0: SocialService.Like
 	(*Comment)(nil),                      // 1: SocialService.Comment
 	(*LikeVideoRequest)(nil),             // 2: SocialService.LikeVideoRequest
@@ -579,147 +636,54 @@ var file_social_proto_goTypes = []interface{}{
 	(*CommentVideoResponse)(nil),         // 5: SocialService.CommentVideoResponse
 	(*GetVideoInteractionsRequest)(nil),  // 6: SocialService.GetVideoInteractionsRequest
 	(*GetVideoInteractionsResponse)(nil), // 7: SocialService.GetVideoInteractionsResponse
-	(*timestamppb.Timestamp)(nil),        // 8: google.protobuf.Timestamp
-}
-var file_social_proto_depIdxs = []int32{
-	8, // 0: SocialService.Like.created_at:type_name -> google.protobuf.Timestamp
-	8, // 1: SocialService.Comment.created_at:type_name -> google.protobuf.Timestamp
-	0, // 2: SocialService.LikeVideoResponse.like:type_name -> SocialService.Like
-	1, // 3: SocialService.CommentVideoResponse.comment:type_name -> SocialService.Comment
-	0, // 4: SocialService.GetVideoInteractionsResponse.likes:type_name -> SocialService.Like
-	1, // 5: SocialService.GetVideoInteractionsResponse.comments:type_name -> SocialService.Comment
-	2, // 6: SocialService.SocialInteractions.LikeVideo:input_type -> SocialService.LikeVideoRequest
-	4, // 7: SocialService.SocialInteractions.CommentVideo:input_type -> SocialService.CommentVideoRequest
-	6, // 8: SocialService.SocialInteractions.GetVideoInteractions:input_type -> SocialService.GetVideoInteractionsRequest
-	3, // 9: SocialService.SocialInteractions.LikeVideo:output_type -> SocialService.LikeVideoResponse
-	5, // 10: SocialService.SocialInteractions.CommentVideo:output_type -> SocialService.CommentVideoResponse
-	7, // 11: SocialService.SocialInteractions.GetVideoInteractions:output_type -> SocialService.GetVideoInteractionsResponse
-	9, // [9:12] is the sub-list for method output_type
-	6, // [6:9] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
-}
-
-func init() { file_social_proto_init() }
-func file_social_proto_init() {
-	if File_social_proto != nil {
+	(*ListUserInteractionsRequest)(nil),  // 8: SocialService.ListUserInteractionsRequest
+	(*ListUserInteractionsResponse)(nil), // 9: SocialService.ListUserInteractionsResponse
+	(*timestamppb.Timestamp)(nil),        // 10: google.protobuf.Timestamp
+}
+var file_protos_social_proto_depIdxs = []int32{
+	10, // 0: SocialService.Like.created_at:type_name -> google.protobuf.Timestamp
+	10, // 1: SocialService.Comment.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: SocialService.LikeVideoResponse.like:type_name -> SocialService.Like
+	1,  // 3: SocialService.CommentVideoResponse.comment:type_name -> SocialService.Comment
+	0,  // 4: SocialService.GetVideoInteractionsResponse.likes:type_name -> SocialService.Like
+	1,  // 5: SocialService.GetVideoInteractionsResponse.comments:type_name -> SocialService.Comment
+	0,  // 6: SocialService.ListUserInteractionsResponse.likes:type_name -> SocialService.Like
+	1,  // 7: SocialService.ListUserInteractionsResponse.comments:type_name -> SocialService.Comment
+	2,  // 8: SocialService.SocialInteractions.LikeVideo:input_type -> SocialService.LikeVideoRequest
+	4,  // 9: SocialService.SocialInteractions.CommentVideo:input_type -> SocialService.CommentVideoRequest
+	6,  // 10: SocialService.SocialInteractions.GetVideoInteractions:input_type -> SocialService.GetVideoInteractionsRequest
+	8,  // 11: SocialService.SocialInteractions.ListUserInteractions:input_type -> SocialService.ListUserInteractionsRequest
+	3,  // 12: SocialService.SocialInteractions.LikeVideo:output_type -> SocialService.LikeVideoResponse
+	5,  // 13: SocialService.SocialInteractions.CommentVideo:output_type -> SocialService.CommentVideoResponse
+	7,  // 14: SocialService.SocialInteractions.GetVideoInteractions:output_type -> SocialService.GetVideoInteractionsResponse
+	9,  // 15: SocialService.SocialInteractions.ListUserInteractions:output_type -> SocialService.ListUserInteractionsResponse
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_protos_social_proto_init() }
+func file_protos_social_proto_init() {
+	if File_protos_social_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_social_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Like); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_social_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Comment); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_social_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LikeVideoRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_social_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LikeVideoResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_social_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommentVideoRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_social_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommentVideoResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_social_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetVideoInteractionsRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_social_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetVideoInteractionsResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_social_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protos_social_proto_rawDesc), len(file_protos_social_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
-		GoTypes:           file_social_proto_goTypes,
-		DependencyIndexes: file_social_proto_depIdxs,
-		MessageInfos:      file_social_proto_msgTypes,
+		GoTypes:           file_protos_social_proto_goTypes,
+		DependencyIndexes: file_protos_social_proto_depIdxs,
+		MessageInfos:      file_protos_social_proto_msgTypes,
 	}.Build()
-	File_social_proto = out.File
-	file_social_proto_rawDesc = nil
-	file_social_proto_goTypes = nil
-	file_social_proto_depIdxs = nil
+	File_protos_social_proto = out.File
+	file_protos_social_proto_goTypes = nil
+	file_protos_social_proto_depIdxs = nil
 }
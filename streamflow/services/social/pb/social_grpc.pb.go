@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: protos/social.proto
 
 package pb
 
@@ -11,7 +15,15 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion7
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SocialInteractions_LikeVideo_FullMethodName            = "/SocialService.SocialInteractions/LikeVideo"
+	SocialInteractions_CommentVideo_FullMethodName         = "/SocialService.SocialInteractions/CommentVideo"
+	SocialInteractions_GetVideoInteractions_FullMethodName = "/SocialService.SocialInteractions/GetVideoInteractions"
+	SocialInteractions_ListUserInteractions_FullMethodName = "/SocialService.SocialInteractions/ListUserInteractions"
+)
 
 // SocialInteractionsClient is the client API for SocialInteractions service.
 //
@@ -20,6 +32,7 @@ type SocialInteractionsClient interface {
 	LikeVideo(ctx context.Context, in *LikeVideoRequest, opts ...grpc.CallOption) (*LikeVideoResponse, error)
 	CommentVideo(ctx context.Context, in *CommentVideoRequest, opts ...grpc.CallOption) (*CommentVideoResponse, error)
 	GetVideoInteractions(ctx context.Context, in *GetVideoInteractionsRequest, opts ...grpc.CallOption) (*GetVideoInteractionsResponse, error)
+	ListUserInteractions(ctx context.Context, in *ListUserInteractionsRequest, opts ...grpc.CallOption) (*ListUserInteractionsResponse, error)
 }
 
 type socialInteractionsClient struct {
@@ -31,8 +44,9 @@ func NewSocialInteractionsClient(cc grpc.ClientConnInterface) SocialInteractions
 }
 
 func (c *socialInteractionsClient) LikeVideo(ctx context.Context, in *LikeVideoRequest, opts ...grpc.CallOption) (*LikeVideoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LikeVideoResponse)
-	err := c.cc.Invoke(ctx, "/SocialService.SocialInteractions/LikeVideo", in, out, opts...)
+	err := c.cc.Invoke(ctx, SocialInteractions_LikeVideo_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -40,8 +54,9 @@ func (c *socialInteractionsClient) LikeVideo(ctx context.Context, in *LikeVideoR
 }
 
 func (c *socialInteractionsClient) CommentVideo(ctx context.Context, in *CommentVideoRequest, opts ...grpc.CallOption) (*CommentVideoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CommentVideoResponse)
-	err := c.cc.Invoke(ctx, "/SocialService.SocialInteractions/CommentVideo", in, out, opts...)
+	err := c.cc.Invoke(ctx, SocialInteractions_CommentVideo_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -49,8 +64,19 @@ func (c *socialInteractionsClient) CommentVideo(ctx context.Context, in *Comment
 }
 
 func (c *socialInteractionsClient) GetVideoInteractions(ctx context.Context, in *GetVideoInteractionsRequest, opts ...grpc.CallOption) (*GetVideoInteractionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetVideoInteractionsResponse)
-	err := c.cc.Invoke(ctx, "/SocialService.SocialInteractions/GetVideoInteractions", in, out, opts...)
+	err := c.cc.Invoke(ctx, SocialInteractions_GetVideoInteractions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *socialInteractionsClient) ListUserInteractions(ctx context.Context, in *ListUserInteractionsRequest, opts ...grpc.CallOption) (*ListUserInteractionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserInteractionsResponse)
+	err := c.cc.Invoke(ctx, SocialInteractions_ListUserInteractions_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -59,17 +85,21 @@ func (c *socialInteractionsClient) GetVideoInteractions(ctx context.Context, in
 
 // SocialInteractionsServer is the server API for SocialInteractions service.
 // All implementations must embed UnimplementedSocialInteractionsServer
-// for forward compatibility
+// for forward compatibility.
 type SocialInteractionsServer interface {
 	LikeVideo(context.Context, *LikeVideoRequest) (*LikeVideoResponse, error)
 	CommentVideo(context.Context, *CommentVideoRequest) (*CommentVideoResponse, error)
 	GetVideoInteractions(context.Context, *GetVideoInteractionsRequest) (*GetVideoInteractionsResponse, error)
+	ListUserInteractions(context.Context, *ListUserInteractionsRequest) (*ListUserInteractionsResponse, error)
 	mustEmbedUnimplementedSocialInteractionsServer()
 }
 
-// UnimplementedSocialInteractionsServer must be embedded to have forward compatible implementations.
-type UnimplementedSocialInteractionsServer struct {
-}
+// UnimplementedSocialInteractionsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSocialInteractionsServer struct{}
 
 func (UnimplementedSocialInteractionsServer) LikeVideo(context.Context, *LikeVideoRequest) (*LikeVideoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LikeVideo not implemented")
@@ -80,7 +110,11 @@ func (UnimplementedSocialInteractionsServer) CommentVideo(context.Context, *Comm
 func (UnimplementedSocialInteractionsServer) GetVideoInteractions(context.Context, *GetVideoInteractionsRequest) (*GetVideoInteractionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVideoInteractions not implemented")
 }
+func (UnimplementedSocialInteractionsServer) ListUserInteractions(context.Context, *ListUserInteractionsRequest) (*ListUserInteractionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserInteractions not implemented")
+}
 func (UnimplementedSocialInteractionsServer) mustEmbedUnimplementedSocialInteractionsServer() {}
+func (UnimplementedSocialInteractionsServer) testEmbeddedByValue()                            {}
 
 // UnsafeSocialInteractionsServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to SocialInteractionsServer will
@@ -89,8 +123,15 @@ type UnsafeSocialInteractionsServer interface {
 	mustEmbedUnimplementedSocialInteractionsServer()
 }
 
-func RegisterSocialInteractionsServer(s *grpc.Server, srv SocialInteractionsServer) {
-	s.RegisterService(&_SocialInteractions_serviceDesc, srv)
+func RegisterSocialInteractionsServer(s grpc.ServiceRegistrar, srv SocialInteractionsServer) {
+	// If the following call pancis, it indicates UnimplementedSocialInteractionsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SocialInteractions_ServiceDesc, srv)
 }
 
 func _SocialInteractions_LikeVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
@@ -103,7 +144,7 @@ func _SocialInteractions_LikeVideo_Handler(srv interface{}, ctx context.Context,
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/SocialService.SocialInteractions/LikeVideo",
+		FullMethod: SocialInteractions_LikeVideo_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(SocialInteractionsServer).LikeVideo(ctx, req.(*LikeVideoRequest))
@@ -121,7 +162,7 @@ func _SocialInteractions_CommentVideo_Handler(srv interface{}, ctx context.Conte
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/SocialService.SocialInteractions/CommentVideo",
+		FullMethod: SocialInteractions_CommentVideo_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(SocialInteractionsServer).CommentVideo(ctx, req.(*CommentVideoRequest))
@@ -139,7 +180,7 @@ func _SocialInteractions_GetVideoInteractions_Handler(srv interface{}, ctx conte
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/SocialService.SocialInteractions/GetVideoInteractions",
+		FullMethod: SocialInteractions_GetVideoInteractions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(SocialInteractionsServer).GetVideoInteractions(ctx, req.(*GetVideoInteractionsRequest))
@@ -147,7 +188,28 @@ func _SocialInteractions_GetVideoInteractions_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
-var _SocialInteractions_serviceDesc = grpc.ServiceDesc{
+func _SocialInteractions_ListUserInteractions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserInteractionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SocialInteractionsServer).ListUserInteractions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SocialInteractions_ListUserInteractions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SocialInteractionsServer).ListUserInteractions(ctx, req.(*ListUserInteractionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SocialInteractions_ServiceDesc is the grpc.ServiceDesc for SocialInteractions service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SocialInteractions_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "SocialService.SocialInteractions",
 	HandlerType: (*SocialInteractionsServer)(nil),
 	Methods: []grpc.MethodDesc{
@@ -163,7 +225,11 @@ var _SocialInteractions_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetVideoInteractions",
 			Handler:    _SocialInteractions_GetVideoInteractions_Handler,
 		},
+		{
+			MethodName: "ListUserInteractions",
+			Handler:    _SocialInteractions_ListUserInteractions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "social.proto",
+	Metadata: "protos/social.proto",
 }
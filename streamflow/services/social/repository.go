@@ -0,0 +1,120 @@
+package main
+
+import (
+    "context"
+    "sync"
+
+    pb "social-service/pb"
+)
+
+// ================================
+//  Interaction repository
+// ================================
+//
+// The gRPC handlers reach likes and comments only through
+// InteractionRepository. The service has no database yet, so the only
+// backend is memoryInteractionRepository; a persistent one only has to
+// implement the same interface.
+
+type InteractionRepository interface {
+    AddLike(ctx context.Context, like *pb.Like) error
+    AddComment(ctx context.Context, comment *pb.Comment) error
+    // ListByVideo returns the video's likes and comments in creation order
+    ListByVideo(ctx context.Context, videoID string) ([]*pb.Like, []*pb.Comment, error)
+    // ListByUser returns every like and comment made by the user
+    ListByUser(ctx context.Context, userID string) ([]*pb.Like, []*pb.Comment, error)
+    // EraseUser removes the user's likes and comments and returns how many
+    // were removed
+    EraseUser(ctx context.Context, userID string) (int64, error)
+}
+
+// memoryInteractionRepository keeps interactions in memory (thread-safe).
+// Lists are returned as copies so callers never share the internal slices.
+type memoryInteractionRepository struct {
+    mu       sync.RWMutex
+    likes    map[string][]*pb.Like    // key: video_id
+    comments map[string][]*pb.Comment // key: video_id
+}
+
+func newMemoryInteractionRepository() *memoryInteractionRepository {
+    return &memoryInteractionRepository{
+        likes:    make(map[string][]*pb.Like),
+        comments: make(map[string][]*pb.Comment),
+    }
+}
+
+func (r *memoryInteractionRepository) AddLike(ctx context.Context, like *pb.Like) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.likes[like.GetVideoId()] = append(r.likes[like.GetVideoId()], like)
+    return nil
+}
+
+func (r *memoryInteractionRepository) AddComment(ctx context.Context, comment *pb.Comment) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.comments[comment.GetVideoId()] = append(r.comments[comment.GetVideoId()], comment)
+    return nil
+}
+
+func (r *memoryInteractionRepository) ListByVideo(ctx context.Context, videoID string) ([]*pb.Like, []*pb.Comment, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    likes := append([]*pb.Like{}, r.likes[videoID]...)
+    comments := append([]*pb.Comment{}, r.comments[videoID]...)
+    return likes, comments, nil
+}
+
+func (r *memoryInteractionRepository) ListByUser(ctx context.Context, userID string) ([]*pb.Like, []*pb.Comment, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    likes := []*pb.Like{}
+    comments := []*pb.Comment{}
+    for _, videoLikes := range r.likes {
+        for _, like := range videoLikes {
+            if like.GetUserId() == userID {
+                likes = append(likes, like)
+            }
+        }
+    }
+    for _, videoComments := range r.comments {
+        for _, comment := range videoComments {
+            if comment.GetUserId() == userID {
+                comments = append(comments, comment)
+            }
+        }
+    }
+    return likes, comments, nil
+}
+
+func (r *memoryInteractionRepository) EraseUser(ctx context.Context, userID string) (int64, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    var removed int64
+    for videoID, likes := range r.likes {
+        kept := make([]*pb.Like, 0, len(likes))
+        for _, like := range likes {
+            if like.GetUserId() == userID {
+                removed++
+                continue
+            }
+            kept = append(kept, like)
+        }
+        r.likes[videoID] = kept
+    }
+    for videoID, comments := range r.comments {
+        kept := make([]*pb.Comment, 0, len(comments))
+        for _, comment := range comments {
+            if comment.GetUserId() == userID {
+                removed++
+                continue
+            }
+            kept = append(kept, comment)
+        }
+        r.comments[videoID] = kept
+    }
+    return removed, nil
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+    "bytes"
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "fmt"
+    "image"
+    "image/color"
+    _ "image/gif" // formatos aceptados por image.Decode
+    "image/jpeg"
+    _ "image/png"
+    "log"
+    "os"
+    "path/filepath"
+    "strings"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    pb "users-service/pb"
+)
+
+// Los avatares se recortan al cuadrado central, se reducen a avatarSize
+// píxeles de lado y se guardan como JPEG en avatarDir. Cada subida usa un
+// archivo nuevo (<id>-<aleatorio>.jpg), así la URL cambia y los navegadores
+// pueden cachearla sin vencimiento.
+
+// avatarDir es donde se guardan los avatares
+var avatarDir = getEnv("AVATAR_DIR", "/data/avatars")
+
+const (
+    avatarSize        = 256
+    avatarQuality     = 85
+    avatarMaxBytes    = 3 << 20  // debajo del límite de 4 MB de los mensajes gRPC
+    avatarMaxPixels   = 25000000 // evita decodificar imágenes gigantes comprimidas
+    avatarContentType = "image/jpeg"
+)
+
+// avatarURL es la URL pública del avatar; vacía si el usuario no tiene
+func avatarURL(userID int32, file string) string {
+    if file == "" {
+        return ""
+    }
+    return fmt.Sprintf("/avatares/%d?v=%s", userID, strings.TrimSuffix(file, ".jpg"))
+}
+
+// UploadAvatar reemplaza el avatar del usuario
+func (s *server) UploadAvatar(ctx context.Context, req *pb.UploadAvatarRequest) (*pb.UserProfile, error) {
+    if err := requireSelfOr(ctx, req.UserId, "users:write"); err != nil {
+        return nil, err
+    }
+    data, err := resizeAvatar(req.Image)
+    if err != nil {
+        return nil, err
+    }
+
+    current, err := s.currentForWrite(ctx, req.UserId, req.ExpectedVersion)
+    if err != nil {
+        return nil, err
+    }
+    profile, err := decodeProfile(current)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "%v", err)
+    }
+
+    file, err := saveAvatar(req.UserId, data)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "Error guardando el avatar: %v", err)
+    }
+
+    previous := profile.Avatar
+    profile.Avatar = file
+    profile.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+    response := profileResponse(current, profile)
+    response.Version++
+    update := userChange{Set: bson.M{"profile.avatar": file, "profile.updated_at": profile.UpdatedAt}}
+    if err := s.writeProfile(ctx, current, update, response, []string{"avatar"}); err != nil {
+        removeAvatarFile(file)
+        return nil, err
+    }
+    if previous != "" {
+        removeAvatarFile(previous)
+    }
+    return response, nil
+}
+
+// DeleteAvatar quita el avatar del usuario
+func (s *server) DeleteAvatar(ctx context.Context, req *pb.DeleteAvatarRequest) (*pb.UserProfile, error) {
+    if err := requireSelfOr(ctx, req.UserId, "users:write"); err != nil {
+        return nil, err
+    }
+    current, err := s.currentForWrite(ctx, req.UserId, req.ExpectedVersion)
+    if err != nil {
+        return nil, err
+    }
+    profile, err := decodeProfile(current)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "%v", err)
+    }
+    if profile.Avatar == "" {
+        return profileResponse(current, profile), nil
+    }
+
+    previous := profile.Avatar
+    profile.Avatar = ""
+    profile.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+    response := profileResponse(current, profile)
+    response.Version++
+    update := userChange{
+        Set:   bson.M{"profile.updated_at": profile.UpdatedAt},
+        Unset: []string{"profile.avatar"},
+    }
+    if err := s.writeProfile(ctx, current, update, response, []string{"avatar"}); err != nil {
+        return nil, err
+    }
+    removeAvatarFile(previous)
+    return response, nil
+}
+
+// GetAvatar devuelve la imagen del avatar del usuario
+func (s *server) GetAvatar(ctx context.Context, req *pb.GetAvatarRequest) (*pb.Avatar, error) {
+    user, err := s.users.Get(ctx, req.UserId)
+    if err != nil {
+        return nil, readError(err)
+    }
+    profile, err := decodeProfile(user)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "%v", err)
+    }
+    if profile.Avatar == "" {
+        return nil, status.Errorf(codes.NotFound, "El usuario no tiene avatar")
+    }
+
+    data, err := os.ReadFile(filepath.Join(avatarDir, profile.Avatar))
+    if os.IsNotExist(err) {
+        return nil, status.Errorf(codes.NotFound, "El usuario no tiene avatar")
+    }
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "Error leyendo el avatar: %v", err)
+    }
+    return &pb.Avatar{
+        Data:        data,
+        ContentType: avatarContentType,
+        Etag:        strings.TrimSuffix(profile.Avatar, ".jpg"),
+    }, nil
+}
+
+// resizeAvatar decodifica la imagen subida y devuelve el avatar en JPEG
+func resizeAvatar(data []byte) ([]byte, error) {
+    if len(data) == 0 {
+        return nil, status.Errorf(codes.InvalidArgument, "Falta la imagen")
+    }
+    if len(data) > avatarMaxBytes {
+        return nil, status.Errorf(codes.InvalidArgument, "La imagen supera el tamaño máximo de 3 MB")
+    }
+    config, _, err := image.DecodeConfig(bytes.NewReader(data))
+    if err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "Formato de imagen no soportado (JPEG, PNG o GIF)")
+    }
+    if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > avatarMaxPixels {
+        return nil, status.Errorf(codes.InvalidArgument, "Dimensiones de imagen no soportadas: %dx%d", config.Width, config.Height)
+    }
+    img, _, err := image.Decode(bytes.NewReader(data))
+    if err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "Imagen inválida: %v", err)
+    }
+
+    var out bytes.Buffer
+    if err := jpeg.Encode(&out, squareThumbnail(img, avatarSize), &jpeg.Options{Quality: avatarQuality}); err != nil {
+        return nil, status.Errorf(codes.Internal, "Error codificando el avatar: %v", err)
+    }
+    return out.Bytes(), nil
+}
+
+// squareThumbnail recorta el cuadrado central de la imagen y lo reduce a
+// size píxeles de lado (nunca la agranda) promediando los píxeles que cubre
+// cada uno del resultado. Las transparencias quedan sobre fondo blanco, que
+// JPEG no tiene alfa.
+func squareThumbnail(src image.Image, size int) *image.RGBA {
+    bounds := src.Bounds()
+    side := bounds.Dx()
+    if bounds.Dy() < side {
+        side = bounds.Dy()
+    }
+    if size > side {
+        size = side
+    }
+    x0 := bounds.Min.X + (bounds.Dx()-side)/2
+    y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+
+    dst := image.NewRGBA(image.Rect(0, 0, size, size))
+    for dy := 0; dy < size; dy++ {
+        sy0, sy1 := y0+dy*side/size, y0+(dy+1)*side/size
+        for dx := 0; dx < size; dx++ {
+            sx0, sx1 := x0+dx*side/size, x0+(dx+1)*side/size
+            var r, g, b, a, n uint64
+            for sy := sy0; sy < sy1; sy++ {
+                for sx := sx0; sx < sx1; sx++ {
+                    cr, cg, cb, ca := src.At(sx, sy).RGBA() // premultiplicados
+                    r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+                    n++
+                }
+            }
+            white := 0xffff*n - a
+            dst.SetRGBA(dx, dy, color.RGBA{
+                R: uint8((r + white) / n >> 8),
+                G: uint8((g + white) / n >> 8),
+                B: uint8((b + white) / n >> 8),
+                A: 0xff,
+            })
+        }
+    }
+    return dst
+}
+
+// saveAvatar escribe el avatar en un archivo nuevo y devuelve su nombre
+func saveAvatar(userID int32, data []byte) (string, error) {
+    suffix := make([]byte, 8)
+    if _, err := rand.Read(suffix); err != nil {
+        return "", err
+    }
+    file := fmt.Sprintf("%d-%s.jpg", userID, hex.EncodeToString(suffix))
+
+    if err := os.MkdirAll(avatarDir, 0o755); err != nil {
+        return "", fmt.Errorf("creando %s: %w", avatarDir, err)
+    }
+    path := filepath.Join(avatarDir, file)
+    if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+        return "", err
+    }
+    if err := os.Rename(path+".tmp", path); err != nil {
+        os.Remove(path + ".tmp")
+        return "", err
+    }
+    return file, nil
+}
+
+func removeAvatarFile(file string) {
+    if err := os.Remove(filepath.Join(avatarDir, file)); err != nil && !os.IsNotExist(err) {
+        log.Printf("Error borrando el avatar %s: %v", file, err)
+    }
+}
+
+// deleteUserAvatars borra los avatares de un usuario purgado
+func deleteUserAvatars(userID int32) error {
+    files, err := filepath.Glob(filepath.Join(avatarDir, fmt.Sprintf("%d-*.jpg", userID)))
+    if err != nil {
+        return err
+    }
+    for _, file := range files {
+        if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+            return fmt.Errorf("borrando %s: %w", file, err)
+        }
+    }
+    return nil
+}
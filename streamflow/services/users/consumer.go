@@ -0,0 +1,108 @@
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "log"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+)
+
+// consumeUserVerified escucha user.verified (publicado por auth al confirmar
+// el email) y pasa la cuenta a estado activo
+func (s *server) consumeUserVerified() {
+    ch, err := s.rabbitmq.Channel()
+    if err != nil {
+        log.Printf("Error abriendo canal para user.verified: %v", err)
+        return
+    }
+    defer ch.Close()
+    
+    if err := ch.ExchangeDeclare("events_exchange", "direct", true, false, false, false, nil); err != nil {
+        log.Printf("Error declarando exchange: %v", err)
+        return
+    }
+    
+    q, err := ch.QueueDeclare("users_verified_queue", true, false, false, false, nil)
+    if err != nil {
+        log.Printf("Error declarando cola users_verified_queue: %v", err)
+        return
+    }
+    if err := ch.QueueBind(q.Name, "user.verified", "events_exchange", false, nil); err != nil {
+        log.Printf("Error enlazando user.verified: %v", err)
+        return
+    }
+    
+    msgs, err := ch.Consume(q.Name, "", false, false, false, false, nil)
+    if err != nil {
+        log.Printf("Error consumiendo user.verified: %v", err)
+        return
+    }
+    
+    log.Println("Escuchando eventos user.verified")
+    for d := range msgs {
+        var event struct {
+            ID int32 `json:"id"`
+        }
+        if err := json.Unmarshal(d.Body, &event); err != nil || event.ID == 0 {
+            log.Printf("Evento user.verified inválido: %s", d.Body)
+            d.Nack(false, false)
+            continue
+        }
+        
+        if err := s.activateUser(event.ID); err != nil {
+            log.Printf("Error activando usuario %d: %v", event.ID, err)
+            d.Nack(false, true)
+            continue
+        }
+        d.Ack(false)
+    }
+    log.Println("Consumidor de user.verified detenido")
+}
+
+// activateUser marca la cuenta como activa y publica user.updated; es
+// idempotente
+func (s *server) activateUser(id int32) error {
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer cancel()
+    
+    user, err := s.users.Get(ctx, id)
+    if errors.Is(err, errUserNotFound) {
+        log.Printf("Usuario %d no encontrado al activar, se ignora", id)
+        return nil
+    }
+    if err != nil {
+        return err
+    }
+    // Solo si no estaba activa, para no cambiar la versión en cada reentrega
+    if userStatus(user) == statusActive {
+        log.Printf("Usuario %d ya activo al activar, se ignora", id)
+        return nil
+    }
+    
+    event, err := newUserEvent("user.updated", id, docVersion(user)+1, map[string]interface{}{
+        "first_name":     user["first_name"],
+        "last_name":      user["last_name"],
+        "email":          user["email"],
+        "role":           user["role"],
+        "status":         statusActive,
+        "changed_fields": []string{"status"},
+        "previous":       bson.M{"status": userStatus(user)},
+    })
+    if err != nil {
+        return err
+    }
+    err = s.users.Update(ctx, id, docVersion(user), userChange{
+        Set:    bson.M{"status": statusActive},
+        Events: []outboxEvent{event},
+    })
+    var conflict *versionConflictError
+    if errors.As(err, &conflict) || errors.Is(err, errUserNotFound) {
+        // Cambió entre la lectura y la escritura: se reintenta con la entrega
+        return fmt.Errorf("el usuario %d cambió durante la activación", id)
+    }
+    return err
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+    "context"
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "log"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    pb "users-service/pb"
+)
+
+// Un cambio de email no se aplica en UpdateUser. El email nuevo queda en
+// pending_email junto con el hash de un token, y user.email_change_requested
+// le pide al servicio de email que mande el enlace de confirmación a la
+// dirección nueva y un aviso a la actual. Solo ConfirmEmailChange, con el
+// token del enlace, cambia el email y publica user.email_changed, con el que
+// auth actualiza las credenciales de login. Pedir de nuevo el email actual
+// cancela el cambio pendiente.
+
+// emailChangeTTL es cuánto tiempo sirve el enlace de confirmación
+var emailChangeTTL = getDurationEnv("EMAIL_CHANGE_TTL", 24*time.Hour)
+
+// pendingEmail es un cambio de email esperando confirmación
+type pendingEmail struct {
+    Email       string    `bson:"email"`
+    TokenHash   string    `bson:"token_hash"`
+    RequestedAt time.Time `bson:"requested_at"`
+    ExpiresAt   time.Time `bson:"expires_at"`
+}
+
+// generateEmailChangeToken devuelve un token aleatorio y su hash SHA-256.
+// Solo se guarda el hash; el token viaja en el enlace del email.
+func generateEmailChangeToken() (string, string, error) {
+    buf := make([]byte, 32)
+    if _, err := rand.Read(buf); err != nil {
+        return "", "", fmt.Errorf("generando el token: %w", err)
+    }
+    token := base64.RawURLEncoding.EncodeToString(buf)
+    return token, hashEmailChangeToken(token), nil
+}
+
+func hashEmailChangeToken(token string) string {
+    sum := sha256.Sum256([]byte(token))
+    return hex.EncodeToString(sum[:])
+}
+
+// decodePendingEmail lee el cambio pendiente del documento; nil si no hay
+func decodePendingEmail(user bson.M) *pendingEmail {
+    raw, ok := user["pending_email"]
+    if !ok || raw == nil {
+        return nil
+    }
+    data, err := bson.Marshal(raw)
+    if err != nil {
+        return nil
+    }
+    var pending pendingEmail
+    if err := bson.Unmarshal(data, &pending); err != nil || pending.Email == "" {
+        return nil
+    }
+    return &pending
+}
+
+// setPendingEmail completa la respuesta con el cambio pendiente, si sigue vigente
+func setPendingEmail(response *pb.UserResponse, pending *pendingEmail) {
+    response.PendingEmail = ""
+    response.PendingEmailExpiresAt = ""
+    if pending == nil || time.Now().After(pending.ExpiresAt) {
+        return
+    }
+    response.PendingEmail = pending.Email
+    response.PendingEmailExpiresAt = pending.ExpiresAt.UTC().Format(time.RFC3339)
+}
+
+// stageEmailChange arma el cambio pendiente hacia newEmail y el evento que
+// dispara los emails de confirmación y aviso
+func stageEmailChange(id int32, currentEmail, name, newEmail string, version int64) (*pendingEmail, outboxEvent, error) {
+    token, tokenHash, err := generateEmailChangeToken()
+    if err != nil {
+        return nil, outboxEvent{}, status.Errorf(codes.Internal, "Error generando el token: %v", err)
+    }
+    now := time.Now().UTC()
+    pending := &pendingEmail{
+        Email:       newEmail,
+        TokenHash:   tokenHash,
+        RequestedAt: now,
+        ExpiresAt:   now.Add(emailChangeTTL),
+    }
+    event, err := newUserEvent("user.email_change_requested", id, version, map[string]interface{}{
+        "email":      currentEmail,
+        "new_email":  newEmail,
+        "name":       name,
+        "token":      token,
+        "expires_at": pending.ExpiresAt.Format(time.RFC3339),
+    })
+    if err != nil {
+        return nil, outboxEvent{}, status.Errorf(codes.Internal, "Error armando el evento: %v", err)
+    }
+    return pending, event, nil
+}
+
+// emailTaken dice si el email pertenece a otro usuario no eliminado
+func (s *server) emailTaken(ctx context.Context, email string, id int32) error {
+    taken, err := s.users.EmailsInUse(ctx, []string{email}, id)
+    if err != nil {
+        return status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+    if taken[email] {
+        return status.Errorf(codes.AlreadyExists, "El email ya está registrado")
+    }
+    return nil
+}
+
+// ConfirmEmailChange aplica el cambio pendiente que corresponde al token
+func (s *server) ConfirmEmailChange(ctx context.Context, req *pb.ConfirmEmailChangeRequest) (*pb.UserResponse, error) {
+    invalid := status.Errorf(codes.InvalidArgument, "Enlace de confirmación inválido o expirado")
+    if req.Token == "" {
+        return nil, invalid
+    }
+
+    current, err := s.users.FindByEmailChangeToken(ctx, hashEmailChangeToken(req.Token))
+    if errors.Is(err, errUserNotFound) {
+        return nil, invalid
+    }
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+    pending := decodePendingEmail(current)
+    if pending == nil || time.Now().After(pending.ExpiresAt) {
+        return nil, invalid
+    }
+
+    id := docUserID(current)
+    // Otro usuario pudo registrarse con el email mientras esperaba
+    if err := s.emailTaken(ctx, pending.Email, id); err != nil {
+        return nil, err
+    }
+
+    previousEmail := current["email"].(string)
+    response := &pb.UserResponse{
+        Id:        id,
+        FirstName: current["first_name"].(string),
+        LastName:  current["last_name"].(string),
+        Email:     pending.Email,
+        Role:      current["role"].(string),
+        CreatedAt: current["created_at"].(string),
+        Status:    userStatus(current),
+        Version:   docVersion(current) + 1,
+    }
+
+    event, err := newUserEvent("user.email_changed", id, response.Version, map[string]interface{}{
+        "email":          response.Email,
+        "previous_email": previousEmail,
+        "first_name":     response.FirstName,
+        "last_name":      response.LastName,
+        "confirmed_at":   time.Now().UTC().Format(time.RFC3339),
+    })
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "Error armando el evento: %v", err)
+    }
+
+    // Condicionado a la versión leída: un cambio posterior, incluido un token
+    // reemplazado o cancelado, sube la versión e invalida esta confirmación
+    err = s.users.Update(ctx, id, docVersion(current), userChange{
+        Set:    bson.M{"email": pending.Email, "search.email": searchKey(pending.Email)},
+        Unset:  []string{"pending_email"},
+        Events: []outboxEvent{event},
+    })
+    var conflict *versionConflictError
+    if errors.As(err, &conflict) || errors.Is(err, errUserNotFound) {
+        return nil, status.Errorf(codes.Aborted, "El usuario cambió mientras se confirmaba el email; abre el enlace de nuevo")
+    }
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB update error: %v", err)
+    }
+
+    log.Printf("Email del usuario %d cambiado de %s a %s", id, previousEmail, pending.Email)
+    return response, nil
+}
@@ -0,0 +1,393 @@
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "log"
+    "strings"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson/primitive"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    pb "users-service/pb"
+)
+
+// Al purgar un usuario, sus datos siguen en otros servicios (facturas,
+// listas, likes y comentarios, registros de monitoreo). Este servicio
+// coordina su borrado: guarda una solicitud en la colección erasures y
+// publica user.erasure_requested.<servicio> para cada participante, que borra
+// o anonimiza los datos y responde con user.erasure_completed. Los servicios
+// que no respondieron reciben la solicitud otra vez cada
+// ERASURE_RETRY_INTERVAL; borrar dos veces no tiene efecto.
+
+// erasureServices son los participantes; ERASURE_SERVICES los reemplaza
+var erasureServices = parseErasureServices(getEnv("ERASURE_SERVICES", "billing,playlists,social,monitoring"))
+
+// erasureRetryInterval es la espera antes de reenviar una solicitud pendiente
+var erasureRetryInterval = getDurationEnv("ERASURE_RETRY_INTERVAL", 10*time.Minute)
+
+// erasureAlertAttempts es el número de envíos a partir del cual se avisa en
+// el log en cada reintento
+const erasureAlertAttempts = 10
+
+// Una solicitud está en preparing mientras se purga el usuario; el job de
+// reintentos nunca la envía en ese estado
+const (
+    erasureStatusPreparing = "preparing"
+    erasureStatusPending   = "pending"
+    erasureStatusCompleted = "completed"
+)
+
+// erasureQueue recibe las confirmaciones de los participantes
+const erasureQueue = "users_erasure_queue"
+
+type erasureServiceDoc struct {
+    Status      string     `bson:"status"`
+    Records     int64      `bson:"records"`
+    CompletedAt *time.Time `bson:"completed_at,omitempty"`
+}
+
+type erasureDoc struct {
+    ID            primitive.ObjectID           `bson:"_id"`
+    UserID        int32                        `bson:"user_id"`
+    Reason        string                       `bson:"reason"`
+    Status        string                       `bson:"status"`
+    Services      map[string]erasureServiceDoc `bson:"services"`
+    RequestedAt   time.Time                    `bson:"requested_at"`
+    CompletedAt   *time.Time                   `bson:"completed_at,omitempty"`
+    Attempts      int32                        `bson:"attempts"`
+    LastAttemptAt *time.Time                   `bson:"last_attempt_at,omitempty"`
+}
+
+func parseErasureServices(value string) []string {
+    var services []string
+    for _, service := range strings.Split(value, ",") {
+        if service = strings.TrimSpace(service); service != "" {
+            services = append(services, service)
+        }
+    }
+    return services
+}
+
+// pendingServices devuelve los participantes que aún no confirmaron
+func (e *erasureDoc) pendingServices() []string {
+    var pending []string
+    for service, state := range e.Services {
+        if state.Status != erasureStatusCompleted {
+            pending = append(pending, service)
+        }
+    }
+    return pending
+}
+
+// createErasure registra la solicitud de borrado de un usuario en preparing
+func (s *server) createErasure(ctx context.Context, userID int32, reason string) (*erasureDoc, error) {
+    erasure := &erasureDoc{
+        ID:          primitive.NewObjectID(),
+        UserID:      userID,
+        Reason:      reason,
+        Status:      erasureStatusPreparing,
+        Services:    make(map[string]erasureServiceDoc, len(erasureServices)),
+        RequestedAt: time.Now().UTC(),
+    }
+    for _, service := range erasureServices {
+        erasure.Services[service] = erasureServiceDoc{Status: erasureStatusPending}
+    }
+    if err := s.erasures.Create(ctx, erasure); err != nil {
+        return nil, fmt.Errorf("registrando solicitud de borrado: %w", err)
+    }
+    return erasure, nil
+}
+
+// activateErasure pasa la solicitud a pending una vez purgado el usuario (o
+// directamente a completed si no hay participantes)
+func (s *server) activateErasure(ctx context.Context, erasure *erasureDoc) error {
+    to, completedAt := erasureStatusPending, (*time.Time)(nil)
+    if len(erasure.Services) == 0 {
+        now := time.Now().UTC()
+        to, completedAt = erasureStatusCompleted, &now
+    }
+    if _, err := s.erasures.Transition(ctx, erasure.ID, erasureStatusPreparing, to, completedAt); err != nil {
+        return fmt.Errorf("activando borrado %s: %w", erasure.ID.Hex(), err)
+    }
+    erasure.Status = to
+    return nil
+}
+
+// resolvePreparingErasures termina las solicitudes que quedaron en preparing
+// porque el proceso se detuvo durante la purga: si el usuario ya no existe
+// se activan, y si sigue existiendo se descartan
+func (s *server) resolvePreparingErasures(ctx context.Context, cutoff time.Time) error {
+    preparing, err := s.erasures.Preparing(ctx, cutoff)
+    if err != nil {
+        return fmt.Errorf("buscando borrados en preparación: %w", err)
+    }
+
+    for i := range preparing {
+        erasure := &preparing[i]
+        exists, err := s.users.Exists(ctx, erasure.UserID)
+        if err != nil {
+            return fmt.Errorf("buscando usuario %d: %w", erasure.UserID, err)
+        }
+        if exists {
+            if err := s.erasures.DeletePreparing(ctx, erasure.ID); err != nil {
+                return fmt.Errorf("descartando borrado %s: %w", erasure.ID.Hex(), err)
+            }
+            log.Printf("Borrado %s descartado: el usuario %d no llegó a purgarse", erasure.ID.Hex(), erasure.UserID)
+            continue
+        }
+        if err := s.activateErasure(ctx, erasure); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// dispatchErasure envía la solicitud a los participantes pendientes. Los
+// errores de publicación solo se registran: el job de reintentos la reenvía.
+func (s *server) dispatchErasure(ctx context.Context, erasure *erasureDoc) error {
+    pending := erasure.pendingServices()
+    if len(pending) == 0 {
+        return nil
+    }
+
+    for _, service := range pending {
+        err := s.publishEvent("user.erasure_requested."+service, map[string]interface{}{
+            "erasure_id": erasure.ID.Hex(),
+            "user_id":    erasure.UserID,
+        })
+        if err != nil {
+            log.Printf("Error enviando el borrado %s a %s: %v", erasure.ID.Hex(), service, err)
+        }
+    }
+
+    if err := s.erasures.RecordAttempt(ctx, erasure.ID, time.Now().UTC()); err != nil {
+        return fmt.Errorf("actualizando intentos del borrado %s: %w", erasure.ID.Hex(), err)
+    }
+    if erasure.Attempts+1 >= erasureAlertAttempts {
+        log.Printf("ALERTA: el borrado %s del usuario %d sigue pendiente tras %d envíos (faltan %s)",
+            erasure.ID.Hex(), erasure.UserID, erasure.Attempts+1, strings.Join(pending, ", "))
+    }
+    return nil
+}
+
+// completeErasureService registra la confirmación de un participante y cierra
+// la solicitud cuando confirmaron todos
+func (s *server) completeErasureService(ctx context.Context, erasureID primitive.ObjectID, service string, records int64, completedAt time.Time) error {
+    if err := s.erasures.CompleteService(ctx, erasureID, service, records, completedAt); err != nil {
+        return fmt.Errorf("registrando confirmación de %s: %w", service, err)
+    }
+
+    erasure, err := s.erasures.Get(ctx, erasureID)
+    if errors.Is(err, errErasureNotFound) {
+        log.Printf("Confirmación de %s para un borrado desconocido: %s", service, erasureID.Hex())
+        return nil
+    }
+    if err != nil {
+        return fmt.Errorf("leyendo borrado %s: %w", erasureID.Hex(), err)
+    }
+    if _, ok := erasure.Services[service]; !ok {
+        log.Printf("Confirmación de %s, que no participa del borrado %s", service, erasureID.Hex())
+        return nil
+    }
+    if erasure.Status != erasureStatusPending || len(erasure.pendingServices()) > 0 {
+        return nil
+    }
+
+    now := time.Now().UTC()
+    closed, err := s.erasures.Transition(ctx, erasureID, erasureStatusPending, erasureStatusCompleted, &now)
+    if err != nil {
+        return fmt.Errorf("cerrando borrado %s: %w", erasureID.Hex(), err)
+    }
+    if closed {
+        log.Printf("Borrado %s del usuario %d completado", erasureID.Hex(), erasure.UserID)
+    }
+    return nil
+}
+
+// consumeErasureCompleted escucha user.erasure_completed de los
+// participantes, reconectándose si se cae la conexión
+func (s *server) consumeErasureCompleted() {
+    for {
+        if err := s.consumeErasureCompletedOnce(); err != nil {
+            log.Printf("Consumidor de user.erasure_completed detenido: %v", err)
+        }
+        time.Sleep(5 * time.Second)
+    }
+}
+
+func (s *server) consumeErasureCompletedOnce() error {
+    ch, err := s.rabbitmq.Channel()
+    if err != nil {
+        return fmt.Errorf("abriendo canal: %w", err)
+    }
+    defer ch.Close()
+
+    if err := ch.ExchangeDeclare("events_exchange", "direct", true, false, false, false, nil); err != nil {
+        return fmt.Errorf("declarando exchange: %w", err)
+    }
+    q, err := ch.QueueDeclare(erasureQueue, true, false, false, false, nil)
+    if err != nil {
+        return fmt.Errorf("declarando cola %s: %w", erasureQueue, err)
+    }
+    if err := ch.QueueBind(q.Name, "user.erasure_completed", "events_exchange", false, nil); err != nil {
+        return fmt.Errorf("enlazando user.erasure_completed: %w", err)
+    }
+
+    msgs, err := ch.Consume(q.Name, "", false, false, false, false, nil)
+    if err != nil {
+        return fmt.Errorf("consumiendo %s: %w", erasureQueue, err)
+    }
+
+    log.Println("Escuchando eventos user.erasure_completed")
+    for d := range msgs {
+        var event struct {
+            ErasureID   string `json:"erasure_id"`
+            Service     string `json:"service"`
+            Records     int64  `json:"records"`
+            CompletedAt string `json:"completed_at"`
+        }
+        err := json.Unmarshal(d.Body, &event)
+        erasureID, idErr := primitive.ObjectIDFromHex(event.ErasureID)
+        if err != nil || idErr != nil || event.Service == "" {
+            log.Printf("Evento user.erasure_completed inválido: %s", d.Body)
+            d.Nack(false, false)
+            continue
+        }
+        completedAt, err := time.Parse(time.RFC3339, event.CompletedAt)
+        if err != nil {
+            completedAt = time.Now().UTC()
+        }
+
+        ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+        err = s.completeErasureService(ctx, erasureID, event.Service, event.Records, completedAt)
+        cancel()
+        if err != nil {
+            log.Printf("Error registrando user.erasure_completed: %v", err)
+            d.Nack(false, true)
+            continue
+        }
+        d.Ack(false)
+    }
+    return fmt.Errorf("canal de entregas cerrado")
+}
+
+// startErasureRetryJob reenvía las solicitudes cuyos participantes no
+// confirmaron dentro de erasureRetryInterval
+func (s *server) startErasureRetryJob() {
+    for {
+        time.Sleep(erasureRetryInterval)
+        if err := s.retryPendingErasures(context.Background()); err != nil {
+            log.Printf("Error reintentando borrados pendientes: %v", err)
+        }
+    }
+}
+
+func (s *server) retryPendingErasures(ctx context.Context) error {
+    cutoff := time.Now().UTC().Add(-erasureRetryInterval)
+    if err := s.resolvePreparingErasures(ctx, cutoff); err != nil {
+        return err
+    }
+
+    pending, err := s.erasures.DueForRetry(ctx, cutoff)
+    if err != nil {
+        return fmt.Errorf("buscando borrados pendientes: %w", err)
+    }
+
+    for i := range pending {
+        if err := s.dispatchErasure(ctx, &pending[i]); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+func erasureToProto(e *erasureDoc) *pb.ErasureRequest {
+    request := &pb.ErasureRequest{
+        Id:          e.ID.Hex(),
+        UserId:      e.UserID,
+        Status:      e.Status,
+        Reason:      e.Reason,
+        RequestedAt: e.RequestedAt.Format(time.RFC3339),
+        Attempts:    e.Attempts,
+    }
+    if e.CompletedAt != nil {
+        request.CompletedAt = e.CompletedAt.Format(time.RFC3339)
+    }
+    if e.LastAttemptAt != nil {
+        request.LastAttemptAt = e.LastAttemptAt.Format(time.RFC3339)
+    }
+    for _, service := range erasureServiceNames(e) {
+        state := e.Services[service]
+        entry := &pb.ErasureServiceStatus{Service: service, Status: state.Status, Records: state.Records}
+        if state.CompletedAt != nil {
+            entry.CompletedAt = state.CompletedAt.Format(time.RFC3339)
+        }
+        request.Services = append(request.Services, entry)
+    }
+    return request
+}
+
+// erasureServiceNames ordena los participantes como en ERASURE_SERVICES y
+// agrega al final los que ya no figuran
+func erasureServiceNames(e *erasureDoc) []string {
+    var names []string
+    seen := make(map[string]bool, len(e.Services))
+    for _, service := range erasureServices {
+        if _, ok := e.Services[service]; ok {
+            names = append(names, service)
+            seen[service] = true
+        }
+    }
+    for service := range e.Services {
+        if !seen[service] {
+            names = append(names, service)
+        }
+    }
+    return names
+}
+
+// GetErasureRequest devuelve el estado de una solicitud de borrado
+func (s *server) GetErasureRequest(ctx context.Context, req *pb.GetErasureRequestRequest) (*pb.ErasureRequest, error) {
+    if err := requirePermission(ctx, "users:purge"); err != nil {
+        return nil, err
+    }
+    id, err := primitive.ObjectIDFromHex(req.Id)
+    if err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "ID de solicitud de borrado inválido")
+    }
+    erasure, err := s.erasures.Get(ctx, id)
+    if errors.Is(err, errErasureNotFound) {
+        return nil, status.Errorf(codes.NotFound, "Solicitud de borrado no encontrada")
+    }
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+    return erasureToProto(erasure), nil
+}
+
+// ListErasureRequests lista las solicitudes de borrado más recientes
+func (s *server) ListErasureRequests(ctx context.Context, req *pb.ListErasureRequestsRequest) (*pb.ListErasureRequestsResponse, error) {
+    if err := requirePermission(ctx, "users:purge"); err != nil {
+        return nil, err
+    }
+    switch req.Status {
+    case "", erasureStatusPending, erasureStatusCompleted:
+    default:
+        return nil, status.Errorf(codes.InvalidArgument, "status inválido: %q", req.Status)
+    }
+
+    erasures, err := s.erasures.List(ctx, req.Status, req.UserId, 100)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+
+    response := &pb.ListErasureRequestsResponse{}
+    for i := range erasures {
+        response.Requests = append(response.Requests, erasureToProto(&erasures[i]))
+    }
+    return response, nil
+}
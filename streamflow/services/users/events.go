@@ -0,0 +1,176 @@
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Los eventos de usuario (user.created, user.updated, user.deleted y
+// user.restored) no se publican desde los handlers. Se guardan en el campo
+// outbox del propio documento, en la misma escritura que el cambio que
+// describen, y el relay los publica en orden con confirmación del broker. Un
+// evento no se pierde aunque RabbitMQ esté caído o el proceso muera después
+// de escribir; a lo sumo se entrega dos veces, con el mismo event_id como
+// MessageId. MongoDB no tiene transacciones en este despliegue (sin replica
+// set), por eso la cola vive en el documento y no en una colección aparte.
+
+// authUserCreatedKey es la routing key de user.created con el hash de la
+// contraseña. Solo la cola de auth la tiene enlazada; el resto de los
+// servicios recibe user.created, sin credenciales.
+const authUserCreatedKey = "user.created.auth"
+
+// userEventSchemaVersion es la versión del esquema de los eventos de
+// usuario; cambia solo si se quita o cambia el significado de un campo
+const userEventSchemaVersion = 1
+
+// Configuración del relay
+var (
+    outboxPollInterval   = time.Second
+    outboxPublishTimeout = 10 * time.Second
+    outboxMaxRetryDelay  = 5 * time.Minute
+)
+
+const (
+    outboxBatchSize     = 100
+    outboxAlertAttempts = 10 // a partir de acá se avisa como alerta en el log
+)
+
+// outboxEvent es un evento pendiente de publicar
+type outboxEvent struct {
+    EventID    string    `bson:"event_id"`
+    RoutingKey string    `bson:"routing_key"`
+    Payload    []byte    `bson:"payload"`
+    CreatedAt  time.Time `bson:"created_at"`
+    Attempts   int32     `bson:"attempts"`
+    LastError  string    `bson:"last_error,omitempty"`
+}
+
+// newUserEvent arma un evento con los campos comunes del esquema: versión
+// del esquema, event_id, tipo, fecha, ID del usuario y versión del documento
+// después del cambio
+func newUserEvent(routingKey string, userID int32, version int64, data map[string]interface{}) (outboxEvent, error) {
+    now := time.Now().UTC()
+    event := outboxEvent{
+        EventID:    primitive.NewObjectID().Hex(),
+        RoutingKey: routingKey,
+        CreatedAt:  now,
+    }
+    payload := map[string]interface{}{
+        "schema_version": userEventSchemaVersion,
+        "event_id":       event.EventID,
+        "event_type":     routingKey,
+        "occurred_at":    now.Format(time.RFC3339),
+        "id":             userID,
+        "version":        version,
+    }
+    for key, value := range data {
+        payload[key] = value
+    }
+    body, err := json.Marshal(payload)
+    if err != nil {
+        return event, fmt.Errorf("serializando %s: %w", routingKey, err)
+    }
+    event.Payload = body
+    return event, nil
+}
+
+// pendingEventCount cuenta los eventos sin publicar de un documento
+func pendingEventCount(user bson.M) int {
+    outbox, _ := user["outbox"].(bson.A)
+    return len(outbox)
+}
+
+// startOutboxRelay publica los eventos pendientes hasta que termina el proceso
+func (s *server) startOutboxRelay() {
+    log.Println("Relay de eventos de usuario iniciado")
+    failures := 0
+    for {
+        published, err := s.relayOutbox(context.Background())
+        if err != nil {
+            failures++
+            log.Printf("Error publicando eventos de usuario: %v", err)
+            time.Sleep(outboxRetryDelay(failures))
+            continue
+        }
+        failures = 0
+        if published > 0 {
+            log.Printf("Eventos de usuario publicados: %d", published)
+        }
+        // Con el lote completo probablemente quedan más
+        if published < outboxBatchSize {
+            time.Sleep(outboxPollInterval)
+        }
+    }
+}
+
+// relayOutbox publica los eventos pendientes de cada documento en orden y
+// los quita del outbox una vez confirmados. Un fallo detiene solo ese
+// documento, para que sus eventos siguientes no se adelanten; los demás
+// documentos se publican igual. Devuelve el primer error para que el relay
+// espere antes de la próxima vuelta.
+func (s *server) relayOutbox(ctx context.Context) (int, error) {
+    docs, err := s.users.PendingEvents(ctx, outboxBatchSize)
+    if err != nil {
+        return 0, fmt.Errorf("buscando eventos pendientes: %w", err)
+    }
+
+    published := 0
+    var firstErr error
+    for _, doc := range docs {
+        n, err := s.relayDocument(ctx, doc)
+        published += n
+        if err != nil && firstErr == nil {
+            firstErr = err
+        }
+    }
+    return published, firstErr
+}
+
+// relayDocument publica el outbox de un documento hasta el primer fallo
+func (s *server) relayDocument(ctx context.Context, doc userOutbox) (int, error) {
+    published := 0
+    for _, event := range doc.Outbox {
+        pubCtx, cancel := context.WithTimeout(ctx, outboxPublishTimeout)
+        publishErr := s.publisher.Publish(pubCtx, event.RoutingKey, event.EventID, event.Payload)
+        cancel()
+
+        if publishErr != nil {
+            attempts := event.Attempts + 1
+            if err := s.users.MarkEventFailed(ctx, doc.ID, event.EventID, attempts, publishErr.Error()); err != nil {
+                log.Printf("Error registrando el fallo del evento %s: %v", event.EventID, err)
+            }
+            if attempts == outboxAlertAttempts {
+                log.Printf("ALERTA: el evento %s (%s) del usuario %d falló %d veces: %v",
+                    event.EventID, event.RoutingKey, doc.UserID, attempts, publishErr)
+            }
+            return published, fmt.Errorf("evento %s (%s) del usuario %d, intento %d: %w",
+                event.EventID, event.RoutingKey, doc.UserID, attempts, publishErr)
+        }
+
+        if err := s.users.RemoveEvent(ctx, doc.ID, event.EventID); err != nil {
+            // Se vuelve a publicar en la próxima vuelta: al menos una vez
+            return published, fmt.Errorf("quitando el evento %s del outbox: %w", event.EventID, err)
+        }
+        published++
+    }
+    return published, nil
+}
+
+// outboxRetryDelay duplica la espera después de cada fallo seguido, hasta
+// outboxMaxRetryDelay. Los eventos se reintentan para siempre.
+func outboxRetryDelay(failures int) time.Duration {
+    delay := outboxPollInterval
+    for i := 1; i < failures && delay < outboxMaxRetryDelay; i++ {
+        delay *= 2
+    }
+    if delay > outboxMaxRetryDelay {
+        delay = outboxMaxRetryDelay
+    }
+    return delay
+}
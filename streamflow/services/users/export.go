@@ -0,0 +1,615 @@
+package main
+
+import (
+    "archive/zip"
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "log"
+    "os"
+    "path/filepath"
+    "strconv"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/credentials/insecure"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/encoding/protojson"
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/types/known/emptypb"
+    pb "users-service/pb"
+)
+
+// Un usuario puede pedir una copia de todo lo que guardamos de él. La
+// solicitud queda en la colección exports y un worker la procesa en segundo
+// plano: lee el perfil de este servicio y pide por gRPC las facturas
+// (billing), las listas (playlists), los likes y comentarios (social) y las
+// acciones registradas (monitoring). El resultado es un ZIP de archivos JSON
+// en EXPORT_DIR, que se descarga con un token hasta EXPORT_TTL después de
+// generado; después el job de limpieza borra el archivo.
+
+// exportDir es donde se guardan los ZIP
+var exportDir = getEnv("EXPORT_DIR", "/data/exports")
+
+// exportTTL es cuánto tiempo se puede descargar una exportación
+var exportTTL = getDurationEnv("EXPORT_TTL", 48*time.Hour)
+
+// Servicios consultados al armar la exportación
+var (
+    billingServiceURL    = getEnv("BILLING_SERVICE_URL", "localhost:50052")
+    playlistsServiceURL  = getEnv("PLAYLISTS_SERVICE_URL", "localhost:50055")
+    socialServiceURL     = getEnv("SOCIAL_SERVICE_URL", "localhost:50051")
+    monitoringServiceURL = getEnv("MONITORING_SERVICE_URL", "localhost:50054")
+)
+
+const (
+    exportMaxAttempts     = 3
+    exportRetryDelay      = time.Minute
+    exportPollInterval    = 5 * time.Second
+    exportCleanupInterval = 15 * time.Minute
+    exportSourceTimeout   = 30 * time.Second
+    exportChunkSize       = 64 * 1024
+)
+
+const (
+    exportStatusPending   = "pending"
+    exportStatusRunning   = "running"
+    exportStatusCompleted = "completed"
+    exportStatusFailed    = "failed"
+    exportStatusExpired   = "expired"
+)
+
+// errExportUserGone indica que el usuario se eliminó mientras la
+// exportación esperaba; no tiene sentido reintentarla
+var errExportUserGone = errors.New("el usuario fue eliminado")
+
+type exportDoc struct {
+    ID            primitive.ObjectID `bson:"_id"`
+    UserID        int32              `bson:"user_id"`
+    Status        string             `bson:"status"`
+    Error         string             `bson:"error,omitempty"`
+    Attempts      int32              `bson:"attempts"`
+    RequestedAt   time.Time          `bson:"requested_at"`
+    NextAttemptAt time.Time          `bson:"next_attempt_at"`
+    CompletedAt   *time.Time         `bson:"completed_at,omitempty"`
+    ExpiresAt     *time.Time         `bson:"expires_at,omitempty"`
+    DownloadToken string             `bson:"download_token,omitempty"`
+    SizeBytes     int64              `bson:"size_bytes,omitempty"`
+}
+
+// exportFile es un archivo dentro del ZIP
+type exportFile struct {
+    name string
+    data []byte
+}
+
+// exportJSON serializa las respuestas de los servicios con los nombres de
+// campo del proto y sin omitir los vacíos
+var exportJSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+func exportPath(id primitive.ObjectID) string {
+    return filepath.Join(exportDir, id.Hex()+".zip")
+}
+
+func exportToProto(e *exportDoc) *pb.DataExport {
+    export := &pb.DataExport{
+        Id:          e.ID.Hex(),
+        UserId:      e.UserID,
+        Status:      e.Status,
+        Error:       e.Error,
+        RequestedAt: e.RequestedAt.Format(time.RFC3339),
+        SizeBytes:   e.SizeBytes,
+        Attempts:    e.Attempts,
+    }
+    if e.CompletedAt != nil {
+        export.CompletedAt = e.CompletedAt.Format(time.RFC3339)
+    }
+    if e.ExpiresAt != nil {
+        export.ExpiresAt = e.ExpiresAt.Format(time.RFC3339)
+    }
+    if e.Status == exportStatusCompleted && e.ExpiresAt != nil && time.Now().Before(*e.ExpiresAt) {
+        export.DownloadToken = e.DownloadToken
+    }
+    return export
+}
+
+// StartDataExport encola la exportación de los datos de un usuario. Si ya
+// tiene una en curso devuelve esa.
+func (s *server) StartDataExport(ctx context.Context, req *pb.StartDataExportRequest) (*pb.DataExport, error) {
+    if err := requireSelfOr(ctx, req.UserId, "users:read"); err != nil {
+        return nil, err
+    }
+    if _, err := s.users.Get(ctx, req.UserId); err != nil {
+        return nil, readError(err)
+    }
+
+    current, err := s.exports.Active(ctx, req.UserId)
+    if err == nil {
+        return exportToProto(current), nil
+    }
+    if !errors.Is(err, errExportNotFound) {
+        return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+
+    now := time.Now().UTC()
+    export := &exportDoc{
+        ID:            primitive.NewObjectID(),
+        UserID:        req.UserId,
+        Status:        exportStatusPending,
+        RequestedAt:   now,
+        NextAttemptAt: now,
+    }
+    if err := s.exports.Create(ctx, export); err != nil {
+        return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+    log.Printf("Exportación %s del usuario %d encolada", export.ID.Hex(), req.UserId)
+    return exportToProto(export), nil
+}
+
+// GetDataExport devuelve el estado de una exportación del usuario
+func (s *server) GetDataExport(ctx context.Context, req *pb.GetDataExportRequest) (*pb.DataExport, error) {
+    if err := requireSelfOr(ctx, req.UserId, "users:read"); err != nil {
+        return nil, err
+    }
+    id, err := primitive.ObjectIDFromHex(req.Id)
+    if err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "ID de exportación inválido")
+    }
+    export, err := s.exports.Get(ctx, id, req.UserId)
+    if errors.Is(err, errExportNotFound) {
+        return nil, status.Errorf(codes.NotFound, "Exportación no encontrada")
+    }
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+    return exportToProto(export), nil
+}
+
+// DownloadDataExport envía el ZIP de una exportación completada a quien
+// presente su token, mientras no haya vencido
+func (s *server) DownloadDataExport(req *pb.DownloadDataExportRequest, stream pb.UserService_DownloadDataExportServer) error {
+    ctx := stream.Context()
+    if req.Token == "" {
+        return status.Errorf(codes.InvalidArgument, "Token de descarga requerido")
+    }
+
+    export, err := s.exports.GetByToken(ctx, req.Token)
+    if errors.Is(err, errExportNotFound) {
+        return status.Errorf(codes.NotFound, "Enlace de descarga inválido o vencido")
+    }
+    if err != nil {
+        return status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+    if export.ExpiresAt == nil || !time.Now().Before(*export.ExpiresAt) {
+        return status.Errorf(codes.NotFound, "Enlace de descarga inválido o vencido")
+    }
+
+    // Un usuario eliminado no descarga sus datos hasta que lo restauren
+    _, err = s.users.Get(ctx, export.UserID)
+    if errors.Is(err, errUserNotFound) {
+        return status.Errorf(codes.NotFound, "Enlace de descarga inválido o vencido")
+    }
+    if err != nil {
+        return status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+
+    f, err := os.Open(exportPath(export.ID))
+    if err != nil {
+        return status.Errorf(codes.Internal, "Error abriendo la exportación: %v", err)
+    }
+    defer f.Close()
+    info, err := f.Stat()
+    if err != nil {
+        return status.Errorf(codes.Internal, "Error abriendo la exportación: %v", err)
+    }
+
+    chunk := &pb.DataExportChunk{
+        Filename:  fmt.Sprintf("streamflow-datos-%d-%s.zip", export.UserID, export.CompletedAt.Format("20060102")),
+        SizeBytes: info.Size(),
+    }
+    buf := make([]byte, exportChunkSize)
+    for {
+        n, err := f.Read(buf)
+        if n > 0 {
+            chunk.Data = buf[:n]
+            if sendErr := stream.Send(chunk); sendErr != nil {
+                return sendErr
+            }
+            chunk = &pb.DataExportChunk{}
+        }
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return status.Errorf(codes.Internal, "Error leyendo la exportación: %v", err)
+        }
+    }
+    // Un archivo vacío igual manda el nombre
+    if chunk.Filename != "" {
+        return stream.Send(chunk)
+    }
+    return nil
+}
+
+// startExportWorker procesa las exportaciones pendientes de a una
+func (s *server) startExportWorker() {
+    // Las que quedaron en running porque el proceso se detuvo se retoman
+    requeued, err := s.exports.RequeueRunning(context.Background(), time.Now().UTC())
+    if err != nil {
+        log.Printf("Error retomando exportaciones interrumpidas: %v", err)
+    } else if requeued > 0 {
+        log.Printf("Exportaciones interrumpidas retomadas: %d", requeued)
+    }
+
+    for {
+        processed, err := s.processNextExport(context.Background())
+        if err != nil {
+            log.Printf("Error procesando exportaciones: %v", err)
+        }
+        if !processed {
+            time.Sleep(exportPollInterval)
+        }
+    }
+}
+
+// processNextExport toma la próxima exportación pendiente y la genera.
+// Devuelve false si no había ninguna.
+func (s *server) processNextExport(ctx context.Context) (bool, error) {
+    export, err := s.exports.ClaimNext(ctx, time.Now().UTC())
+    if errors.Is(err, errExportNotFound) {
+        return false, nil
+    }
+    if err != nil {
+        return false, fmt.Errorf("tomando exportación pendiente: %w", err)
+    }
+
+    size, err := s.buildExport(ctx, export)
+    if err != nil {
+        return true, s.failExport(ctx, export, err)
+    }
+
+    now := time.Now().UTC()
+    expiresAt := now.Add(exportTTL)
+    token, err := newDownloadToken()
+    if err != nil {
+        return true, s.failExport(ctx, export, err)
+    }
+    export.CompletedAt = &now
+    export.ExpiresAt = &expiresAt
+    export.DownloadToken = token
+    export.SizeBytes = size
+    completed, err := s.exports.Complete(ctx, export)
+    if err != nil {
+        return true, fmt.Errorf("completando exportación %s: %w", export.ID.Hex(), err)
+    }
+    if !completed {
+        // El usuario se purgó mientras se generaba: el archivo no debe quedar
+        removeExportFile(export.ID)
+        return true, nil
+    }
+    log.Printf("Exportación %s del usuario %d completada (%d bytes)", export.ID.Hex(), export.UserID, size)
+    return true, nil
+}
+
+// failExport registra el error y vuelve a encolar la exportación, salvo que
+// se hayan agotado los intentos
+func (s *server) failExport(ctx context.Context, export *exportDoc, cause error) error {
+    removeExportFile(export.ID)
+
+    next, nextAttemptAt := exportStatusFailed, time.Time{}
+    if export.Attempts >= exportMaxAttempts || errors.Is(cause, errExportUserGone) {
+        log.Printf("Exportación %s del usuario %d fallida: %v", export.ID.Hex(), export.UserID, cause)
+    } else {
+        next = exportStatusPending
+        nextAttemptAt = time.Now().UTC().Add(exportRetryDelay * time.Duration(export.Attempts))
+        log.Printf("Exportación %s del usuario %d falló (intento %d), se reintenta: %v", export.ID.Hex(), export.UserID, export.Attempts, cause)
+    }
+    if err := s.exports.Fail(ctx, export.ID, next, cause.Error(), nextAttemptAt); err != nil {
+        return fmt.Errorf("registrando fallo de la exportación %s: %w", export.ID.Hex(), err)
+    }
+    return nil
+}
+
+func newDownloadToken() (string, error) {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return "", fmt.Errorf("generando token de descarga: %w", err)
+    }
+    return hex.EncodeToString(b), nil
+}
+
+func removeExportFile(id primitive.ObjectID) {
+    for _, path := range []string{exportPath(id), exportPath(id) + ".tmp"} {
+        if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+            log.Printf("Error borrando %s: %v", path, err)
+        }
+    }
+}
+
+// buildExport junta los datos del usuario y escribe el ZIP. Devuelve su
+// tamaño en bytes.
+func (s *server) buildExport(ctx context.Context, export *exportDoc) (int64, error) {
+    files, err := s.collectExportFiles(ctx, export)
+    if err != nil {
+        return 0, err
+    }
+
+    if err := os.MkdirAll(exportDir, 0o700); err != nil {
+        return 0, fmt.Errorf("creando %s: %w", exportDir, err)
+    }
+    path := exportPath(export.ID)
+    tmp := path + ".tmp"
+    f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+    if err != nil {
+        return 0, fmt.Errorf("creando el ZIP: %w", err)
+    }
+    zw := zip.NewWriter(f)
+    for _, file := range files {
+        w, err := zw.Create(file.name)
+        if err == nil {
+            _, err = w.Write(file.data)
+        }
+        if err != nil {
+            f.Close()
+            return 0, fmt.Errorf("escribiendo %s: %w", file.name, err)
+        }
+    }
+    if err := zw.Close(); err != nil {
+        f.Close()
+        return 0, fmt.Errorf("cerrando el ZIP: %w", err)
+    }
+    if err := f.Close(); err != nil {
+        return 0, fmt.Errorf("cerrando el ZIP: %w", err)
+    }
+    if err := os.Rename(tmp, path); err != nil {
+        return 0, fmt.Errorf("guardando el ZIP: %w", err)
+    }
+
+    info, err := os.Stat(path)
+    if err != nil {
+        return 0, fmt.Errorf("leyendo el ZIP: %w", err)
+    }
+    return info.Size(), nil
+}
+
+// collectExportFiles arma los archivos del ZIP. Si falla cualquier servicio
+// la exportación falla entera: una copia parcial no sirve al usuario.
+func (s *server) collectExportFiles(ctx context.Context, export *exportDoc) ([]exportFile, error) {
+    profile, err := s.exportProfile(ctx, export.UserID)
+    if err != nil {
+        return nil, err
+    }
+
+    sources := []struct {
+        name    string
+        file    string
+        collect func(context.Context, int32) (interface{}, error)
+    }{
+        {"billing", "facturas.json", exportInvoices},
+        {"playlists", "listas_reproduccion.json", exportPlaylists},
+        {"social", "interacciones.json", exportInteractions},
+        {"monitoring", "actividad.json", exportActions},
+    }
+
+    files := []exportFile{{name: "perfil.json"}}
+    profileData, err := json.MarshalIndent(profile, "", "  ")
+    if err != nil {
+        return nil, fmt.Errorf("serializando el perfil: %w", err)
+    }
+    files[0].data = profileData
+    if avatar, err := s.exportAvatar(ctx, export.UserID); err != nil {
+        return nil, err
+    } else if avatar != nil {
+        files = append(files, exportFile{name: "avatar.jpg", data: avatar})
+    }
+
+    for _, source := range sources {
+        sourceCtx, cancel := context.WithTimeout(ctx, exportSourceTimeout)
+        value, err := source.collect(sourceCtx, export.UserID)
+        cancel()
+        if err != nil {
+            return nil, fmt.Errorf("%s: %w", source.name, err)
+        }
+        data, err := json.MarshalIndent(value, "", "  ")
+        if err != nil {
+            return nil, fmt.Errorf("%s: serializando: %w", source.name, err)
+        }
+        files = append(files, exportFile{name: source.file, data: data})
+    }
+
+    names := make([]string, 0, len(files))
+    for _, file := range files {
+        names = append(names, file.name)
+    }
+    manifest, err := json.MarshalIndent(map[string]interface{}{
+        "export_id":    export.ID.Hex(),
+        "user_id":      export.UserID,
+        "generated_at": time.Now().UTC().Format(time.RFC3339),
+        "files":        names,
+    }, "", "  ")
+    if err != nil {
+        return nil, fmt.Errorf("serializando el manifiesto: %w", err)
+    }
+    return append([]exportFile{{name: "manifiesto.json", data: manifest}}, files...), nil
+}
+
+// exportProfile lee el documento del usuario sin la contraseña ni los
+// eventos pendientes de publicar
+func (s *server) exportProfile(ctx context.Context, userID int32) (bson.M, error) {
+    user, err := s.users.Get(ctx, userID)
+    if errors.Is(err, errUserNotFound) {
+        return nil, errExportUserGone
+    }
+    if err != nil {
+        return nil, fmt.Errorf("leyendo el perfil: %w", err)
+    }
+    delete(user, "_id")
+    delete(user, "password")
+    delete(user, "outbox")
+    if pending, ok := user["pending_email"].(bson.M); ok {
+        delete(pending, "token_hash")
+    }
+    return user, nil
+}
+
+// exportAvatar lee la imagen del avatar; nil si el usuario no tiene
+func (s *server) exportAvatar(ctx context.Context, userID int32) ([]byte, error) {
+    avatar, err := s.GetAvatar(ctx, &pb.GetAvatarRequest{UserId: userID})
+    if status.Code(err) == codes.NotFound {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("leyendo el avatar: %w", err)
+    }
+    return avatar.Data, nil
+}
+
+// dialExportSource conecta con un servicio y devuelve un contexto con la
+// metadata que identifica al usuario exportado
+func dialExportSource(ctx context.Context, addr string, userID int32, md ...string) (*grpc.ClientConn, context.Context, error) {
+    conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+    if err != nil {
+        return nil, nil, fmt.Errorf("conectando a %s: %w", addr, err)
+    }
+    pairs := append([]string{"user_id", strconv.Itoa(int(userID))}, md...)
+    return conn, metadata.AppendToOutgoingContext(ctx, pairs...), nil
+}
+
+func protoValue(m proto.Message) (json.RawMessage, error) {
+    data, err := exportJSON.Marshal(m)
+    if err != nil {
+        return nil, err
+    }
+    return json.RawMessage(data), nil
+}
+
+// exportInvoices pide a billing las facturas del usuario
+func exportInvoices(ctx context.Context, userID int32) (interface{}, error) {
+    conn, ctx, err := dialExportSource(ctx, billingServiceURL, userID, "permissions", "invoices:read")
+    if err != nil {
+        return nil, err
+    }
+    defer conn.Close()
+
+    resp, err := pb.NewBillingServiceClient(conn).ListInvoicesByUser(ctx, &pb.ListInvoicesByUserRequest{})
+    if err != nil {
+        return nil, err
+    }
+    return protoValue(resp)
+}
+
+// exportPlaylists pide a playlists las listas del usuario con sus videos
+func exportPlaylists(ctx context.Context, userID int32) (interface{}, error) {
+    conn, ctx, err := dialExportSource(ctx, playlistsServiceURL, userID)
+    if err != nil {
+        return nil, err
+    }
+    defer conn.Close()
+
+    client := pb.NewPlaylistsServiceClient(conn)
+    resp, err := client.ListPlaylists(ctx, &emptypb.Empty{})
+    if err != nil {
+        return nil, err
+    }
+
+    playlists := make([]map[string]json.RawMessage, 0, len(resp.Playlists))
+    for _, playlist := range resp.Playlists {
+        videos, err := client.ListVideos(ctx, &pb.ListVideosRequest{PlaylistId: playlist.Id})
+        if err != nil {
+            return nil, fmt.Errorf("videos de la lista %d: %w", playlist.Id, err)
+        }
+        playlistJSON, err := protoValue(playlist)
+        if err != nil {
+            return nil, err
+        }
+        videosJSON, err := protoValue(videos)
+        if err != nil {
+            return nil, err
+        }
+        playlists = append(playlists, map[string]json.RawMessage{"playlist": playlistJSON, "videos": videosJSON})
+    }
+    return map[string]interface{}{"playlists": playlists}, nil
+}
+
+// exportInteractions pide a social los likes y comentarios del usuario
+func exportInteractions(ctx context.Context, userID int32) (interface{}, error) {
+    id := strconv.Itoa(int(userID))
+    conn, ctx, err := dialExportSource(ctx, socialServiceURL, userID, "authorization", id)
+    if err != nil {
+        return nil, err
+    }
+    defer conn.Close()
+
+    resp, err := pb.NewSocialInteractionsClient(conn).ListUserInteractions(ctx, &pb.ListUserInteractionsRequest{UserId: id})
+    if err != nil {
+        return nil, err
+    }
+    return protoValue(resp)
+}
+
+// exportActions pide a monitoring las acciones registradas del usuario
+func exportActions(ctx context.Context, userID int32) (interface{}, error) {
+    conn, ctx, err := dialExportSource(ctx, monitoringServiceURL, userID)
+    if err != nil {
+        return nil, err
+    }
+    defer conn.Close()
+
+    resp, err := pb.NewMonitoringServiceClient(conn).ListUserActions(ctx, &pb.ListUserActionsRequest{UserId: int64(userID)})
+    if err != nil {
+        return nil, err
+    }
+    return protoValue(resp)
+}
+
+// startExportCleanupJob borra los archivos de las exportaciones vencidas
+func (s *server) startExportCleanupJob() {
+    for {
+        expired, err := s.expireExports(context.Background())
+        if err != nil {
+            log.Printf("Error limpiando exportaciones vencidas: %v", err)
+        }
+        if expired > 0 {
+            log.Printf("Exportaciones vencidas: %d", expired)
+        }
+        time.Sleep(exportCleanupInterval)
+    }
+}
+
+func (s *server) expireExports(ctx context.Context) (int, error) {
+    docs, err := s.exports.Expired(ctx, time.Now().UTC())
+    if err != nil {
+        return 0, fmt.Errorf("buscando exportaciones vencidas: %w", err)
+    }
+
+    expired := 0
+    for _, export := range docs {
+        removeExportFile(export.ID)
+        if err := s.exports.Expire(ctx, export.ID); err != nil {
+            return expired, fmt.Errorf("venciendo exportación %s: %w", export.ID.Hex(), err)
+        }
+        expired++
+    }
+    return expired, nil
+}
+
+// deleteUserExports borra las exportaciones de un usuario purgado, archivos
+// incluidos
+func (s *server) deleteUserExports(ctx context.Context, userID int32) error {
+    ids, err := s.exports.DeleteByUser(ctx, userID)
+    if err != nil {
+        return fmt.Errorf("borrando exportaciones del usuario %d: %w", userID, err)
+    }
+    for _, id := range ids {
+        removeExportFile(id)
+    }
+    return nil
+}
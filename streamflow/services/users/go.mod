@@ -13,6 +13,8 @@ require (
 	github.com/go-sql-driver/mysql v1.7.1
 	github.com/streadway/amqp v1.1.0
 	go.mongodb.org/mongo-driver v1.17.4
+	golang.org/x/crypto v0.36.0
+	streamflow/shared v0.0.0
 )
 
 require (
@@ -23,10 +25,11 @@ require (
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
 	github.com/youmark/pkcs8 v0.0.0-20240726163527-a2c0da244d78 // indirect
-	golang.org/x/crypto v0.36.0 // indirect
 	golang.org/x/net v0.38.0 // indirect
 	golang.org/x/sync v0.12.0 // indirect
 	golang.org/x/sys v0.31.0 // indirect
 	golang.org/x/text v0.23.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250324211829-b45e905df463 // indirect
 )
+
+replace streamflow/shared => ../../shared
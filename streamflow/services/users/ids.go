@@ -0,0 +1,35 @@
+package main
+
+import (
+    "context"
+
+    "go.mongodb.org/mongo-driver/bson"
+)
+
+// El ID público de un usuario es el campo "id" del documento, asignado desde
+// un contador en la colección counters. Antes se derivaba del timestamp del
+// ObjectID, lo que repetía IDs entre usuarios creados en el mismo segundo
+// (ver migrateIDs en repository_mongo.go).
+
+// userIDCounter es el documento de counters que guarda el último ID asignado
+const userIDCounter = "users"
+
+// userIDSequenceStart deja libres los IDs bajos, que auth usa para cuentas
+// creadas directamente en su base (el administrador por defecto es el 1)
+const userIDSequenceStart = 1000
+
+// docUserID lee el ID público de un documento de usuario
+func docUserID(user bson.M) int32 {
+    switch id := user["id"].(type) {
+    case int32:
+        return id
+    case int64:
+        return int32(id)
+    }
+    return 0
+}
+
+// nextUserID reserva el siguiente ID del contador
+func (s *server) nextUserID(ctx context.Context) (int32, error) {
+    return s.users.ReserveIDs(ctx, 1)
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+    "bytes"
+    "context"
+    "encoding/csv"
+    "errors"
+    "fmt"
+    "io"
+    "log"
+    "strings"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "streamflow/shared/passwordhash"
+    pb "users-service/pb"
+)
+
+// ImportUsers crea usuarios desde un CSV. Cada fila pasa por las mismas
+// reglas que CreateUser y un email no puede repetirse en el archivo ni
+// pertenecer a un usuario existente. Las filas válidas se insertan en lotes de
+// importBatchSize, cada una con su user.created en el outbox; las inválidas
+// se informan con su número de línea y no impiden crear las demás.
+//
+// La importación solo crea clientes. Otro rol pasa por ChangeUserRole, que lo
+// valida contra los roles de auth y publica user.role_changed para la
+// auditoría.
+
+const (
+    importMaxRows   = 1000
+    importBatchSize = 100
+)
+
+// Columnas del CSV; confirm_password es opcional
+var (
+    importRequiredColumns = []string{"first_name", "last_name", "email", "password", "role"}
+    importOptionalColumns = []string{"confirm_password"}
+)
+
+// importRow es una fila ya leída del archivo
+type importRow struct {
+    line int
+    req  *pb.CreateUserRequest
+}
+
+// parseImportCSV lee el encabezado y las filas. Un archivo mal formado falla
+// entero con InvalidArgument.
+func parseImportCSV(data []byte) ([]importRow, error) {
+    data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // BOM de Excel
+    reader := csv.NewReader(bytes.NewReader(data))
+    reader.TrimLeadingSpace = true
+
+    header, err := reader.Read()
+    if err == io.EOF {
+        return nil, status.Errorf(codes.InvalidArgument, "El archivo CSV está vacío")
+    }
+    if err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "CSV inválido: %v", err)
+    }
+
+    known := make(map[string]bool)
+    for _, column := range append(importRequiredColumns, importOptionalColumns...) {
+        known[column] = true
+    }
+    columns := make(map[string]int, len(header))
+    for i, name := range header {
+        name = strings.ToLower(strings.TrimSpace(name))
+        if !known[name] {
+            return nil, status.Errorf(codes.InvalidArgument, "Columna desconocida en el CSV: %q", name)
+        }
+        if _, dup := columns[name]; dup {
+            return nil, status.Errorf(codes.InvalidArgument, "Columna repetida en el CSV: %q", name)
+        }
+        columns[name] = i
+    }
+    for _, name := range importRequiredColumns {
+        if _, ok := columns[name]; !ok {
+            return nil, status.Errorf(codes.InvalidArgument, "Falta la columna %q en el CSV", name)
+        }
+    }
+
+    field := func(record []string, name string) string {
+        i, ok := columns[name]
+        if !ok || i >= len(record) {
+            return ""
+        }
+        return strings.TrimSpace(record[i])
+    }
+
+    var rows []importRow
+    for {
+        record, err := reader.Read()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return nil, status.Errorf(codes.InvalidArgument, "CSV inválido: %v", err)
+        }
+        line, _ := reader.FieldPos(0)
+        if len(rows) == importMaxRows {
+            return nil, status.Errorf(codes.InvalidArgument, "El CSV supera el máximo de %d filas", importMaxRows)
+        }
+
+        req := &pb.CreateUserRequest{
+            FirstName: field(record, "first_name"),
+            LastName:  field(record, "last_name"),
+            Email:     field(record, "email"),
+            Password:  field(record, "password"),
+            Role:      field(record, "role"),
+        }
+        // Sin rol, como en el registro, la fila crea un cliente
+        if req.Role == "" || strings.EqualFold(req.Role, customerRole) {
+            req.Role = customerRole
+        }
+        // Sin columna de confirmación la contraseña se confirma a sí misma
+        req.ConfirmPassword = req.Password
+        if _, ok := columns["confirm_password"]; ok {
+            req.ConfirmPassword = field(record, "confirm_password")
+        }
+        rows = append(rows, importRow{line: line, req: req})
+    }
+    if len(rows) == 0 {
+        return nil, status.Errorf(codes.InvalidArgument, "El CSV no tiene filas")
+    }
+    return rows, nil
+}
+
+// ImportUsers valida el CSV y, salvo en dry run, crea los usuarios válidos
+func (s *server) ImportUsers(ctx context.Context, req *pb.ImportUsersRequest) (*pb.ImportUsersResponse, error) {
+    if err := requirePermission(ctx, "users:write"); err != nil {
+        return nil, err
+    }
+    rows, err := parseImportCSV(req.Csv)
+    if err != nil {
+        return nil, err
+    }
+
+    response := &pb.ImportUsersResponse{DryRun: req.DryRun, TotalRows: int32(len(rows))}
+    rowError := func(row importRow, err error) {
+        message := err.Error()
+        if st, ok := status.FromError(err); ok {
+            message = st.Message()
+        }
+        response.Errors = append(response.Errors, &pb.ImportRowError{Row: int32(row.line), Email: row.req.Email, Error: message})
+    }
+
+    // Reglas de CreateUser, solo clientes y emails repetidos dentro del archivo
+    var valid []importRow
+    seen := make(map[string]int)
+    for _, row := range rows {
+        if err := validateNewUser(row.req); err != nil {
+            rowError(row, err)
+            continue
+        }
+        if row.req.Role != customerRole {
+            rowError(row, fmt.Errorf("La importación solo crea usuarios %s; el rol %q se asigna después desde el cambio de rol", customerRole, row.req.Role))
+            continue
+        }
+        if first, dup := seen[row.req.Email]; dup {
+            rowError(row, fmt.Errorf("El email está repetido en la fila %d", first))
+            continue
+        }
+        seen[row.req.Email] = row.line
+        valid = append(valid, row)
+    }
+
+    // Emails que ya pertenecen a un usuario
+    valid, err = s.dropExistingEmails(ctx, valid, rowError)
+    if err != nil {
+        return nil, err
+    }
+    response.ValidRows = int32(len(valid))
+
+    if !req.DryRun {
+        for start := 0; start < len(valid); start += importBatchSize {
+            end := start + importBatchSize
+            if end > len(valid) {
+                end = len(valid)
+            }
+            ids, err := s.importBatch(ctx, valid[start:end], rowError)
+            if err != nil {
+                return nil, err
+            }
+            response.CreatedIds = append(response.CreatedIds, ids...)
+        }
+        response.Created = int32(len(response.CreatedIds))
+        log.Printf("Importación de usuarios: %d filas, %d creados, %d con errores",
+            response.TotalRows, response.Created, len(response.Errors))
+    }
+
+    response.Failed = int32(len(response.Errors))
+    return response, nil
+}
+
+// dropExistingEmails descarta las filas cuyo email ya pertenece a un usuario
+// no eliminado
+func (s *server) dropExistingEmails(ctx context.Context, rows []importRow, rowError func(importRow, error)) ([]importRow, error) {
+    if len(rows) == 0 {
+        return rows, nil
+    }
+    emails := make([]string, 0, len(rows))
+    for _, row := range rows {
+        emails = append(emails, row.req.Email)
+    }
+    taken, err := s.users.EmailsInUse(ctx, emails, 0)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+
+    kept := rows[:0]
+    for _, row := range rows {
+        if taken[row.req.Email] {
+            rowError(row, errors.New("El email ya está registrado"))
+            continue
+        }
+        kept = append(kept, row)
+    }
+    return kept, nil
+}
+
+// importBatch crea un lote de usuarios y devuelve los IDs creados. Las filas
+// que fallan al insertarse se informan como errores de fila; solo devuelve
+// error si el lote no se pudo intentar.
+func (s *server) importBatch(ctx context.Context, rows []importRow, rowError func(importRow, error)) ([]int32, error) {
+    firstID, err := s.users.ReserveIDs(ctx, len(rows))
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+
+    docs := make([]bson.M, 0, len(rows))
+    batch := make([]importRow, 0, len(rows))
+    ids := make([]int32, 0, len(rows))
+    for i, row := range rows {
+        // Hash de la contraseña; auth recibe el mismo hash para las credenciales de login
+        hashedPassword, err := passwordhash.Hash(row.req.Password)
+        if err != nil {
+            rowError(row, fmt.Errorf("Error procesando la contraseña: %v", err))
+            continue
+        }
+        user, err := newUserDocument(firstID+int32(i), row.req, hashedPassword)
+        if err != nil {
+            rowError(row, fmt.Errorf("Error armando el evento: %v", err))
+            continue
+        }
+        docs = append(docs, user)
+        batch = append(batch, row)
+        ids = append(ids, firstID+int32(i))
+    }
+    if len(docs) == 0 {
+        return nil, nil
+    }
+
+    failed, err := s.users.CreateMany(ctx, docs)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB insert error: %v", err)
+    }
+    for i := range batch {
+        if insertErr, ok := failed[i]; ok {
+            rowError(batch[i], fmt.Errorf("DB insert error: %s", insertErr))
+        }
+    }
+    created := ids[:0]
+    for i, id := range ids {
+        if failed[i] == nil {
+            created = append(created, id)
+        }
+    }
+    return created, nil
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "strings"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    pb "users-service/pb"
+)
+
+// ListUsers pagina por keyset: el page_token guarda la clave de orden y el
+// _id del último usuario devuelto, así las altas y bajas entre páginas no
+// repiten ni saltean resultados. La fecha de alta se filtra y ordena por el
+// timestamp del ObjectID, que está indexado y no depende del formato del
+// campo created_at. Los filtros email y name buscan el texto en cualquier
+// parte del subdocumento search, que guarda en minúsculas el email y el
+// nombre completo: "garcia" encuentra a maria.garcia@… y "ana lópez" a quien
+// se llama Ana López. La regex sin anclar recorre el índice de cada campo,
+// no la colección.
+
+const (
+    defaultListPageSize = 50
+    maxListPageSize     = 200
+)
+
+// listSortFields traduce los valores de order_by al campo del documento
+var listSortFields = map[string]string{
+    "created_at": "_id",
+    "id":         "id",
+    "email":      "email",
+    "first_name": "first_name",
+    "last_name":  "last_name",
+}
+
+// listPageToken es el contenido (en base64) de next_page_token
+type listPageToken struct {
+    Value    interface{} `json:"v,omitempty"`
+    ObjectID string      `json:"o"`
+    Query    string      `json:"q"`
+}
+
+// ListUsers busca usuarios no eliminados con filtros, orden y paginación
+func (s *server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+    if err := requirePermission(ctx, "users:read"); err != nil {
+        return nil, err
+    }
+    role, err := s.resolveListRole(ctx, req.Role)
+    if err != nil {
+        return nil, err
+    }
+    query, err := listUsersQuery(req, role)
+    if err != nil {
+        return nil, err
+    }
+    query.SortField, query.Descending, err = parseListOrder(req.OrderBy)
+    if err != nil {
+        return nil, err
+    }
+
+    pageSize := int(req.PageSize)
+    if pageSize <= 0 {
+        pageSize = defaultListPageSize
+    }
+    if pageSize > maxListPageSize {
+        pageSize = maxListPageSize
+    }
+    query.Limit = pageSize + 1
+
+    queryHash := listQueryHash(req)
+    if req.PageToken != "" {
+        token, err := decodeListPageToken(req.PageToken, queryHash)
+        if err != nil {
+            return nil, err
+        }
+        if query.After, err = listCursorFromToken(query.SortField, token); err != nil {
+            return nil, err
+        }
+    }
+
+    docs, total, err := s.users.List(ctx, query)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+
+    response := &pb.ListUsersResponse{TotalSize: total}
+    if len(docs) > pageSize {
+        docs = docs[:pageSize]
+        response.NextPageToken = encodeListPageToken(docs[len(docs)-1], query.SortField, queryHash)
+    }
+    for _, user := range docs {
+        response.Users = append(response.Users, &pb.UserResponse{
+            Id:        docUserID(user),
+            FirstName: user["first_name"].(string),
+            LastName:  user["last_name"].(string),
+            Email:     user["email"].(string),
+            Role:      user["role"].(string),
+            CreatedAt: user["created_at"].(string),
+            Status:    userStatus(user),
+            Version:   docVersion(user),
+        })
+    }
+    return response, nil
+}
+
+// listUsersQuery valida los filtros de la solicitud; role es el nombre ya
+// resuelto por resolveListRole
+func listUsersQuery(req *pb.ListUsersRequest, role string) (userQuery, error) {
+    query := userQuery{
+        Email: searchKey(req.Email),
+        Name:  searchKey(req.Name),
+        Role:  role,
+    }
+    switch req.Status {
+    case "", statusActive, statusPendingVerification:
+        query.Status = req.Status
+    default:
+        return query, status.Errorf(codes.InvalidArgument, "status inválido: %q", req.Status)
+    }
+
+    if req.CreatedAfter != "" {
+        t, err := parseListDate(req.CreatedAfter)
+        if err != nil {
+            return query, status.Errorf(codes.InvalidArgument, "created_after inválido: %v", err)
+        }
+        query.CreatedAfter = t
+    }
+    if req.CreatedBefore != "" {
+        t, err := parseListDate(req.CreatedBefore)
+        if err != nil {
+            return query, status.Errorf(codes.InvalidArgument, "created_before inválido: %v", err)
+        }
+        query.CreatedBefore = t
+    }
+    return query, nil
+}
+
+// searchKey normaliza un valor para el subdocumento search y para los
+// filtros que lo consultan
+func searchKey(value string) string {
+    return strings.ToLower(strings.TrimSpace(value))
+}
+
+// searchFields arma el subdocumento search de un usuario
+func searchFields(email, firstName, lastName string) bson.M {
+    return bson.M{
+        "email":     searchKey(email),
+        "full_name": fullNameKey(firstName, lastName),
+    }
+}
+
+// fullNameKey normaliza nombre y apellido juntos, así el filtro name
+// encuentra tanto "ana" como "ana lópez"
+func fullNameKey(firstName, lastName string) string {
+    return searchKey(strings.TrimSpace(firstName) + " " + strings.TrimSpace(lastName))
+}
+
+// resolveListRole compara el filtro role con los roles de los usuarios sin
+// distinguir mayúsculas y devuelve el nombre tal como está guardado. Un rol
+// que ningún usuario tiene es un error, no una lista vacía: así ?role=admin
+// encuentra a los "Administrador" escritos de otra forma y un rol mal
+// escrito no pasa por una búsqueda sin resultados.
+func (s *server) resolveListRole(ctx context.Context, role string) (string, error) {
+    role = strings.TrimSpace(role)
+    if role == "" {
+        return "", nil
+    }
+    roles, err := s.users.Roles(ctx)
+    if err != nil {
+        return "", status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+    for _, existing := range roles {
+        if strings.EqualFold(existing, role) {
+            return existing, nil
+        }
+    }
+    return "", status.Errorf(codes.InvalidArgument, "role inválido: ningún usuario tiene el rol %q", role)
+}
+
+// parseListDate acepta RFC 3339 o una fecha YYYY-MM-DD (en UTC)
+func parseListDate(value string) (time.Time, error) {
+    if t, err := time.Parse(time.RFC3339, value); err == nil {
+        return t, nil
+    }
+    t, err := time.Parse("2006-01-02", value)
+    if err != nil {
+        return time.Time{}, fmt.Errorf("se espera RFC 3339 o YYYY-MM-DD")
+    }
+    return t, nil
+}
+
+// parseListOrder interpreta order_by ("campo" o "campo desc")
+func parseListOrder(orderBy string) (field string, descending bool, err error) {
+    parts := strings.Fields(strings.ToLower(orderBy))
+    if len(parts) == 0 {
+        return "_id", true, nil
+    }
+    field, ok := listSortFields[parts[0]]
+    if !ok || len(parts) > 2 {
+        return "", false, status.Errorf(codes.InvalidArgument,
+            "order_by inválido: %q (created_at, id, email, first_name o last_name, opcionalmente con asc o desc)", orderBy)
+    }
+    if len(parts) == 2 {
+        switch parts[1] {
+        case "asc":
+        case "desc":
+            descending = true
+        default:
+            return "", false, status.Errorf(codes.InvalidArgument, "order_by inválido: %q", orderBy)
+        }
+    }
+    return field, descending, nil
+}
+
+// listQueryHash identifica los filtros y el orden, para rechazar un
+// page_token usado con otra búsqueda
+func listQueryHash(req *pb.ListUsersRequest) string {
+    sum := sha256.Sum256([]byte(strings.Join([]string{
+        req.Email, req.Name, req.Role, req.Status,
+        req.CreatedAfter, req.CreatedBefore, strings.ToLower(strings.TrimSpace(req.OrderBy)),
+    }, "\x00")))
+    return hex.EncodeToString(sum[:8])
+}
+
+func encodeListPageToken(last bson.M, sortField, queryHash string) string {
+    token := listPageToken{Query: queryHash}
+    if objectID, ok := last["_id"].(primitive.ObjectID); ok {
+        token.ObjectID = objectID.Hex()
+    }
+    if sortField != "_id" {
+        token.Value = last[sortField]
+    }
+    data, _ := json.Marshal(token)
+    return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListPageToken(value, queryHash string) (*listPageToken, error) {
+    data, err := base64.RawURLEncoding.DecodeString(value)
+    if err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "page_token inválido")
+    }
+    var token listPageToken
+    if err := json.Unmarshal(data, &token); err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "page_token inválido")
+    }
+    if token.Query != queryHash {
+        return nil, status.Errorf(codes.InvalidArgument, "page_token no corresponde a estos filtros u orden")
+    }
+    return &token, nil
+}
+
+// listCursorFromToken lee del page_token el último usuario de la página
+// anterior, con el valor de orden en el tipo del documento
+func listCursorFromToken(sortField string, token *listPageToken) (*listCursor, error) {
+    objectID, err := primitive.ObjectIDFromHex(token.ObjectID)
+    if err != nil {
+        return nil, status.Errorf(codes.InvalidArgument, "page_token inválido")
+    }
+    cursor := &listCursor{ObjectID: objectID}
+    if sortField == "_id" {
+        return cursor, nil
+    }
+
+    cursor.Value = token.Value
+    if sortField == "id" {
+        // JSON devuelve los números como float64
+        number, ok := token.Value.(float64)
+        if !ok {
+            return nil, status.Errorf(codes.InvalidArgument, "page_token inválido")
+        }
+        cursor.Value = int32(number)
+    }
+    return cursor, nil
+}
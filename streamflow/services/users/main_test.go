@@ -0,0 +1,681 @@
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "strconv"
+    "strings"
+    "sync"
+    "testing"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/types/known/fieldmaskpb"
+
+    pb "users-service/pb"
+)
+
+const testPassword = "Violeta-Marina-8123"
+
+// published es un mensaje que el servidor entregó al broker
+type published struct {
+    routingKey string
+    body       map[string]interface{}
+}
+
+// fakePublisher guarda lo publicado; fail decide si un mensaje falla
+type fakePublisher struct {
+    mu       sync.Mutex
+    messages []published
+    fail     func(routingKey string) error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, routingKey, messageID string, body []byte) error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+
+    if p.fail != nil {
+        if err := p.fail(routingKey); err != nil {
+            return err
+        }
+    }
+    var decoded map[string]interface{}
+    if err := json.Unmarshal(body, &decoded); err != nil {
+        return err
+    }
+    p.messages = append(p.messages, published{routingKey: routingKey, body: decoded})
+    return nil
+}
+
+func (p *fakePublisher) keys() []string {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+
+    keys := make([]string, len(p.messages))
+    for i, message := range p.messages {
+        keys[i] = message.routingKey
+    }
+    return keys
+}
+
+// last devuelve el último mensaje con esa routing key
+func (p *fakePublisher) last(t *testing.T, routingKey string) map[string]interface{} {
+    t.Helper()
+    p.mu.Lock()
+    defer p.mu.Unlock()
+
+    for i := len(p.messages) - 1; i >= 0; i-- {
+        if p.messages[i].routingKey == routingKey {
+            return p.messages[i].body
+        }
+    }
+    t.Fatalf("no se publicó %s (publicados: %v)", routingKey, p.messages)
+    return nil
+}
+
+func newTestServer(t *testing.T) (*server, *memoryUserRepository, *fakePublisher) {
+    t.Helper()
+    avatarDir = t.TempDir()
+    exportDir = t.TempDir()
+    users := newMemoryUserRepository()
+    publisher := &fakePublisher{}
+    return newServer(users, newMemoryErasureRepository(), newMemoryExportRepository(), publisher), users, publisher
+}
+
+func asUser(userID int32, permissions ...string) context.Context {
+    md := metadata.Pairs("user_id", strconv.Itoa(int(userID)))
+    for _, permission := range permissions {
+        md.Append("permissions", permission)
+    }
+    return metadata.NewIncomingContext(context.Background(), md)
+}
+
+// asAdmin es quien administra usuarios desde el gateway, sin ser ninguno de
+// los usuarios del test
+func asAdmin() context.Context {
+    return asUser(999, "users:read", "users:write", "users:purge")
+}
+
+func assertCode(t *testing.T, err error, want codes.Code) {
+    t.Helper()
+    if got := status.Code(err); got != want {
+        t.Fatalf("expected %s, got %s (%v)", want, got, err)
+    }
+}
+
+func createUser(t *testing.T, s *server, first, last, email string) *pb.UserResponse {
+    t.Helper()
+    user, err := s.CreateUser(context.Background(), &pb.CreateUserRequest{
+        FirstName: first, LastName: last, Email: email,
+        Password: testPassword, ConfirmPassword: testPassword,
+    })
+    if err != nil {
+        t.Fatalf("CreateUser: %v", err)
+    }
+    return user
+}
+
+// outboxKeys devuelve las routing keys pendientes del documento, esté o no
+// en la papelera
+func outboxKeys(t *testing.T, users *memoryUserRepository, id int32) []string {
+    t.Helper()
+    users.mu.Lock()
+    defer users.mu.Unlock()
+
+    user := users.find(id)
+    if user == nil {
+        t.Fatalf("usuario %d no encontrado", id)
+    }
+    var keys []string
+    outbox, _ := user["outbox"].(bson.A)
+    for _, raw := range outbox {
+        keys = append(keys, raw.(bson.M)["routing_key"].(string))
+    }
+    return keys
+}
+
+func assertKeys(t *testing.T, got []string, want ...string) {
+    t.Helper()
+    if len(got) != len(want) {
+        t.Fatalf("expected %v, got %v", want, got)
+    }
+    for i := range want {
+        if got[i] != want[i] {
+            t.Fatalf("expected %v, got %v", want, got)
+        }
+    }
+}
+
+func relay(t *testing.T, s *server) {
+    t.Helper()
+    if _, err := s.relayOutbox(context.Background()); err != nil {
+        t.Fatalf("relayOutbox: %v", err)
+    }
+}
+
+func TestCreateUserQueuesCreatedEvents(t *testing.T) {
+    s, users, publisher := newTestServer(t)
+    user := createUser(t, s, "Ana", "García", "ana@example.com")
+
+    if user.Id != userIDSequenceStart || user.Version != 1 || user.Status != statusPendingVerification {
+        t.Fatalf("unexpected user: %v", user)
+    }
+    assertKeys(t, outboxKeys(t, users, user.Id), "user.created", authUserCreatedKey)
+
+    relay(t, s)
+    if _, ok := publisher.last(t, "user.created")["password_hash"]; ok {
+        t.Fatal("user.created must not carry the password hash")
+    }
+    if publisher.last(t, authUserCreatedKey)["password_hash"] == "" {
+        t.Fatal("user.created.auth must carry the password hash")
+    }
+
+    _, err := s.CreateUser(context.Background(), &pb.CreateUserRequest{
+        FirstName: "Otra", LastName: "Ana", Email: "ana@example.com",
+        Password: testPassword, ConfirmPassword: testPassword,
+    })
+    assertCode(t, err, codes.AlreadyExists)
+}
+
+func TestUpdateWithStaleVersionIsAborted(t *testing.T) {
+    s, users, _ := newTestServer(t)
+    user := createUser(t, s, "Ana", "García", "ana@example.com")
+    ctx := asUser(user.Id)
+
+    updated, err := s.UpdateUser(ctx, &pb.UpdateUserRequest{
+        Id: user.Id, FirstName: "Anabel", ExpectedVersion: user.Version,
+    })
+    if err != nil {
+        t.Fatalf("UpdateUser: %v", err)
+    }
+    if updated.Version != 2 || updated.FirstName != "Anabel" {
+        t.Fatalf("unexpected update result: %v", updated)
+    }
+    assertKeys(t, outboxKeys(t, users, user.Id), "user.created", authUserCreatedKey, "user.updated")
+
+    _, err = s.UpdateUser(ctx, &pb.UpdateUserRequest{
+        Id: user.Id, FirstName: "Otra", ExpectedVersion: user.Version,
+    })
+    assertCode(t, err, codes.Aborted)
+
+    got, err := s.GetUser(ctx, &pb.GetUserRequest{Id: user.Id})
+    if err != nil {
+        t.Fatalf("GetUser: %v", err)
+    }
+    if got.FirstName != "Anabel" || got.Version != 2 {
+        t.Fatalf("stale update must not apply: %v", got)
+    }
+}
+
+func TestUnchangedUpdateKeepsVersion(t *testing.T) {
+    s, users, _ := newTestServer(t)
+    user := createUser(t, s, "Ana", "García", "ana@example.com")
+
+    updated, err := s.UpdateUser(asUser(user.Id), &pb.UpdateUserRequest{
+        Id:         user.Id,
+        FirstName:  "Ana",
+        UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"first_name"}},
+    })
+    if err != nil {
+        t.Fatalf("UpdateUser: %v", err)
+    }
+    if updated.Version != 1 {
+        t.Fatalf("expected version 1, got %d", updated.Version)
+    }
+    assertKeys(t, outboxKeys(t, users, user.Id), "user.created", authUserCreatedKey)
+}
+
+func TestEmailChangeStaysPendingUntilConfirmed(t *testing.T) {
+    s, _, publisher := newTestServer(t)
+    user := createUser(t, s, "Ana", "García", "ana@example.com")
+    ctx := asUser(user.Id)
+
+    staged, err := s.UpdateUser(ctx, &pb.UpdateUserRequest{Id: user.Id, Email: "ana.nueva@example.com"})
+    if err != nil {
+        t.Fatalf("UpdateUser: %v", err)
+    }
+    if staged.Email != "ana@example.com" || staged.PendingEmail != "ana.nueva@example.com" || staged.Version != 2 {
+        t.Fatalf("email change must stay pending: %v", staged)
+    }
+
+    relay(t, s)
+    requested := publisher.last(t, "user.email_change_requested")
+    if requested["email"] != "ana@example.com" || requested["new_email"] != "ana.nueva@example.com" {
+        t.Fatalf("unexpected user.email_change_requested: %v", requested)
+    }
+    token, _ := requested["token"].(string)
+
+    _, err = s.ConfirmEmailChange(ctx, &pb.ConfirmEmailChangeRequest{Token: "otro-token"})
+    assertCode(t, err, codes.InvalidArgument)
+
+    confirmed, err := s.ConfirmEmailChange(ctx, &pb.ConfirmEmailChangeRequest{Token: token})
+    if err != nil {
+        t.Fatalf("ConfirmEmailChange: %v", err)
+    }
+    if confirmed.Email != "ana.nueva@example.com" || confirmed.Version != 3 {
+        t.Fatalf("unexpected confirmation: %v", confirmed)
+    }
+    got, err := s.GetUser(ctx, &pb.GetUserRequest{Id: user.Id})
+    if err != nil {
+        t.Fatalf("GetUser: %v", err)
+    }
+    if got.Email != "ana.nueva@example.com" || got.PendingEmail != "" {
+        t.Fatalf("email change not applied: %v", got)
+    }
+
+    relay(t, s)
+    if changed := publisher.last(t, "user.email_changed"); changed["previous_email"] != "ana@example.com" {
+        t.Fatalf("unexpected user.email_changed: %v", changed)
+    }
+
+    // El token sirve una sola vez
+    _, err = s.ConfirmEmailChange(ctx, &pb.ConfirmEmailChangeRequest{Token: token})
+    assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestRequestingCurrentEmailCancelsPendingChange(t *testing.T) {
+    s, _, publisher := newTestServer(t)
+    user := createUser(t, s, "Ana", "García", "ana@example.com")
+    ctx := asUser(user.Id)
+
+    if _, err := s.UpdateUser(ctx, &pb.UpdateUserRequest{Id: user.Id, Email: "ana.nueva@example.com"}); err != nil {
+        t.Fatalf("UpdateUser: %v", err)
+    }
+    relay(t, s)
+    token, _ := publisher.last(t, "user.email_change_requested")["token"].(string)
+
+    cancelled, err := s.UpdateUser(ctx, &pb.UpdateUserRequest{Id: user.Id, Email: "ana@example.com"})
+    if err != nil {
+        t.Fatalf("UpdateUser: %v", err)
+    }
+    if cancelled.PendingEmail != "" || cancelled.Version != 3 {
+        t.Fatalf("pending change not cancelled: %v", cancelled)
+    }
+
+    _, err = s.ConfirmEmailChange(ctx, &pb.ConfirmEmailChangeRequest{Token: token})
+    assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestConfirmEmailChangeRejectsEmailTakenMeanwhile(t *testing.T) {
+    s, _, publisher := newTestServer(t)
+    user := createUser(t, s, "Ana", "García", "ana@example.com")
+    ctx := asUser(user.Id)
+
+    if _, err := s.UpdateUser(ctx, &pb.UpdateUserRequest{Id: user.Id, Email: "compartido@example.com"}); err != nil {
+        t.Fatalf("UpdateUser: %v", err)
+    }
+    relay(t, s)
+    token, _ := publisher.last(t, "user.email_change_requested")["token"].(string)
+
+    createUser(t, s, "Bruno", "Díaz", "compartido@example.com")
+
+    _, err := s.ConfirmEmailChange(ctx, &pb.ConfirmEmailChangeRequest{Token: token})
+    assertCode(t, err, codes.AlreadyExists)
+}
+
+func TestChangeUserRole(t *testing.T) {
+    s, users, publisher := newTestServer(t)
+    admin := createUser(t, s, "Admin", "Principal", "admin@example.com")
+    user := createUser(t, s, "Ana", "García", "ana@example.com")
+    ctx := asUser(admin.Id, "roles:manage")
+
+    // Sin roles:manage, aunque la llamada no pase por el gateway
+    _, err := s.ChangeUserRole(asUser(admin.Id, "users:write"), &pb.ChangeUserRoleRequest{Id: user.Id, Role: "Administrador"})
+    assertCode(t, err, codes.PermissionDenied)
+    _, err = s.ChangeUserRole(context.Background(), &pb.ChangeUserRoleRequest{Id: user.Id, Role: "Administrador"})
+    assertCode(t, err, codes.PermissionDenied)
+
+    _, err = s.ChangeUserRole(ctx, &pb.ChangeUserRoleRequest{Id: admin.Id, Role: "Administrador"})
+    assertCode(t, err, codes.PermissionDenied)
+
+    changed, err := s.ChangeUserRole(ctx, &pb.ChangeUserRoleRequest{
+        Id: user.Id, Role: "Moderador", Reason: "Modera los comentarios", ExpectedVersion: user.Version,
+    })
+    if err != nil {
+        t.Fatalf("ChangeUserRole: %v", err)
+    }
+    if changed.Role != "Moderador" || changed.Version != 2 {
+        t.Fatalf("unexpected role change: %v", changed)
+    }
+
+    relay(t, s)
+    event := publisher.last(t, "user.role_changed")
+    if event["previous_role"] != customerRole || event["changed_by_email"] != "admin@example.com" || event["reason"] != "Modera los comentarios" {
+        t.Fatalf("unexpected user.role_changed: %v", event)
+    }
+
+    // El mismo rol no escribe ni publica
+    same, err := s.ChangeUserRole(ctx, &pb.ChangeUserRoleRequest{Id: user.Id, Role: "Moderador"})
+    if err != nil {
+        t.Fatalf("ChangeUserRole: %v", err)
+    }
+    if same.Version != 2 || len(outboxKeys(t, users, user.Id)) != 0 {
+        t.Fatalf("unchanged role must not write: %v", same)
+    }
+
+    _, err = s.ChangeUserRole(ctx, &pb.ChangeUserRoleRequest{Id: user.Id, Role: customerRole, ExpectedVersion: 1})
+    assertCode(t, err, codes.Aborted)
+}
+
+func TestDeletedUserCanBeRestored(t *testing.T) {
+    s, users, _ := newTestServer(t)
+    user := createUser(t, s, "Ana", "García", "ana@example.com")
+    ctx := asUser(1, "users:read", "users:write")
+
+    if _, err := s.DeleteUser(ctx, &pb.DeleteUserRequest{Id: user.Id}); err != nil {
+        t.Fatalf("DeleteUser: %v", err)
+    }
+    _, err := s.GetUser(ctx, &pb.GetUserRequest{Id: user.Id})
+    assertCode(t, err, codes.NotFound)
+
+    trash, err := s.ListDeletedUsers(ctx, &pb.ListDeletedUsersRequest{})
+    if err != nil {
+        t.Fatalf("ListDeletedUsers: %v", err)
+    }
+    if len(trash.Users) != 1 || trash.Users[0].Id != user.Id || trash.Users[0].PurgeAfter == "" {
+        t.Fatalf("unexpected trash: %v", trash.Users)
+    }
+
+    _, err = s.RestoreUser(asUser(1, "users:read"), &pb.RestoreUserRequest{Id: user.Id})
+    assertCode(t, err, codes.PermissionDenied)
+
+    restored, err := s.RestoreUser(ctx, &pb.RestoreUserRequest{Id: user.Id})
+    if err != nil {
+        t.Fatalf("RestoreUser: %v", err)
+    }
+    if restored.Version != 3 || restored.Email != "ana@example.com" {
+        t.Fatalf("unexpected restore result: %v", restored)
+    }
+    assertKeys(t, outboxKeys(t, users, user.Id), "user.created", authUserCreatedKey, "user.deleted", "user.restored")
+
+    _, err = s.RestoreUser(ctx, &pb.RestoreUserRequest{Id: user.Id})
+    assertCode(t, err, codes.FailedPrecondition)
+}
+
+func TestRestoreRejectsEmailTakenMeanwhile(t *testing.T) {
+    s, _, _ := newTestServer(t)
+    user := createUser(t, s, "Ana", "García", "ana@example.com")
+    ctx := asAdmin()
+
+    if _, err := s.DeleteUser(ctx, &pb.DeleteUserRequest{Id: user.Id}); err != nil {
+        t.Fatalf("DeleteUser: %v", err)
+    }
+    createUser(t, s, "Ana", "Otra", "ana@example.com")
+
+    _, err := s.RestoreUser(asUser(1, "users:write"), &pb.RestoreUserRequest{Id: user.Id})
+    assertCode(t, err, codes.AlreadyExists)
+}
+
+func TestPurgeWaitsForPendingEvents(t *testing.T) {
+    s, users, publisher := newTestServer(t)
+    user := createUser(t, s, "Ana", "García", "ana@example.com")
+    active := createUser(t, s, "Bruno", "Díaz", "bruno@example.com")
+    ctx := asUser(1, "users:write", "users:purge")
+
+    _, err := s.PurgeUser(asUser(1, "users:write"), &pb.PurgeUserRequest{Id: active.Id})
+    assertCode(t, err, codes.PermissionDenied)
+    _, err = s.PurgeUser(ctx, &pb.PurgeUserRequest{Id: active.Id})
+    assertCode(t, err, codes.FailedPrecondition)
+    _, err = s.PurgeUser(ctx, &pb.PurgeUserRequest{Id: 999999})
+    assertCode(t, err, codes.NotFound)
+
+    if _, err := s.DeleteUser(ctx, &pb.DeleteUserRequest{Id: user.Id}); err != nil {
+        t.Fatalf("DeleteUser: %v", err)
+    }
+    // user.deleted todavía no se publicó
+    _, err = s.PurgeUser(ctx, &pb.PurgeUserRequest{Id: user.Id})
+    assertCode(t, err, codes.Unavailable)
+    if erasures, _ := s.erasures.List(ctx, "", user.Id, 10); len(erasures) != 0 {
+        t.Fatalf("a blocked purge must not leave an erasure request: %v", erasures)
+    }
+
+    relay(t, s)
+    purged, err := s.PurgeUser(ctx, &pb.PurgeUserRequest{Id: user.Id})
+    if err != nil {
+        t.Fatalf("PurgeUser: %v", err)
+    }
+    if exists, _ := users.Exists(ctx, user.Id); exists {
+        t.Fatal("purged user still exists")
+    }
+    if publisher.last(t, "user.purged")["erasure_id"] != purged.ErasureId {
+        t.Fatalf("user.purged must reference erasure %s", purged.ErasureId)
+    }
+
+    erasures, err := s.ListErasureRequests(ctx, &pb.ListErasureRequestsRequest{UserId: user.Id})
+    if err != nil {
+        t.Fatalf("ListErasureRequests: %v", err)
+    }
+    if len(erasures.Requests) != 1 || erasures.Requests[0].Status != erasureStatusPending || erasures.Requests[0].Attempts != 1 {
+        t.Fatalf("unexpected erasure requests: %v", erasures.Requests)
+    }
+    for _, service := range erasureServices {
+        publisher.last(t, "user.erasure_requested."+service)
+    }
+}
+
+func TestPrivilegedCallsRequirePermission(t *testing.T) {
+    s, _, _ := newTestServer(t)
+    user := createUser(t, s, "Ana", "García", "ana@example.com")
+    other := asUser(user.Id + 1)
+    csv := []byte("first_name,last_name,email,password,role\nBruno,Díaz,bruno@example.com," + testPassword + ",\n")
+
+    calls := map[string]func() error{
+        "ListUsers": func() error { _, err := s.ListUsers(other, &pb.ListUsersRequest{}); return err },
+        "ListDeletedUsers": func() error { _, err := s.ListDeletedUsers(other, &pb.ListDeletedUsersRequest{}); return err },
+        "ImportUsers": func() error { _, err := s.ImportUsers(other, &pb.ImportUsersRequest{Csv: csv}); return err },
+        "DeleteUser": func() error { _, err := s.DeleteUser(other, &pb.DeleteUserRequest{Id: user.Id}); return err },
+        "ListErasureRequests": func() error { _, err := s.ListErasureRequests(other, &pb.ListErasureRequestsRequest{}); return err },
+        "GetErasureRequest": func() error { _, err := s.GetErasureRequest(other, &pb.GetErasureRequestRequest{Id: "0123456789abcdef01234567"}); return err },
+        "GetUser": func() error { _, err := s.GetUser(other, &pb.GetUserRequest{Id: user.Id}); return err },
+        "UpdateUser": func() error { _, err := s.UpdateUser(other, &pb.UpdateUserRequest{Id: user.Id, FirstName: "Otra"}); return err },
+        "GetProfile": func() error { _, err := s.GetProfile(other, &pb.GetProfileRequest{UserId: user.Id}); return err },
+    }
+    for name, call := range calls {
+        if err := call(); status.Code(err) != codes.PermissionDenied {
+            t.Errorf("%s without permission: expected PermissionDenied, got %v", name, err)
+        }
+    }
+
+    // Sobre sus propios datos no hace falta permiso
+    if _, err := s.GetUser(asUser(user.Id), &pb.GetUserRequest{Id: user.Id}); err != nil {
+        t.Fatalf("GetUser as self: %v", err)
+    }
+}
+
+func TestImportUsersReportsRowErrors(t *testing.T) {
+    s, users, _ := newTestServer(t)
+    createUser(t, s, "Ana", "García", "ana@example.com")
+    csv := "first_name,last_name,email,password,role\n" +
+        "Bruno,Díaz,bruno@example.com," + testPassword + ",\n" +
+        "Carla,Pérez,no-es-un-email," + testPassword + ",\n" +
+        "Bruno,Otro,bruno@example.com," + testPassword + ",\n" +
+        "Ana,Repetida,ana@example.com," + testPassword + ",\n" +
+        "Dario,Gómez,dario@example.com," + testPassword + ",Administrador\n" +
+        "Elena,Ruiz,elena@example.com," + testPassword + ",Cliente\n"
+
+    dryRun, err := s.ImportUsers(asAdmin(), &pb.ImportUsersRequest{Csv: []byte(csv), DryRun: true})
+    if err != nil {
+        t.Fatalf("ImportUsers: %v", err)
+    }
+    if dryRun.ValidRows != 2 || dryRun.Created != 0 {
+        t.Fatalf("unexpected dry run: %v", dryRun)
+    }
+
+    imported, err := s.ImportUsers(asAdmin(), &pb.ImportUsersRequest{Csv: []byte(csv)})
+    if err != nil {
+        t.Fatalf("ImportUsers: %v", err)
+    }
+    if imported.TotalRows != 6 || imported.Created != 2 || imported.Failed != 4 {
+        t.Fatalf("unexpected import result: %v", imported)
+    }
+    // Primero las reglas de cada fila y después los emails ya registrados
+    wantRows := []int32{3, 4, 6, 5}
+    for i, rowErr := range imported.Errors {
+        if rowErr.Row != wantRows[i] {
+            t.Fatalf("expected errors on rows %v, got %v", wantRows, imported.Errors)
+        }
+    }
+    for _, id := range imported.CreatedIds {
+        assertKeys(t, outboxKeys(t, users, id), "user.created", authUserCreatedKey)
+    }
+
+    // Ni con roles:manage la importación asigna otro rol que cliente
+    manager := asUser(999, "users:write", "roles:manage")
+    withRoles, err := s.ImportUsers(manager, &pb.ImportUsersRequest{Csv: []byte(csv), DryRun: true})
+    if err != nil {
+        t.Fatalf("ImportUsers: %v", err)
+    }
+    if rowErr := withRoles.Errors[2]; rowErr.Row != 6 || !strings.Contains(rowErr.Error, "Cliente") {
+        t.Fatalf("expected the Administrador row to fail, got %v", withRoles.Errors)
+    }
+
+    got, err := s.GetUser(asAdmin(), &pb.GetUserRequest{Id: imported.CreatedIds[1]})
+    if err != nil {
+        t.Fatalf("GetUser: %v", err)
+    }
+    if got.Email != "elena@example.com" {
+        t.Fatalf("unexpected imported user: %v", got)
+    }
+}
+
+func TestOutboxRelayKeepsOrderAfterFailure(t *testing.T) {
+    s, users, publisher := newTestServer(t)
+    user := createUser(t, s, "Ana", "García", "ana@example.com")
+    if _, err := s.UpdateUser(asUser(user.Id), &pb.UpdateUserRequest{Id: user.Id, LastName: "Gómez"}); err != nil {
+        t.Fatalf("UpdateUser: %v", err)
+    }
+
+    publisher.fail = func(routingKey string) error {
+        if routingKey == authUserCreatedKey {
+            return errors.New("broker caído")
+        }
+        return nil
+    }
+    published, err := s.relayOutbox(context.Background())
+    if err == nil || published != 1 {
+        t.Fatalf("expected the relay to stop after 1 event, got %d (%v)", published, err)
+    }
+    assertKeys(t, outboxKeys(t, users, user.Id), authUserCreatedKey, "user.updated")
+    doc, _ := users.Get(context.Background(), user.Id)
+    failed := doc["outbox"].(bson.A)[0].(bson.M)
+    if failed["attempts"] != int32(1) || failed["last_error"] != "broker caído" {
+        t.Fatalf("failure not recorded: %v", failed)
+    }
+
+    publisher.fail = nil
+    relay(t, s)
+    assertKeys(t, publisher.keys(), "user.created", authUserCreatedKey, "user.updated")
+    assertKeys(t, outboxKeys(t, users, user.Id))
+}
+
+func TestOutboxRelayContinuesWithOtherUsersAfterFailure(t *testing.T) {
+    s, users, publisher := newTestServer(t)
+    ana := createUser(t, s, "Ana", "García", "ana@example.com")
+    bruno := createUser(t, s, "Bruno", "López", "bruno@example.com")
+
+    // Solo falla la primera publicación, la de Ana
+    calls := 0
+    publisher.fail = func(routingKey string) error {
+        calls++
+        if calls == 1 {
+            return errors.New("broker caído")
+        }
+        return nil
+    }
+    published, err := s.relayOutbox(context.Background())
+    if err == nil || published != 2 {
+        t.Fatalf("expected the second user's 2 events and an error, got %d (%v)", published, err)
+    }
+    assertKeys(t, outboxKeys(t, users, ana.Id), "user.created", authUserCreatedKey)
+    assertKeys(t, outboxKeys(t, users, bruno.Id))
+    if publisher.last(t, "user.created")["email"] != "bruno@example.com" {
+        t.Fatalf("expected Bruno's event to be published, got %v", publisher.messages)
+    }
+
+    publisher.fail = nil
+    relay(t, s)
+    assertKeys(t, outboxKeys(t, users, ana.Id))
+    assertKeys(t, publisher.keys(), "user.created", authUserCreatedKey, "user.created", authUserCreatedKey)
+}
+
+func TestListUsersSearchesAndPages(t *testing.T) {
+    s, _, _ := newTestServer(t)
+    createUser(t, s, "Ana", "García", "ana@example.com")
+    createUser(t, s, "Andrés", "López", "andres@example.com")
+    createUser(t, s, "Bruno", "Anaya", "bruno@example.com")
+    ctx := asAdmin()
+
+    byName, err := s.ListUsers(ctx, &pb.ListUsersRequest{Name: "an", OrderBy: "id"})
+    if err != nil {
+        t.Fatalf("ListUsers: %v", err)
+    }
+    if byName.TotalSize != 3 {
+        t.Fatalf("expected 3 users whose name contains \"an\", got %d", byName.TotalSize)
+    }
+
+    first, err := s.ListUsers(ctx, &pb.ListUsersRequest{Email: "AN", OrderBy: "id", PageSize: 1, Role: "cliente"})
+    if err != nil {
+        t.Fatalf("ListUsers: %v", err)
+    }
+    if first.TotalSize != 2 || len(first.Users) != 1 || first.Users[0].Email != "ana@example.com" || first.NextPageToken == "" {
+        t.Fatalf("unexpected first page: %v", first)
+    }
+    second, err := s.ListUsers(ctx, &pb.ListUsersRequest{
+        Email: "AN", OrderBy: "id", PageSize: 1, Role: "cliente", PageToken: first.NextPageToken,
+    })
+    if err != nil {
+        t.Fatalf("ListUsers: %v", err)
+    }
+    if len(second.Users) != 1 || second.Users[0].Email != "andres@example.com" || second.NextPageToken != "" {
+        t.Fatalf("unexpected second page: %v", second)
+    }
+
+    _, err = s.ListUsers(ctx, &pb.ListUsersRequest{Role: "Administrador"})
+    assertCode(t, err, codes.InvalidArgument)
+    _, err = s.ListUsers(ctx, &pb.ListUsersRequest{OrderBy: "id", PageToken: first.NextPageToken})
+    assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestListUsersMatchesInsideEmailAndFullName(t *testing.T) {
+    s, _, _ := newTestServer(t)
+    createUser(t, s, "María", "García", "maria.garcia@example.com")
+    createUser(t, s, "Ana", "López", "ana@example.com")
+    createUser(t, s, "Ana", "Martín", "amartin@example.com")
+    ctx := asAdmin()
+
+    tests := []struct {
+        name string
+        req  *pb.ListUsersRequest
+        want string
+    }{
+        {"email infix", &pb.ListUsersRequest{Email: "garcia"}, "maria.garcia@example.com"},
+        {"name infix", &pb.ListUsersRequest{Name: "ópe"}, "ana@example.com"},
+        {"full name", &pb.ListUsersRequest{Name: "Ana López"}, "ana@example.com"},
+        {"full name with spaces", &pb.ListUsersRequest{Name: "  ana martín "}, "amartin@example.com"},
+    }
+    for _, tt := range tests {
+        resp, err := s.ListUsers(ctx, tt.req)
+        if err != nil {
+            t.Fatalf("%s: ListUsers: %v", tt.name, err)
+        }
+        if len(resp.Users) != 1 || resp.Users[0].Email != tt.want {
+            t.Errorf("%s: expected only %s, got %v", tt.name, tt.want, resp.Users)
+        }
+    }
+
+    // El nombre completo sigue al cambiar el apellido
+    ana := createUser(t, s, "Ana", "Ruiz", "ruiz@example.com")
+    if _, err := s.UpdateUser(ctx, &pb.UpdateUserRequest{Id: ana.Id, LastName: "Sanz"}); err != nil {
+        t.Fatalf("UpdateUser: %v", err)
+    }
+    resp, err := s.ListUsers(ctx, &pb.ListUsersRequest{Name: "ana sanz"})
+    if err != nil {
+        t.Fatalf("ListUsers: %v", err)
+    }
+    if len(resp.Users) != 1 || resp.Users[0].Id != ana.Id {
+        t.Fatalf("expected the renamed user, got %v", resp.Users)
+    }
+}
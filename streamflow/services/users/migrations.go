@@ -0,0 +1,32 @@
+package main
+
+import (
+    "context"
+    "database/sql"
+    "embed"
+    "log"
+
+    "streamflow/shared/dbmigrate"
+)
+
+// El esquema MySQL se arma con los scripts versionados de migrations/,
+// compilados en el binario. Cada réplica aplica los pendientes al arrancar;
+// el lock de migración hace que las demás esperen a que termine la primera.
+// "users-service migrate [status | up | down -steps N]" muestra o revierte
+// el esquema sin levantar el servidor.
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+func newMigrator(db *sql.DB) (*dbmigrate.Migrator, error) {
+    return dbmigrate.New(db, dbmigrate.MySQL, migrationFiles, "migrations", "users-service")
+}
+
+// migrateSchema aplica las migraciones pendientes al arrancar
+func migrateSchema(migrator *dbmigrate.Migrator) error {
+    applied, err := migrator.Up(context.Background())
+    for _, m := range applied {
+        log.Printf("Migración aplicada: %04d_%s", m.Version, m.Name)
+    }
+    return err
+}
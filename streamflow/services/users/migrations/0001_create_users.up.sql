@@ -0,0 +1,14 @@
+-- IF NOT EXISTS permite que las bases creadas antes de las migraciones
+-- versionadas adopten esta versión sin cambios.
+CREATE TABLE IF NOT EXISTS users (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    first_name VARCHAR(50) NOT NULL,
+    last_name VARCHAR(50) NOT NULL,
+    email VARCHAR(100) NOT NULL UNIQUE,
+    password VARCHAR(255) NOT NULL,
+    role ENUM('Administrador', 'Cliente') NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    INDEX idx_email (email),
+    INDEX idx_deleted (deleted_at)
+);
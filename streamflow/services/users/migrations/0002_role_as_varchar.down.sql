@@ -0,0 +1,2 @@
+-- Falla si algún usuario tiene un rol distinto de los dos originales
+ALTER TABLE users MODIFY role ENUM('Administrador', 'Cliente') NOT NULL;
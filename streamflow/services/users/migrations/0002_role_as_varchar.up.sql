@@ -0,0 +1,2 @@
+-- Los roles se administran en el servicio de autenticación, ya no son un ENUM fijo
+ALTER TABLE users MODIFY role VARCHAR(50) NOT NULL;
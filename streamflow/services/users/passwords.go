@@ -0,0 +1,39 @@
+package main
+
+import (
+    "context"
+    "fmt"
+
+    "streamflow/shared/passwordhash"
+)
+
+// migratePlaintextPasswords hashea las contraseñas que versiones anteriores de
+// CreateUser guardaban en texto plano. Es idempotente y corre al iniciar.
+func (s *server) migratePlaintextPasswords(ctx context.Context) (int, error) {
+    passwords, err := s.users.Passwords(ctx)
+    if err != nil {
+        return 0, fmt.Errorf("buscando contraseñas: %w", err)
+    }
+
+    migrated := 0
+    for _, doc := range passwords {
+        if doc.Password == "" || passwordhash.IsHash(doc.Password) {
+            continue
+        }
+
+        hash, err := passwordhash.Hash(doc.Password)
+        if err != nil {
+            return migrated, fmt.Errorf("hasheando contraseña de %s: %w", doc.ID.Hex(), err)
+        }
+
+        // Solo si la contraseña no cambió mientras tanto
+        replaced, err := s.users.ReplacePassword(ctx, doc.ID, doc.Password, hash)
+        if err != nil {
+            return migrated, fmt.Errorf("guardando contraseña de %s: %w", doc.ID.Hex(), err)
+        }
+        if replaced {
+            migrated++
+        }
+    }
+    return migrated, nil
+}
@@ -9,6 +9,7 @@ package pb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -150,13 +151,18 @@ func (x *GetUserRequest) GetId() int32 {
 }
 
 type UpdateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Email     string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	// Fields to update: first_name, last_name, email. Without a mask only the
+	// non-empty fields are updated.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Version the client last read (ETag); 0 skips the check
+	ExpectedVersion int64 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateUserRequest) Reset() {
@@ -217,11 +223,26 @@ func (x *UpdateUserRequest) GetEmail() string {
 	return ""
 }
 
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
 type DeleteUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // 0 skips the check
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DeleteUserRequest) Reset() {
@@ -261,10 +282,26 @@ func (x *DeleteUserRequest) GetId() int32 {
 	return 0
 }
 
+func (x *DeleteUserRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
 type ListUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"` // case-insensitive partial match
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`   // case-insensitive partial match on first or last name
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                    // pending_verification | active
+	CreatedAfter  string                 `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`    // RFC 3339 or YYYY-MM-DD, inclusive
+	CreatedBefore string                 `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"` // RFC 3339 or YYYY-MM-DD, exclusive
+	// created_at | id | email | first_name | last_name, optionally followed by
+	// " desc". Defaults to "created_at desc".
+	OrderBy       string `protobuf:"bytes,7,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	PageSize      int32  `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // default 50, max 200
+	PageToken     string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -313,16 +350,71 @@ func (x *ListUsersRequest) GetName() string {
 	return ""
 }
 
+func (x *ListUsersRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type UserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
-	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName             string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName              string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Email                 string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Role                  string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAt             string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Status                string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`                                  // pending_verification | active
+	Version               int64                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`                               // incremented on every change
+	DeletedAt             string                 `protobuf:"bytes,9,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`           // only in ListDeletedUsers
+	PurgeAfter            string                 `protobuf:"bytes,10,opt,name=purge_after,json=purgeAfter,proto3" json:"purge_after,omitempty"`       // only in ListDeletedUsers: when the purge job erases it
+	PendingEmail          string                 `protobuf:"bytes,11,opt,name=pending_email,json=pendingEmail,proto3" json:"pending_email,omitempty"` // requested email waiting for confirmation
+	PendingEmailExpiresAt string                 `protobuf:"bytes,12,opt,name=pending_email_expires_at,json=pendingEmailExpiresAt,proto3" json:"pending_email_expires_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *UserResponse) Reset() {
@@ -397,6 +489,48 @@ func (x *UserResponse) GetCreatedAt() string {
 	return ""
 }
 
+func (x *UserResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UserResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UserResponse) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *UserResponse) GetPurgeAfter() string {
+	if x != nil {
+		return x.PurgeAfter
+	}
+	return ""
+}
+
+func (x *UserResponse) GetPendingEmail() string {
+	if x != nil {
+		return x.PendingEmail
+	}
+	return ""
+}
+
+func (x *UserResponse) GetPendingEmailExpiresAt() string {
+	if x != nil {
+		return x.PendingEmailExpiresAt
+	}
+	return ""
+}
+
 type DeleteUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -444,6 +578,8 @@ func (x *DeleteUserResponse) GetMessage() string {
 type ListUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Users         []*UserResponse        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	TotalSize     int64                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`              // users matching the filters, across all pages
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -485,54 +621,1912 @@ func (x *ListUsersResponse) GetUsers() []*UserResponse {
 	return nil
 }
 
-var File_protos_users_proto protoreflect.FileDescriptor
+func (x *ListUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
 
-const file_protos_users_proto_rawDesc = "" +
-	"\n" +
-	"\x12protos/users.proto\x12\x05users\"\xc0\x01\n" +
-	"\x11CreateUserRequest\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x02 \x01(\tR\blastName\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x04 \x01(\tR\bpassword\x12)\n" +
-	"\x10confirm_password\x18\x05 \x01(\tR\x0fconfirmPassword\x12\x12\n" +
-	"\x04role\x18\x06 \x01(\tR\x04role\" \n" +
-	"\x0eGetUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"u\n" +
-	"\x11UpdateUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x14\n" +
-	"\x05email\x18\x04 \x01(\tR\x05email\"#\n" +
-	"\x11DeleteUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"<\n" +
-	"\x10ListUsersRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\xa3\x01\n" +
-	"\fUserResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x14\n" +
-	"\x05email\x18\x04 \x01(\tR\x05email\x12\x12\n" +
-	"\x04role\x18\x05 \x01(\tR\x04role\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\tR\tcreatedAt\".\n" +
-	"\x12DeleteUserResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\">\n" +
-	"\x11ListUsersResponse\x12)\n" +
-	"\x05users\x18\x01 \x03(\v2\x13.users.UserResponseR\x05users2\xc1\x02\n" +
-	"\vUserService\x12;\n" +
-	"\n" +
-	"CreateUser\x12\x18.users.CreateUserRequest\x1a\x13.users.UserResponse\x125\n" +
-	"\aGetUser\x12\x15.users.GetUserRequest\x1a\x13.users.UserResponse\x12;\n" +
-	"\n" +
-	"UpdateUser\x12\x18.users.UpdateUserRequest\x1a\x13.users.UserResponse\x12A\n" +
-	"\n" +
-	"DeleteUser\x12\x18.users.DeleteUserRequest\x1a\x19.users.DeleteUserResponse\x12>\n" +
-	"\tListUsers\x12\x17.users.ListUsersRequest\x1a\x18.users.ListUsersResponseB\bZ\x06/pb;pbb\x06proto3"
+func (x *ListUsersResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type RestoreUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserRequest) Reset() {
+	*x = RestoreUserRequest{}
+	mi := &file_protos_users_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserRequest) ProtoMessage() {}
+
+func (x *RestoreUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserRequest.ProtoReflect.Descriptor instead.
+func (*RestoreUserRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RestoreUserRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type PurgeUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeUserRequest) Reset() {
+	*x = PurgeUserRequest{}
+	mi := &file_protos_users_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeUserRequest) ProtoMessage() {}
+
+func (x *PurgeUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeUserRequest.ProtoReflect.Descriptor instead.
+func (*PurgeUserRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PurgeUserRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type PurgeUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ErasureId     string                 `protobuf:"bytes,2,opt,name=erasure_id,json=erasureId,proto3" json:"erasure_id,omitempty"` // tracks the erasure of the user's data in other services
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeUserResponse) Reset() {
+	*x = PurgeUserResponse{}
+	mi := &file_protos_users_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeUserResponse) ProtoMessage() {}
+
+func (x *PurgeUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeUserResponse.ProtoReflect.Descriptor instead.
+func (*PurgeUserResponse) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PurgeUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PurgeUserResponse) GetErasureId() string {
+	if x != nil {
+		return x.ErasureId
+	}
+	return ""
+}
+
+type ListDeletedUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // default 50, max 200
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedUsersRequest) Reset() {
+	*x = ListDeletedUsersRequest{}
+	mi := &file_protos_users_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedUsersRequest) ProtoMessage() {}
+
+func (x *ListDeletedUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedUsersRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListDeletedUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeletedUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ErasureServiceStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`    // pending | completed
+	Records       int64                  `protobuf:"varint,3,opt,name=records,proto3" json:"records,omitempty"` // rows/documents deleted or anonymized
+	CompletedAt   string                 `protobuf:"bytes,4,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErasureServiceStatus) Reset() {
+	*x = ErasureServiceStatus{}
+	mi := &file_protos_users_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErasureServiceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErasureServiceStatus) ProtoMessage() {}
+
+func (x *ErasureServiceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErasureServiceStatus.ProtoReflect.Descriptor instead.
+func (*ErasureServiceStatus) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ErasureServiceStatus) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *ErasureServiceStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ErasureServiceStatus) GetRecords() int64 {
+	if x != nil {
+		return x.Records
+	}
+	return 0
+}
+
+func (x *ErasureServiceStatus) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+type ErasureRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                   `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                  `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // preparing (while purging) | pending | completed
+	Reason        string                  `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // manual | grace_period
+	RequestedAt   string                  `protobuf:"bytes,5,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	CompletedAt   string                  `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Attempts      int32                   `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"` // times the request was sent to the pending services
+	LastAttemptAt string                  `protobuf:"bytes,8,opt,name=last_attempt_at,json=lastAttemptAt,proto3" json:"last_attempt_at,omitempty"`
+	Services      []*ErasureServiceStatus `protobuf:"bytes,9,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErasureRequest) Reset() {
+	*x = ErasureRequest{}
+	mi := &file_protos_users_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErasureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErasureRequest) ProtoMessage() {}
+
+func (x *ErasureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErasureRequest.ProtoReflect.Descriptor instead.
+func (*ErasureRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ErasureRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ErasureRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ErasureRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ErasureRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ErasureRequest) GetRequestedAt() string {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return ""
+}
+
+func (x *ErasureRequest) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+func (x *ErasureRequest) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *ErasureRequest) GetLastAttemptAt() string {
+	if x != nil {
+		return x.LastAttemptAt
+	}
+	return ""
+}
+
+func (x *ErasureRequest) GetServices() []*ErasureServiceStatus {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type GetErasureRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetErasureRequestRequest) Reset() {
+	*x = GetErasureRequestRequest{}
+	mi := &file_protos_users_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetErasureRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetErasureRequestRequest) ProtoMessage() {}
+
+func (x *GetErasureRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetErasureRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetErasureRequestRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetErasureRequestRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListErasureRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                // optional: pending | completed
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListErasureRequestsRequest) Reset() {
+	*x = ListErasureRequestsRequest{}
+	mi := &file_protos_users_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListErasureRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListErasureRequestsRequest) ProtoMessage() {}
+
+func (x *ListErasureRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListErasureRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListErasureRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListErasureRequestsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListErasureRequestsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListErasureRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*ErasureRequest      `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"` // newest first, at most 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListErasureRequestsResponse) Reset() {
+	*x = ListErasureRequestsResponse{}
+	mi := &file_protos_users_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListErasureRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListErasureRequestsResponse) ProtoMessage() {}
+
+func (x *ListErasureRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListErasureRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListErasureRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListErasureRequestsResponse) GetRequests() []*ErasureRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type DataExport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // pending | running | completed | failed | expired
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`   // last failure, if any
+	RequestedAt   string                 `protobuf:"bytes,5,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	CompletedAt   string                 `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`             // the file and its download token stop working
+	DownloadToken string                 `protobuf:"bytes,8,opt,name=download_token,json=downloadToken,proto3" json:"download_token,omitempty"` // only while completed and not expired
+	SizeBytes     int64                  `protobuf:"varint,9,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Attempts      int32                  `protobuf:"varint,10,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataExport) Reset() {
+	*x = DataExport{}
+	mi := &file_protos_users_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExport) ProtoMessage() {}
+
+func (x *DataExport) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExport.ProtoReflect.Descriptor instead.
+func (*DataExport) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DataExport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DataExport) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DataExport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DataExport) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DataExport) GetRequestedAt() string {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return ""
+}
+
+func (x *DataExport) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+func (x *DataExport) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *DataExport) GetDownloadToken() string {
+	if x != nil {
+		return x.DownloadToken
+	}
+	return ""
+}
+
+func (x *DataExport) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *DataExport) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+type StartDataExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartDataExportRequest) Reset() {
+	*x = StartDataExportRequest{}
+	mi := &file_protos_users_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartDataExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartDataExportRequest) ProtoMessage() {}
+
+func (x *StartDataExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartDataExportRequest.ProtoReflect.Descriptor instead.
+func (*StartDataExportRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StartDataExportRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetDataExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // the export must belong to this user
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDataExportRequest) Reset() {
+	*x = GetDataExportRequest{}
+	mi := &file_protos_users_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDataExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDataExportRequest) ProtoMessage() {}
+
+func (x *GetDataExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDataExportRequest.ProtoReflect.Descriptor instead.
+func (*GetDataExportRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetDataExportRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetDataExportRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DownloadDataExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadDataExportRequest) Reset() {
+	*x = DownloadDataExportRequest{}
+	mi := &file_protos_users_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadDataExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadDataExportRequest) ProtoMessage() {}
+
+func (x *DownloadDataExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadDataExportRequest.ProtoReflect.Descriptor instead.
+func (*DownloadDataExportRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DownloadDataExportRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// The first chunk carries the file name and size; the rest only data
+type DataExportChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataExportChunk) Reset() {
+	*x = DataExportChunk{}
+	mi := &file_protos_users_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExportChunk) ProtoMessage() {}
+
+func (x *DataExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExportChunk.ProtoReflect.Descriptor instead.
+func (*DataExportChunk) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DataExportChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DataExportChunk) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *DataExportChunk) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+// Columns (header required, any order): first_name, last_name, email,
+// password, role; confirm_password is optional and checked when present
+type ImportUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Csv           []byte                 `protobuf:"bytes,1,opt,name=csv,proto3" json:"csv,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // validate only, nothing is created
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersRequest) Reset() {
+	*x = ImportUsersRequest{}
+	mi := &file_protos_users_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersRequest) ProtoMessage() {}
+
+func (x *ImportUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersRequest.ProtoReflect.Descriptor instead.
+func (*ImportUsersRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ImportUsersRequest) GetCsv() []byte {
+	if x != nil {
+		return x.Csv
+	}
+	return nil
+}
+
+func (x *ImportUsersRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportRowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"` // line in the file; the header is row 1
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_protos_users_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ImportRowError) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ImportRowError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ImportUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	TotalRows     int32                  `protobuf:"varint,2,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	ValidRows     int32                  `protobuf:"varint,3,opt,name=valid_rows,json=validRows,proto3" json:"valid_rows,omitempty"` // rows that passed validation
+	Created       int32                  `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`                      // always 0 in a dry run
+	Failed        int32                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors        []*ImportRowError      `protobuf:"bytes,6,rep,name=errors,proto3" json:"errors,omitempty"`
+	CreatedIds    []int32                `protobuf:"varint,7,rep,packed,name=created_ids,json=createdIds,proto3" json:"created_ids,omitempty"` // in file order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersResponse) Reset() {
+	*x = ImportUsersResponse{}
+	mi := &file_protos_users_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersResponse) ProtoMessage() {}
+
+func (x *ImportUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersResponse.ProtoReflect.Descriptor instead.
+func (*ImportUsersResponse) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ImportUsersResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportUsersResponse) GetTotalRows() int32 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetValidRows() int32 {
+	if x != nil {
+		return x.ValidRows
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportUsersResponse) GetCreatedIds() []int32 {
+	if x != nil {
+		return x.CreatedIds
+	}
+	return nil
+}
+
+type NotificationPreferences struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Newsletter      bool                   `protobuf:"varint,1,opt,name=newsletter,proto3" json:"newsletter,omitempty"`           // news and promotions
+	Recommendations bool                   `protobuf:"varint,2,opt,name=recommendations,proto3" json:"recommendations,omitempty"` // videos picked from the favorite genres
+	Social          bool                   `protobuf:"varint,3,opt,name=social,proto3" json:"social,omitempty"`                   // likes and comments on the user's content
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *NotificationPreferences) Reset() {
+	*x = NotificationPreferences{}
+	mi := &file_protos_users_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferences) ProtoMessage() {}
+
+func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferences.ProtoReflect.Descriptor instead.
+func (*NotificationPreferences) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *NotificationPreferences) GetNewsletter() bool {
+	if x != nil {
+		return x.Newsletter
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetRecommendations() bool {
+	if x != nil {
+		return x.Recommendations
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetSocial() bool {
+	if x != nil {
+		return x.Social
+	}
+	return false
+}
+
+type UserProfile struct {
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	UserId         int32                    `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DisplayName    string                   `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"` // falls back to first and last name when empty
+	AvatarUrl      string                   `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`       // empty without an avatar; changes with each upload
+	Locale         string                   `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`                              // es | en
+	Timezone       string                   `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`                          // IANA name, e.g. America/Santiago
+	Notifications  *NotificationPreferences `protobuf:"bytes,6,opt,name=notifications,proto3" json:"notifications,omitempty"`
+	FavoriteGenres []string                 `protobuf:"bytes,7,rep,name=favorite_genres,json=favoriteGenres,proto3" json:"favorite_genres,omitempty"`
+	Version        int64                    `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"` // the user's version: profile changes increment it too
+	UpdatedAt      string                   `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UserProfile) Reset() {
+	*x = UserProfile{}
+	mi := &file_protos_users_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserProfile) ProtoMessage() {}
+
+func (x *UserProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
+func (*UserProfile) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UserProfile) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserProfile) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *UserProfile) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *UserProfile) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *UserProfile) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *UserProfile) GetNotifications() *NotificationPreferences {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *UserProfile) GetFavoriteGenres() []string {
+	if x != nil {
+		return x.FavoriteGenres
+	}
+	return nil
+}
+
+func (x *UserProfile) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UserProfile) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type GetProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileRequest) Reset() {
+	*x = GetProfileRequest{}
+	mi := &file_protos_users_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileRequest) ProtoMessage() {}
+
+func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetProfileRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetProfileRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UpdateProfileRequest struct {
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	UserId         int32                    `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DisplayName    string                   `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Locale         string                   `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	Timezone       string                   `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Notifications  *NotificationPreferences `protobuf:"bytes,5,opt,name=notifications,proto3" json:"notifications,omitempty"`
+	FavoriteGenres []string                 `protobuf:"bytes,6,rep,name=favorite_genres,json=favoriteGenres,proto3" json:"favorite_genres,omitempty"`
+	// Fields to update: display_name, locale, timezone, favorite_genres,
+	// notifications or notifications.<preference>. Required.
+	UpdateMask      *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,8,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // 0 skips the check
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateProfileRequest) Reset() {
+	*x = UpdateProfileRequest{}
+	mi := &file_protos_users_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileRequest) ProtoMessage() {}
+
+func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateProfileRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateProfileRequest) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetNotifications() *NotificationPreferences {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *UpdateProfileRequest) GetFavoriteGenres() []string {
+	if x != nil {
+		return x.FavoriteGenres
+	}
+	return nil
+}
+
+func (x *UpdateProfileRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateProfileRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+// The image is resized to a square JPEG before it is stored
+type UploadAvatarRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Image           []byte                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`                                             // JPEG, PNG or GIF
+	ExpectedVersion int64                  `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // 0 skips the check
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UploadAvatarRequest) Reset() {
+	*x = UploadAvatarRequest{}
+	mi := &file_protos_users_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAvatarRequest) ProtoMessage() {}
+
+func (x *UploadAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAvatarRequest.ProtoReflect.Descriptor instead.
+func (*UploadAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UploadAvatarRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UploadAvatarRequest) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *UploadAvatarRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteAvatarRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // 0 skips the check
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteAvatarRequest) Reset() {
+	*x = DeleteAvatarRequest{}
+	mi := &file_protos_users_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAvatarRequest) ProtoMessage() {}
+
+func (x *DeleteAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAvatarRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteAvatarRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DeleteAvatarRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type GetAvatarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvatarRequest) Reset() {
+	*x = GetAvatarRequest{}
+	mi := &file_protos_users_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvatarRequest) ProtoMessage() {}
+
+func (x *GetAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvatarRequest.ProtoReflect.Descriptor instead.
+func (*GetAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetAvatarRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type Avatar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Etag          string                 `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"` // changes with each upload
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Avatar) Reset() {
+	*x = Avatar{}
+	mi := &file_protos_users_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Avatar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Avatar) ProtoMessage() {}
+
+func (x *Avatar) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Avatar.ProtoReflect.Descriptor instead.
+func (*Avatar) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Avatar) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Avatar) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Avatar) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type ChangeUserRoleRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Role            string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`                                               // existing role name in auth, as spelled there
+	ExpectedVersion int64                  `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // 0 skips the check
+	Reason          string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                           // optional, kept in the event and the audit log
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangeUserRoleRequest) Reset() {
+	*x = ChangeUserRoleRequest{}
+	mi := &file_protos_users_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeUserRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeUserRoleRequest) ProtoMessage() {}
+
+func (x *ChangeUserRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeUserRoleRequest.ProtoReflect.Descriptor instead.
+func (*ChangeUserRoleRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ChangeUserRoleRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChangeUserRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ChangeUserRoleRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *ChangeUserRoleRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ConfirmEmailChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // from the link emailed to the new address
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailChangeRequest) Reset() {
+	*x = ConfirmEmailChangeRequest{}
+	mi := &file_protos_users_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailChangeRequest) ProtoMessage() {}
+
+func (x *ConfirmEmailChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_users_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailChangeRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailChangeRequest) Descriptor() ([]byte, []int) {
+	return file_protos_users_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ConfirmEmailChangeRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+var File_protos_users_proto protoreflect.FileDescriptor
+
+const file_protos_users_proto_rawDesc = "" +
+	"\n" +
+	"\x12protos/users.proto\x12\x05users\x1a google/protobuf/field_mask.proto\"\xc0\x01\n" +
+	"\x11CreateUserRequest\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x02 \x01(\tR\blastName\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x12)\n" +
+	"\x10confirm_password\x18\x05 \x01(\tR\x0fconfirmPassword\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\" \n" +
+	"\x0eGetUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\xdd\x01\n" +
+	"\x11UpdateUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12;\n" +
+	"\vupdate_mask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12)\n" +
+	"\x10expected_version\x18\x06 \x01(\x03R\x0fexpectedVersion\"N\n" +
+	"\x11DeleteUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"\x8b\x02\n" +
+	"\x10ListUsersRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12#\n" +
+	"\rcreated_after\x18\x05 \x01(\tR\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\x06 \x01(\tR\rcreatedBefore\x12\x19\n" +
+	"\border_by\x18\a \x01(\tR\aorderBy\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\"\xf3\x02\n" +
+	"\fUserResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\t \x01(\tR\tdeletedAt\x12\x1f\n" +
+	"\vpurge_after\x18\n" +
+	" \x01(\tR\n" +
+	"purgeAfter\x12#\n" +
+	"\rpending_email\x18\v \x01(\tR\fpendingEmail\x127\n" +
+	"\x18pending_email_expires_at\x18\f \x01(\tR\x15pendingEmailExpiresAt\".\n" +
+	"\x12DeleteUserResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\x85\x01\n" +
+	"\x11ListUsersResponse\x12)\n" +
+	"\x05users\x18\x01 \x03(\v2\x13.users.UserResponseR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x03R\ttotalSize\"$\n" +
+	"\x12RestoreUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\"\n" +
+	"\x10PurgeUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"L\n" +
+	"\x11PurgeUserResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"erasure_id\x18\x02 \x01(\tR\terasureId\"U\n" +
+	"\x17ListDeletedUsersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\x85\x01\n" +
+	"\x14ErasureServiceStatus\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\arecords\x18\x03 \x01(\x03R\arecords\x12!\n" +
+	"\fcompleted_at\x18\x04 \x01(\tR\vcompletedAt\"\xac\x02\n" +
+	"\x0eErasureRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12!\n" +
+	"\frequested_at\x18\x05 \x01(\tR\vrequestedAt\x12!\n" +
+	"\fcompleted_at\x18\x06 \x01(\tR\vcompletedAt\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12&\n" +
+	"\x0flast_attempt_at\x18\b \x01(\tR\rlastAttemptAt\x127\n" +
+	"\bservices\x18\t \x03(\v2\x1b.users.ErasureServiceStatusR\bservices\"*\n" +
+	"\x18GetErasureRequestRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"M\n" +
+	"\x1aListErasureRequestsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"P\n" +
+	"\x1bListErasureRequestsResponse\x121\n" +
+	"\brequests\x18\x01 \x03(\v2\x15.users.ErasureRequestR\brequests\"\xaa\x02\n" +
+	"\n" +
+	"DataExport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12!\n" +
+	"\frequested_at\x18\x05 \x01(\tR\vrequestedAt\x12!\n" +
+	"\fcompleted_at\x18\x06 \x01(\tR\vcompletedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\tR\texpiresAt\x12%\n" +
+	"\x0edownload_token\x18\b \x01(\tR\rdownloadToken\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\t \x01(\x03R\tsizeBytes\x12\x1a\n" +
+	"\battempts\x18\n" +
+	" \x01(\x05R\battempts\"1\n" +
+	"\x16StartDataExportRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"?\n" +
+	"\x14GetDataExportRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"1\n" +
+	"\x19DownloadDataExportRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"`\n" +
+	"\x0fDataExportChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\"?\n" +
+	"\x12ImportUsersRequest\x12\x10\n" +
+	"\x03csv\x18\x01 \x01(\fR\x03csv\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"N\n" +
+	"\x0eImportRowError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xee\x01\n" +
+	"\x13ImportUsersResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x1d\n" +
+	"\n" +
+	"total_rows\x18\x02 \x01(\x05R\ttotalRows\x12\x1d\n" +
+	"\n" +
+	"valid_rows\x18\x03 \x01(\x05R\tvalidRows\x12\x18\n" +
+	"\acreated\x18\x04 \x01(\x05R\acreated\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x05R\x06failed\x12-\n" +
+	"\x06errors\x18\x06 \x03(\v2\x15.users.ImportRowErrorR\x06errors\x12\x1f\n" +
+	"\vcreated_ids\x18\a \x03(\x05R\n" +
+	"createdIds\"{\n" +
+	"\x17NotificationPreferences\x12\x1e\n" +
+	"\n" +
+	"newsletter\x18\x01 \x01(\bR\n" +
+	"newsletter\x12(\n" +
+	"\x0frecommendations\x18\x02 \x01(\bR\x0frecommendations\x12\x16\n" +
+	"\x06social\x18\x03 \x01(\bR\x06social\"\xc4\x02\n" +
+	"\vUserProfile\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12D\n" +
+	"\rnotifications\x18\x06 \x01(\v2\x1e.users.NotificationPreferencesR\rnotifications\x12'\n" +
+	"\x0ffavorite_genres\x18\a \x03(\tR\x0efavoriteGenres\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\tR\tupdatedAt\",\n" +
+	"\x11GetProfileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"\xdd\x02\n" +
+	"\x14UpdateProfileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\x12\x1a\n" +
+	"\btimezone\x18\x04 \x01(\tR\btimezone\x12D\n" +
+	"\rnotifications\x18\x05 \x01(\v2\x1e.users.NotificationPreferencesR\rnotifications\x12'\n" +
+	"\x0ffavorite_genres\x18\x06 \x03(\tR\x0efavoriteGenres\x12;\n" +
+	"\vupdate_mask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12)\n" +
+	"\x10expected_version\x18\b \x01(\x03R\x0fexpectedVersion\"o\n" +
+	"\x13UploadAvatarRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x14\n" +
+	"\x05image\x18\x02 \x01(\fR\x05image\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x03R\x0fexpectedVersion\"Y\n" +
+	"\x13DeleteAvatarRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"+\n" +
+	"\x10GetAvatarRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"S\n" +
+	"\x06Avatar\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\"~\n" +
+	"\x15ChangeUserRoleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x03R\x0fexpectedVersion\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"1\n" +
+	"\x19ConfirmEmailChangeRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token2\x9c\v\n" +
+	"\vUserService\x12;\n" +
+	"\n" +
+	"CreateUser\x12\x18.users.CreateUserRequest\x1a\x13.users.UserResponse\x125\n" +
+	"\aGetUser\x12\x15.users.GetUserRequest\x1a\x13.users.UserResponse\x12;\n" +
+	"\n" +
+	"UpdateUser\x12\x18.users.UpdateUserRequest\x1a\x13.users.UserResponse\x12A\n" +
+	"\n" +
+	"DeleteUser\x12\x18.users.DeleteUserRequest\x1a\x19.users.DeleteUserResponse\x12>\n" +
+	"\tListUsers\x12\x17.users.ListUsersRequest\x1a\x18.users.ListUsersResponse\x12=\n" +
+	"\vRestoreUser\x12\x19.users.RestoreUserRequest\x1a\x13.users.UserResponse\x12>\n" +
+	"\tPurgeUser\x12\x17.users.PurgeUserRequest\x1a\x18.users.PurgeUserResponse\x12L\n" +
+	"\x10ListDeletedUsers\x12\x1e.users.ListDeletedUsersRequest\x1a\x18.users.ListUsersResponse\x12K\n" +
+	"\x11GetErasureRequest\x12\x1f.users.GetErasureRequestRequest\x1a\x15.users.ErasureRequest\x12\\\n" +
+	"\x13ListErasureRequests\x12!.users.ListErasureRequestsRequest\x1a\".users.ListErasureRequestsResponse\x12C\n" +
+	"\x0fStartDataExport\x12\x1d.users.StartDataExportRequest\x1a\x11.users.DataExport\x12?\n" +
+	"\rGetDataExport\x12\x1b.users.GetDataExportRequest\x1a\x11.users.DataExport\x12P\n" +
+	"\x12DownloadDataExport\x12 .users.DownloadDataExportRequest\x1a\x16.users.DataExportChunk0\x01\x12D\n" +
+	"\vImportUsers\x12\x19.users.ImportUsersRequest\x1a\x1a.users.ImportUsersResponse\x12:\n" +
+	"\n" +
+	"GetProfile\x12\x18.users.GetProfileRequest\x1a\x12.users.UserProfile\x12@\n" +
+	"\rUpdateProfile\x12\x1b.users.UpdateProfileRequest\x1a\x12.users.UserProfile\x12>\n" +
+	"\fUploadAvatar\x12\x1a.users.UploadAvatarRequest\x1a\x12.users.UserProfile\x12>\n" +
+	"\fDeleteAvatar\x12\x1a.users.DeleteAvatarRequest\x1a\x12.users.UserProfile\x123\n" +
+	"\tGetAvatar\x12\x17.users.GetAvatarRequest\x1a\r.users.Avatar\x12C\n" +
+	"\x0eChangeUserRole\x12\x1c.users.ChangeUserRoleRequest\x1a\x13.users.UserResponse\x12K\n" +
+	"\x12ConfirmEmailChange\x12 .users.ConfirmEmailChangeRequest\x1a\x13.users.UserResponseB\bZ\x06/pb;pbb\x06proto3"
 
 var (
 	file_protos_users_proto_rawDescOnce sync.Once
@@ -546,34 +2540,101 @@ func file_protos_users_proto_rawDescGZIP() []byte {
 	return file_protos_users_proto_rawDescData
 }
 
-var file_protos_users_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_protos_users_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_protos_users_proto_goTypes = []any{
-	(*CreateUserRequest)(nil),  // 0: users.CreateUserRequest
-	(*GetUserRequest)(nil),     // 1: users.GetUserRequest
-	(*UpdateUserRequest)(nil),  // 2: users.UpdateUserRequest
-	(*DeleteUserRequest)(nil),  // 3: users.DeleteUserRequest
-	(*ListUsersRequest)(nil),   // 4: users.ListUsersRequest
-	(*UserResponse)(nil),       // 5: users.UserResponse
-	(*DeleteUserResponse)(nil), // 6: users.DeleteUserResponse
-	(*ListUsersResponse)(nil),  // 7: users.ListUsersResponse
+	(*CreateUserRequest)(nil),           // 0: users.CreateUserRequest
+	(*GetUserRequest)(nil),              // 1: users.GetUserRequest
+	(*UpdateUserRequest)(nil),           // 2: users.UpdateUserRequest
+	(*DeleteUserRequest)(nil),           // 3: users.DeleteUserRequest
+	(*ListUsersRequest)(nil),            // 4: users.ListUsersRequest
+	(*UserResponse)(nil),                // 5: users.UserResponse
+	(*DeleteUserResponse)(nil),          // 6: users.DeleteUserResponse
+	(*ListUsersResponse)(nil),           // 7: users.ListUsersResponse
+	(*RestoreUserRequest)(nil),          // 8: users.RestoreUserRequest
+	(*PurgeUserRequest)(nil),            // 9: users.PurgeUserRequest
+	(*PurgeUserResponse)(nil),           // 10: users.PurgeUserResponse
+	(*ListDeletedUsersRequest)(nil),     // 11: users.ListDeletedUsersRequest
+	(*ErasureServiceStatus)(nil),        // 12: users.ErasureServiceStatus
+	(*ErasureRequest)(nil),              // 13: users.ErasureRequest
+	(*GetErasureRequestRequest)(nil),    // 14: users.GetErasureRequestRequest
+	(*ListErasureRequestsRequest)(nil),  // 15: users.ListErasureRequestsRequest
+	(*ListErasureRequestsResponse)(nil), // 16: users.ListErasureRequestsResponse
+	(*DataExport)(nil),                  // 17: users.DataExport
+	(*StartDataExportRequest)(nil),      // 18: users.StartDataExportRequest
+	(*GetDataExportRequest)(nil),        // 19: users.GetDataExportRequest
+	(*DownloadDataExportRequest)(nil),   // 20: users.DownloadDataExportRequest
+	(*DataExportChunk)(nil),             // 21: users.DataExportChunk
+	(*ImportUsersRequest)(nil),          // 22: users.ImportUsersRequest
+	(*ImportRowError)(nil),              // 23: users.ImportRowError
+	(*ImportUsersResponse)(nil),         // 24: users.ImportUsersResponse
+	(*NotificationPreferences)(nil),     // 25: users.NotificationPreferences
+	(*UserProfile)(nil),                 // 26: users.UserProfile
+	(*GetProfileRequest)(nil),           // 27: users.GetProfileRequest
+	(*UpdateProfileRequest)(nil),        // 28: users.UpdateProfileRequest
+	(*UploadAvatarRequest)(nil),         // 29: users.UploadAvatarRequest
+	(*DeleteAvatarRequest)(nil),         // 30: users.DeleteAvatarRequest
+	(*GetAvatarRequest)(nil),            // 31: users.GetAvatarRequest
+	(*Avatar)(nil),                      // 32: users.Avatar
+	(*ChangeUserRoleRequest)(nil),       // 33: users.ChangeUserRoleRequest
+	(*ConfirmEmailChangeRequest)(nil),   // 34: users.ConfirmEmailChangeRequest
+	(*fieldmaskpb.FieldMask)(nil),       // 35: google.protobuf.FieldMask
 }
 var file_protos_users_proto_depIdxs = []int32{
-	5, // 0: users.ListUsersResponse.users:type_name -> users.UserResponse
-	0, // 1: users.UserService.CreateUser:input_type -> users.CreateUserRequest
-	1, // 2: users.UserService.GetUser:input_type -> users.GetUserRequest
-	2, // 3: users.UserService.UpdateUser:input_type -> users.UpdateUserRequest
-	3, // 4: users.UserService.DeleteUser:input_type -> users.DeleteUserRequest
-	4, // 5: users.UserService.ListUsers:input_type -> users.ListUsersRequest
-	5, // 6: users.UserService.CreateUser:output_type -> users.UserResponse
-	5, // 7: users.UserService.GetUser:output_type -> users.UserResponse
-	5, // 8: users.UserService.UpdateUser:output_type -> users.UserResponse
-	6, // 9: users.UserService.DeleteUser:output_type -> users.DeleteUserResponse
-	7, // 10: users.UserService.ListUsers:output_type -> users.ListUsersResponse
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	35, // 0: users.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	5,  // 1: users.ListUsersResponse.users:type_name -> users.UserResponse
+	12, // 2: users.ErasureRequest.services:type_name -> users.ErasureServiceStatus
+	13, // 3: users.ListErasureRequestsResponse.requests:type_name -> users.ErasureRequest
+	23, // 4: users.ImportUsersResponse.errors:type_name -> users.ImportRowError
+	25, // 5: users.UserProfile.notifications:type_name -> users.NotificationPreferences
+	25, // 6: users.UpdateProfileRequest.notifications:type_name -> users.NotificationPreferences
+	35, // 7: users.UpdateProfileRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 8: users.UserService.CreateUser:input_type -> users.CreateUserRequest
+	1,  // 9: users.UserService.GetUser:input_type -> users.GetUserRequest
+	2,  // 10: users.UserService.UpdateUser:input_type -> users.UpdateUserRequest
+	3,  // 11: users.UserService.DeleteUser:input_type -> users.DeleteUserRequest
+	4,  // 12: users.UserService.ListUsers:input_type -> users.ListUsersRequest
+	8,  // 13: users.UserService.RestoreUser:input_type -> users.RestoreUserRequest
+	9,  // 14: users.UserService.PurgeUser:input_type -> users.PurgeUserRequest
+	11, // 15: users.UserService.ListDeletedUsers:input_type -> users.ListDeletedUsersRequest
+	14, // 16: users.UserService.GetErasureRequest:input_type -> users.GetErasureRequestRequest
+	15, // 17: users.UserService.ListErasureRequests:input_type -> users.ListErasureRequestsRequest
+	18, // 18: users.UserService.StartDataExport:input_type -> users.StartDataExportRequest
+	19, // 19: users.UserService.GetDataExport:input_type -> users.GetDataExportRequest
+	20, // 20: users.UserService.DownloadDataExport:input_type -> users.DownloadDataExportRequest
+	22, // 21: users.UserService.ImportUsers:input_type -> users.ImportUsersRequest
+	27, // 22: users.UserService.GetProfile:input_type -> users.GetProfileRequest
+	28, // 23: users.UserService.UpdateProfile:input_type -> users.UpdateProfileRequest
+	29, // 24: users.UserService.UploadAvatar:input_type -> users.UploadAvatarRequest
+	30, // 25: users.UserService.DeleteAvatar:input_type -> users.DeleteAvatarRequest
+	31, // 26: users.UserService.GetAvatar:input_type -> users.GetAvatarRequest
+	33, // 27: users.UserService.ChangeUserRole:input_type -> users.ChangeUserRoleRequest
+	34, // 28: users.UserService.ConfirmEmailChange:input_type -> users.ConfirmEmailChangeRequest
+	5,  // 29: users.UserService.CreateUser:output_type -> users.UserResponse
+	5,  // 30: users.UserService.GetUser:output_type -> users.UserResponse
+	5,  // 31: users.UserService.UpdateUser:output_type -> users.UserResponse
+	6,  // 32: users.UserService.DeleteUser:output_type -> users.DeleteUserResponse
+	7,  // 33: users.UserService.ListUsers:output_type -> users.ListUsersResponse
+	5,  // 34: users.UserService.RestoreUser:output_type -> users.UserResponse
+	10, // 35: users.UserService.PurgeUser:output_type -> users.PurgeUserResponse
+	7,  // 36: users.UserService.ListDeletedUsers:output_type -> users.ListUsersResponse
+	13, // 37: users.UserService.GetErasureRequest:output_type -> users.ErasureRequest
+	16, // 38: users.UserService.ListErasureRequests:output_type -> users.ListErasureRequestsResponse
+	17, // 39: users.UserService.StartDataExport:output_type -> users.DataExport
+	17, // 40: users.UserService.GetDataExport:output_type -> users.DataExport
+	21, // 41: users.UserService.DownloadDataExport:output_type -> users.DataExportChunk
+	24, // 42: users.UserService.ImportUsers:output_type -> users.ImportUsersResponse
+	26, // 43: users.UserService.GetProfile:output_type -> users.UserProfile
+	26, // 44: users.UserService.UpdateProfile:output_type -> users.UserProfile
+	26, // 45: users.UserService.UploadAvatar:output_type -> users.UserProfile
+	26, // 46: users.UserService.DeleteAvatar:output_type -> users.UserProfile
+	32, // 47: users.UserService.GetAvatar:output_type -> users.Avatar
+	5,  // 48: users.UserService.ChangeUserRole:output_type -> users.UserResponse
+	5,  // 49: users.UserService.ConfirmEmailChange:output_type -> users.UserResponse
+	29, // [29:50] is the sub-list for method output_type
+	8,  // [8:29] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_protos_users_proto_init() }
@@ -587,7 +2648,7 @@ func file_protos_users_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protos_users_proto_rawDesc), len(file_protos_users_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
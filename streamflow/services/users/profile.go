@@ -0,0 +1,307 @@
+package main
+
+import (
+    "context"
+    "fmt"
+    "reflect"
+    "strings"
+    "time"
+    _ "time/tzdata" // zonas horarias aunque la imagen no tenga tzdata
+    "unicode/utf8"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    pb "users-service/pb"
+)
+
+// El perfil es lo que el usuario elige mostrar y cómo quiere que lo traten
+// los demás servicios: nombre visible, avatar, idioma, zona horaria,
+// notificaciones y géneros favoritos. Se guarda en el campo profile del
+// documento del usuario, así que comparte su versión (ETag) y cada cambio
+// publica user.profile_updated por el outbox.
+
+// Valores del perfil cuando el usuario no los eligió
+const (
+    defaultLocale   = "es"
+    defaultTimezone = "America/Santiago"
+)
+
+const (
+    displayNameMaxLength = 50
+    maxFavoriteGenres    = 10
+    genreMaxLength       = 30
+)
+
+// supportedLocales son los idiomas de las plantillas de email y del sitio
+var supportedLocales = map[string]bool{"es": true, "en": true}
+
+// profileDoc es el campo profile del documento del usuario
+type profileDoc struct {
+    DisplayName    string             `bson:"display_name,omitempty"`
+    Locale         string             `bson:"locale,omitempty"`
+    Timezone       string             `bson:"timezone,omitempty"`
+    Notifications  *notificationPrefs `bson:"notifications,omitempty"`
+    FavoriteGenres []string           `bson:"favorite_genres,omitempty"`
+    Avatar         string             `bson:"avatar,omitempty"` // archivo en avatarDir
+    UpdatedAt      string             `bson:"updated_at,omitempty"`
+}
+
+type notificationPrefs struct {
+    Newsletter      bool `bson:"newsletter" json:"newsletter"`
+    Recommendations bool `bson:"recommendations" json:"recommendations"`
+    Social          bool `bson:"social" json:"social"`
+}
+
+// defaultNotifications: sin publicidad hasta que el usuario la acepte
+var defaultNotifications = notificationPrefs{Newsletter: false, Recommendations: true, Social: true}
+
+// decodeProfile lee el perfil de un documento de usuario y completa los
+// valores por defecto
+func decodeProfile(user bson.M) (profileDoc, error) {
+    var profile profileDoc
+    if raw, ok := user["profile"]; ok && raw != nil {
+        data, err := bson.Marshal(raw)
+        if err != nil {
+            return profile, fmt.Errorf("leyendo el perfil: %w", err)
+        }
+        if err := bson.Unmarshal(data, &profile); err != nil {
+            return profile, fmt.Errorf("leyendo el perfil: %w", err)
+        }
+    }
+    if profile.Locale == "" {
+        profile.Locale = defaultLocale
+    }
+    if profile.Timezone == "" {
+        profile.Timezone = defaultTimezone
+    }
+    if profile.Notifications == nil {
+        prefs := defaultNotifications
+        profile.Notifications = &prefs
+    }
+    if profile.FavoriteGenres == nil {
+        profile.FavoriteGenres = []string{}
+    }
+    return profile, nil
+}
+
+// profileResponse arma la respuesta del perfil de un usuario
+func profileResponse(user bson.M, profile profileDoc) *pb.UserProfile {
+    userID := docUserID(user)
+    displayName := profile.DisplayName
+    if displayName == "" {
+        first, _ := user["first_name"].(string)
+        last, _ := user["last_name"].(string)
+        displayName = strings.TrimSpace(first + " " + last)
+    }
+    return &pb.UserProfile{
+        UserId:      userID,
+        DisplayName: displayName,
+        AvatarUrl:   avatarURL(userID, profile.Avatar),
+        Locale:      profile.Locale,
+        Timezone:    profile.Timezone,
+        Notifications: &pb.NotificationPreferences{
+            Newsletter:      profile.Notifications.Newsletter,
+            Recommendations: profile.Notifications.Recommendations,
+            Social:          profile.Notifications.Social,
+        },
+        FavoriteGenres: profile.FavoriteGenres,
+        Version:        docVersion(user),
+        UpdatedAt:      profile.UpdatedAt,
+    }
+}
+
+// profileEventData son los datos de user.profile_updated: el perfil completo
+// después del cambio, para que los servicios lo repliquen sin consultarlo
+func profileEventData(response *pb.UserProfile, changed []string) map[string]interface{} {
+    return map[string]interface{}{
+        "display_name": response.DisplayName,
+        "avatar_url":   response.AvatarUrl,
+        "locale":       response.Locale,
+        "timezone":     response.Timezone,
+        "notifications": notificationPrefs{
+            Newsletter:      response.Notifications.Newsletter,
+            Recommendations: response.Notifications.Recommendations,
+            Social:          response.Notifications.Social,
+        },
+        "favorite_genres": response.FavoriteGenres,
+        "changed_fields":  changed,
+    }
+}
+
+// GetProfile devuelve el perfil de un usuario
+func (s *server) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.UserProfile, error) {
+    if err := requireSelfOr(ctx, req.UserId, "users:read"); err != nil {
+        return nil, err
+    }
+    user, err := s.users.Get(ctx, req.UserId)
+    if err != nil {
+        return nil, readError(err)
+    }
+    profile, err := decodeProfile(user)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "%v", err)
+    }
+    return profileResponse(user, profile), nil
+}
+
+// UpdateProfile actualiza los campos del perfil indicados en update_mask
+func (s *server) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UserProfile, error) {
+    if err := requireSelfOr(ctx, req.UserId, "users:write"); err != nil {
+        return nil, err
+    }
+    if req.UpdateMask == nil || len(req.UpdateMask.Paths) == 0 {
+        return nil, status.Errorf(codes.InvalidArgument, "No hay campos para actualizar")
+    }
+
+    current, err := s.currentForWrite(ctx, req.UserId, req.ExpectedVersion)
+    if err != nil {
+        return nil, err
+    }
+    profile, err := decodeProfile(current)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "%v", err)
+    }
+
+    paths := req.UpdateMask.Paths
+    for _, path := range paths {
+        if strings.HasPrefix(path, "notifications.") && containsString(paths, "notifications") {
+            return nil, status.Errorf(codes.InvalidArgument, "notifications y %s no pueden ir juntos en update_mask", path)
+        }
+    }
+
+    // Validar y aplicar sobre una copia; solo se escriben los campos que cambian
+    updated := profile
+    prefs := *profile.Notifications
+    updated.Notifications = &prefs
+    set := bson.M{}
+    var changed []string
+    for _, path := range paths {
+        var value, old interface{}
+        switch path {
+        case "display_name":
+            name := strings.TrimSpace(req.DisplayName)
+            if utf8.RuneCountInString(name) > displayNameMaxLength {
+                return nil, status.Errorf(codes.InvalidArgument, "El nombre visible admite hasta %d caracteres", displayNameMaxLength)
+            }
+            old, value, updated.DisplayName = profile.DisplayName, name, name
+        case "locale":
+            locale := strings.ToLower(strings.TrimSpace(req.Locale))
+            if !supportedLocales[locale] {
+                return nil, status.Errorf(codes.InvalidArgument, "Idioma no soportado: %q (es o en)", req.Locale)
+            }
+            old, value, updated.Locale = profile.Locale, locale, locale
+        case "timezone":
+            timezone := strings.TrimSpace(req.Timezone)
+            if timezone == "" || strings.EqualFold(timezone, "local") {
+                return nil, status.Errorf(codes.InvalidArgument, "Zona horaria inválida: %q", req.Timezone)
+            }
+            if _, err := time.LoadLocation(timezone); err != nil {
+                return nil, status.Errorf(codes.InvalidArgument, "Zona horaria inválida: %q", req.Timezone)
+            }
+            old, value, updated.Timezone = profile.Timezone, timezone, timezone
+        case "favorite_genres":
+            genres, err := normalizeGenres(req.FavoriteGenres)
+            if err != nil {
+                return nil, status.Error(codes.InvalidArgument, err.Error())
+            }
+            old, value, updated.FavoriteGenres = profile.FavoriteGenres, genres, genres
+        case "notifications":
+            if req.Notifications == nil {
+                return nil, status.Errorf(codes.InvalidArgument, "Faltan las preferencias de notificación")
+            }
+            prefs = notificationPrefs{
+                Newsletter:      req.Notifications.Newsletter,
+                Recommendations: req.Notifications.Recommendations,
+                Social:          req.Notifications.Social,
+            }
+            old, value = *profile.Notifications, prefs
+        case "notifications.newsletter", "notifications.recommendations", "notifications.social":
+            if req.Notifications == nil {
+                return nil, status.Errorf(codes.InvalidArgument, "Faltan las preferencias de notificación")
+            }
+            switch path {
+            case "notifications.newsletter":
+                old, value, prefs.Newsletter = profile.Notifications.Newsletter, req.Notifications.Newsletter, req.Notifications.Newsletter
+            case "notifications.recommendations":
+                old, value, prefs.Recommendations = profile.Notifications.Recommendations, req.Notifications.Recommendations, req.Notifications.Recommendations
+            case "notifications.social":
+                old, value, prefs.Social = profile.Notifications.Social, req.Notifications.Social, req.Notifications.Social
+            }
+        default:
+            return nil, status.Errorf(codes.InvalidArgument, "Campo no actualizable: %q", path)
+        }
+        if !reflect.DeepEqual(old, value) {
+            set["profile."+path] = value
+            changed = append(changed, path)
+        }
+    }
+    // Las preferencias se guardan siempre completas: un documento sin ellas
+    // usa los valores por defecto, que no deben perderse al cambiar solo una
+    for key := range set {
+        if strings.HasPrefix(key, "profile.notifications") {
+            delete(set, key)
+            set["profile.notifications"] = prefs
+        }
+    }
+
+    // Sin cambios no se escribe: la versión y el ETag siguen iguales
+    if len(changed) == 0 {
+        return profileResponse(current, profile), nil
+    }
+
+    now := time.Now().UTC().Format(time.RFC3339)
+    updated.UpdatedAt = now
+    set["profile.updated_at"] = now
+    response := profileResponse(current, updated)
+    response.Version++
+    if err := s.writeProfile(ctx, current, userChange{Set: set}, response, changed); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// writeProfile guarda un cambio del perfil condicionado a la versión leída,
+// junto con su evento
+func (s *server) writeProfile(ctx context.Context, current bson.M, change userChange, response *pb.UserProfile, changed []string) error {
+    event, err := newUserEvent("user.profile_updated", response.UserId, response.Version, profileEventData(response, changed))
+    if err != nil {
+        return status.Errorf(codes.Internal, "Error armando el evento: %v", err)
+    }
+    change.Events = append(change.Events, event)
+    if err := s.users.Update(ctx, response.UserId, docVersion(current), change); err != nil {
+        return writeError(err, docVersion(current))
+    }
+    return nil
+}
+
+// normalizeGenres pasa los géneros a minúsculas, quita vacíos y repetidos y
+// conserva el orden elegido
+func normalizeGenres(genres []string) ([]string, error) {
+    normalized := make([]string, 0, len(genres))
+    seen := make(map[string]bool, len(genres))
+    for _, genre := range genres {
+        genre = strings.ToLower(strings.Join(strings.Fields(genre), " "))
+        if genre == "" || seen[genre] {
+            continue
+        }
+        if utf8.RuneCountInString(genre) > genreMaxLength {
+            return nil, fmt.Errorf("Cada género admite hasta %d caracteres", genreMaxLength)
+        }
+        seen[genre] = true
+        normalized = append(normalized, genre)
+    }
+    if len(normalized) > maxFavoriteGenres {
+        return nil, fmt.Errorf("Se admiten hasta %d géneros favoritos", maxFavoriteGenres)
+    }
+    return normalized, nil
+}
+
+func containsString(values []string, value string) bool {
+    for _, v := range values {
+        if v == value {
+            return true
+        }
+    }
+    return false
+}
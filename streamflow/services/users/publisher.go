@@ -0,0 +1,125 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "sync"
+    "time"
+
+    "github.com/streadway/amqp"
+)
+
+// eventPublisher publica un mensaje en events_exchange y espera la
+// confirmación del broker. El relay del outbox lo usa sin conocer RabbitMQ.
+type eventPublisher interface {
+    Publish(ctx context.Context, routingKey, messageID string, body []byte) error
+}
+
+// publisher mantiene una conexión y un canal en modo confirmación para todo
+// el proceso. Si el broker cierra la conexión, la descarta y vuelve a
+// conectarse en el próximo Publish, así que el error solo afecta al mensaje
+// en curso; quien llama decide si reintenta.
+type publisher struct {
+    url string
+
+    mu       sync.Mutex
+    conn     *amqp.Connection
+    ch       *amqp.Channel
+    confirms chan amqp.Confirmation
+    closed   chan *amqp.Error
+}
+
+// errPublishNacked indica que el broker rechazó el mensaje en lugar de confirmarlo
+var errPublishNacked = errors.New("el broker rechazó el mensaje")
+
+func newPublisher(url string) *publisher {
+    return &publisher{url: url}
+}
+
+// channel devuelve el canal abierto, conectándose si no hay ninguno o si el
+// broker lo cerró. Requiere p.mu.
+func (p *publisher) channel() (*amqp.Channel, error) {
+    if p.ch != nil && !p.conn.IsClosed() {
+        select {
+        case <-p.closed:
+        default:
+            return p.ch, nil
+        }
+    }
+    p.reset()
+
+    conn, err := amqp.Dial(p.url)
+    if err != nil {
+        return nil, fmt.Errorf("conectando a RabbitMQ: %w", err)
+    }
+    ch, err := conn.Channel()
+    if err != nil {
+        conn.Close()
+        return nil, fmt.Errorf("abriendo canal: %w", err)
+    }
+    if err := ch.Confirm(false); err != nil {
+        conn.Close()
+        return nil, fmt.Errorf("activando confirmaciones: %w", err)
+    }
+    if err := ch.ExchangeDeclare("events_exchange", "direct", true, false, false, false, nil); err != nil {
+        conn.Close()
+        return nil, fmt.Errorf("declarando exchange: %w", err)
+    }
+
+    p.conn, p.ch = conn, ch
+    p.confirms = ch.NotifyPublish(make(chan amqp.Confirmation, 1))
+    p.closed = ch.NotifyClose(make(chan *amqp.Error, 1))
+    log.Println("Canal de publicación de RabbitMQ conectado")
+    return ch, nil
+}
+
+// reset cierra y olvida la conexión actual. Requiere p.mu.
+func (p *publisher) reset() {
+    if p.conn != nil {
+        p.conn.Close()
+    }
+    p.conn, p.ch, p.confirms, p.closed = nil, nil, nil, nil
+}
+
+// Publish envía un mensaje persistente a events_exchange y espera la
+// confirmación del broker. messageID permite a los consumidores descartar
+// reentregas.
+func (p *publisher) Publish(ctx context.Context, routingKey, messageID string, body []byte) error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+
+    ch, err := p.channel()
+    if err != nil {
+        return err
+    }
+
+    err = ch.Publish("events_exchange", routingKey, false, false, amqp.Publishing{
+        DeliveryMode: amqp.Persistent,
+        ContentType:  "application/json",
+        MessageId:    messageID,
+        Timestamp:    time.Now().UTC(),
+        Body:         body,
+    })
+    if err != nil {
+        p.reset()
+        return fmt.Errorf("publicando %s: %w", routingKey, err)
+    }
+
+    select {
+    case confirmation, ok := <-p.confirms:
+        if !ok {
+            p.reset()
+            return fmt.Errorf("publicando %s: canal cerrado antes de la confirmación", routingKey)
+        }
+        if !confirmation.Ack {
+            return fmt.Errorf("publicando %s: %w", routingKey, errPublishNacked)
+        }
+        return nil
+    case <-ctx.Done():
+        // Una confirmación tardía desordenaría las siguientes: se descarta el canal
+        p.reset()
+        return fmt.Errorf("publicando %s: sin confirmación: %w", routingKey, ctx.Err())
+    }
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ----------- Repositorios -----------------------------------------------------
+
+// Los handlers gRPC aplican las reglas (versiones, cambio de email pendiente,
+// roles, papelera, importación) y llegan al almacenamiento solo a través de
+// UserRepository, ErasureRepository y ExportRepository. Los backends de
+// producción son los de Mongo (repository_mongo.go) y los de memoria
+// (repository_memory.go) respaldan las pruebas. Cada escritura de un usuario
+// recibe los eventos que describen el cambio y el repositorio los guarda en
+// el outbox del documento en esa misma escritura (ver events.go).
+
+// errUserNotFound se devuelve si el usuario no existe o no está en el estado
+// pedido (activo o en la papelera)
+var errUserNotFound = errors.New("usuario no encontrado")
+
+// errErasureNotFound se devuelve si no existe la solicitud de borrado
+var errErasureNotFound = errors.New("solicitud de borrado no encontrada")
+
+// errExportNotFound se devuelve si no existe la exportación pedida
+var errExportNotFound = errors.New("exportación no encontrada")
+
+// versionConflictError se devuelve cuando el usuario existe pero ya no está
+// en la versión esperada
+type versionConflictError struct {
+    Current int64
+}
+
+func (e *versionConflictError) Error() string {
+    return fmt.Sprintf("el usuario está en la versión %d", e.Current)
+}
+
+// userChange es una escritura sobre el documento de un usuario
+type userChange struct {
+    Set    bson.M        // rutas (con punto para subdocumentos) y sus valores
+    Unset  []string      // rutas que se quitan
+    Events []outboxEvent // se agregan al outbox en la misma escritura
+}
+
+// userQuery son los filtros, el orden y la página de ListUsers y de la papelera
+type userQuery struct {
+    Deleted       bool   // la papelera en vez de los usuarios no eliminados
+    Email         string // parte del email, normalizada con searchKey
+    Name          string // parte del nombre completo, normalizada con searchKey
+    Role          string
+    Status        string // "", statusActive o statusPendingVerification
+    CreatedAfter  time.Time
+    CreatedBefore time.Time
+    SortField     string // campo del documento; "_id" ordena por fecha de alta
+    Descending    bool
+    After         *listCursor // la página empieza después de este usuario
+    Limit         int
+}
+
+// listCursor es el último usuario de la página anterior: el valor del campo
+// de orden y el _id que desempata
+type listCursor struct {
+    Value    interface{}
+    ObjectID primitive.ObjectID
+}
+
+// userOutbox son los eventos pendientes de un documento, en orden
+type userOutbox struct {
+    ID     primitive.ObjectID `bson:"_id"`
+    UserID int32              `bson:"id"`
+    Outbox []outboxEvent      `bson:"outbox"`
+}
+
+// storedPassword es la contraseña guardada de un documento
+type storedPassword struct {
+    ID       primitive.ObjectID `bson:"_id"`
+    Password string             `bson:"password"`
+}
+
+// UserRepository guarda los documentos de usuario y el contador de IDs. Los
+// documentos se devuelven como bson.M, con los mismos tipos que lee Mongo.
+type UserRepository interface {
+    // ReserveIDs reserva n IDs consecutivos y devuelve el primero
+    ReserveIDs(ctx context.Context, n int) (int32, error)
+    // Create inserta un usuario nuevo, con su outbox ya armado
+    Create(ctx context.Context, user bson.M) error
+    // CreateMany inserta varios usuarios; uno que falla no impide los demás.
+    // Devuelve los errores de inserción por índice.
+    CreateMany(ctx context.Context, users []bson.M) (map[int]error, error)
+    // Get devuelve un usuario no eliminado o errUserNotFound
+    Get(ctx context.Context, id int32) (bson.M, error)
+    // GetDeleted devuelve un usuario de la papelera o errUserNotFound
+    GetDeleted(ctx context.Context, id int32) (bson.M, error)
+    // Exists dice si el documento existe, esté eliminado o no
+    Exists(ctx context.Context, id int32) (bool, error)
+    // FindByEmailChangeToken devuelve el usuario no eliminado que tiene un
+    // cambio de email pendiente con ese hash de token
+    FindByEmailChangeToken(ctx context.Context, tokenHash string) (bson.M, error)
+    // EmailsInUse devuelve cuáles de los emails pertenecen a un usuario no
+    // eliminado distinto de exceptID (0 no excluye a nadie)
+    EmailsInUse(ctx context.Context, emails []string, exceptID int32) (map[string]bool, error)
+    // Update aplica el cambio a un usuario no eliminado que sigue en version,
+    // incrementa la versión y agrega los eventos al outbox en la misma
+    // escritura. Devuelve errUserNotFound o *versionConflictError.
+    Update(ctx context.Context, id int32, version int64, change userChange) error
+    // Restore saca de la papelera al usuario que sigue en version, con sus
+    // eventos, y devuelve el documento restaurado
+    Restore(ctx context.Context, id int32, version int64, events ...outboxEvent) (bson.M, error)
+    // Purge borra el documento si está en la papelera y no tiene eventos sin
+    // publicar. Devuelve false si no lo borró.
+    Purge(ctx context.Context, id int32) (bool, error)
+    // List devuelve una página de usuarios sin la contraseña y el total que
+    // cumple los filtros
+    List(ctx context.Context, q userQuery) ([]bson.M, int64, error)
+    // DeletedUsers devuelve el id y el deleted_at de los usuarios de la papelera
+    DeletedUsers(ctx context.Context) ([]bson.M, error)
+    // Roles devuelve los roles distintos de los usuarios no eliminados
+    Roles(ctx context.Context) ([]string, error)
+    // PendingEvents devuelve hasta limit documentos con eventos sin publicar
+    PendingEvents(ctx context.Context, limit int) ([]userOutbox, error)
+    // MarkEventFailed registra un intento fallido de publicar el evento
+    MarkEventFailed(ctx context.Context, docID primitive.ObjectID, eventID string, attempts int32, lastError string) error
+    // RemoveEvent quita del outbox un evento ya publicado
+    RemoveEvent(ctx context.Context, docID primitive.ObjectID, eventID string) error
+    // Passwords devuelve las contraseñas guardadas como texto
+    Passwords(ctx context.Context) ([]storedPassword, error)
+    // ReplacePassword cambia la contraseña solo si sigue siendo previous
+    ReplacePassword(ctx context.Context, docID primitive.ObjectID, previous, hash string) (bool, error)
+}
+
+// ErasureRepository guarda las solicitudes de borrado de datos (ver erasure.go)
+type ErasureRepository interface {
+    Create(ctx context.Context, erasure *erasureDoc) error
+    // Get devuelve la solicitud o errErasureNotFound
+    Get(ctx context.Context, id primitive.ObjectID) (*erasureDoc, error)
+    // List devuelve las más recientes primero; status y userID vacíos no filtran
+    List(ctx context.Context, status string, userID int32, limit int) ([]erasureDoc, error)
+    // Transition pasa la solicitud de from a to; completedAt se guarda si no
+    // es nil. Devuelve false si ya no estaba en from.
+    Transition(ctx context.Context, id primitive.ObjectID, from, to string, completedAt *time.Time) (bool, error)
+    // DeletePreparing descarta la solicitud si sigue en preparing
+    DeletePreparing(ctx context.Context, id primitive.ObjectID) error
+    // Preparing devuelve las solicitudes en preparing pedidas hasta cutoff
+    Preparing(ctx context.Context, cutoff time.Time) ([]erasureDoc, error)
+    // DueForRetry devuelve las pendientes no enviadas desde cutoff
+    DueForRetry(ctx context.Context, cutoff time.Time) ([]erasureDoc, error)
+    // RecordAttempt cuenta un envío de la solicitud
+    RecordAttempt(ctx context.Context, id primitive.ObjectID, at time.Time) error
+    // CompleteService registra la confirmación de un participante pendiente
+    CompleteService(ctx context.Context, id primitive.ObjectID, service string, records int64, completedAt time.Time) error
+}
+
+// ExportRepository guarda las exportaciones de datos (ver export.go)
+type ExportRepository interface {
+    Create(ctx context.Context, export *exportDoc) error
+    // Get devuelve la exportación del usuario o errExportNotFound
+    Get(ctx context.Context, id primitive.ObjectID, userID int32) (*exportDoc, error)
+    // Active devuelve la exportación pendiente o en curso del usuario, o
+    // errExportNotFound
+    Active(ctx context.Context, userID int32) (*exportDoc, error)
+    // GetByToken devuelve la exportación completada con ese token de
+    // descarga, o errExportNotFound
+    GetByToken(ctx context.Context, token string) (*exportDoc, error)
+    // ClaimNext pasa a running la pendiente más antigua cuyo próximo intento
+    // ya llegó y cuenta el intento; errExportNotFound si no hay ninguna
+    ClaimNext(ctx context.Context, now time.Time) (*exportDoc, error)
+    // RequeueRunning devuelve a pending las que quedaron en running
+    RequeueRunning(ctx context.Context, now time.Time) (int64, error)
+    // Complete marca la exportación completada si sigue en running.
+    // Devuelve false si ya no lo estaba.
+    Complete(ctx context.Context, export *exportDoc) (bool, error)
+    // Fail guarda el error y el nuevo estado si sigue en running;
+    // nextAttemptAt se guarda si no es cero
+    Fail(ctx context.Context, id primitive.ObjectID, status, message string, nextAttemptAt time.Time) error
+    // Expired devuelve las completadas que vencieron antes de now
+    Expired(ctx context.Context, now time.Time) ([]exportDoc, error)
+    // Expire marca vencida una exportación completada y olvida su token
+    Expire(ctx context.Context, id primitive.ObjectID) error
+    // DeleteByUser borra las exportaciones del usuario y devuelve sus IDs
+    DeleteByUser(ctx context.Context, userID int32) ([]primitive.ObjectID, error)
+}
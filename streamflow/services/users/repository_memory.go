@@ -0,0 +1,839 @@
+package main
+
+import (
+    "context"
+    "fmt"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Los repositorios en memoria reproducen la semántica de Mongo que usan las
+// reglas (versiones, papelera, outbox por documento, índice único de id) para
+// probarlas sin base de datos. Los documentos pasan por bson al guardarse y
+// al leerse, así que tienen los mismos tipos que devuelve el driver y nadie
+// modifica lo guardado por fuera del repositorio.
+
+// copyBSON copia in en out pasando por bson
+func copyBSON(in, out interface{}) error {
+    data, err := bson.Marshal(in)
+    if err != nil {
+        return err
+    }
+    return bson.Unmarshal(data, out)
+}
+
+func cloneDoc(doc bson.M) bson.M {
+    var clone bson.M
+    if err := copyBSON(doc, &clone); err != nil {
+        panic(fmt.Sprintf("copiando documento: %v", err))
+    }
+    return clone
+}
+
+// pathValue lee una ruta con punto de un documento
+func pathValue(doc bson.M, path string) (interface{}, bool) {
+    parts := strings.Split(path, ".")
+    for _, part := range parts[:len(parts)-1] {
+        next, ok := doc[part].(bson.M)
+        if !ok {
+            return nil, false
+        }
+        doc = next
+    }
+    value, ok := doc[parts[len(parts)-1]]
+    return value, ok
+}
+
+// setPath asigna una ruta con punto, creando los subdocumentos que falten
+func setPath(doc bson.M, path string, value interface{}) {
+    parts := strings.Split(path, ".")
+    for _, part := range parts[:len(parts)-1] {
+        next, ok := doc[part].(bson.M)
+        if !ok {
+            next = bson.M{}
+            doc[part] = next
+        }
+        doc = next
+    }
+    doc[parts[len(parts)-1]] = value
+}
+
+func unsetPath(doc bson.M, path string) {
+    parts := strings.Split(path, ".")
+    for _, part := range parts[:len(parts)-1] {
+        next, ok := doc[part].(bson.M)
+        if !ok {
+            return
+        }
+        doc = next
+    }
+    delete(doc, parts[len(parts)-1])
+}
+
+// compareValues ordena como Mongo los tipos que usan los órdenes de la lista
+func compareValues(a, b interface{}) int {
+    number := func(v interface{}) (int64, bool) {
+        switch n := v.(type) {
+        case int32:
+            return int64(n), true
+        case int64:
+            return n, true
+        }
+        return 0, false
+    }
+    if x, ok := number(a); ok {
+        if y, ok := number(b); ok {
+            switch {
+            case x < y:
+                return -1
+            case x > y:
+                return 1
+            }
+            return 0
+        }
+    }
+    if x, ok := a.(primitive.ObjectID); ok {
+        if y, ok := b.(primitive.ObjectID); ok {
+            return strings.Compare(x.Hex(), y.Hex())
+        }
+    }
+    x, _ := a.(string)
+    y, _ := b.(string)
+    return strings.Compare(x, y)
+}
+
+// ----------- Usuarios ---------------------------------------------------------
+
+// memoryUserRepository guarda los documentos en orden de inserción
+type memoryUserRepository struct {
+    mu    sync.Mutex
+    seq   int64
+    users []bson.M
+}
+
+func newMemoryUserRepository() *memoryUserRepository {
+    return &memoryUserRepository{seq: userIDSequenceStart - 1}
+}
+
+func isDeleted(user bson.M) bool {
+    _, ok := user["deleted_at"]
+    return ok
+}
+
+// find devuelve el documento guardado con ese id. Requiere r.mu.
+func (r *memoryUserRepository) find(id int32) bson.M {
+    for _, user := range r.users {
+        if docUserID(user) == id {
+            return user
+        }
+    }
+    return nil
+}
+
+// byObjectID devuelve el documento guardado con ese _id. Requiere r.mu.
+func (r *memoryUserRepository) byObjectID(docID primitive.ObjectID) bson.M {
+    for _, user := range r.users {
+        if user["_id"] == docID {
+            return user
+        }
+    }
+    return nil
+}
+
+// insert guarda una copia del documento, con _id si no lo tiene, respetando
+// el índice único de id. Requiere r.mu.
+func (r *memoryUserRepository) insert(user bson.M) error {
+    doc := cloneDoc(user)
+    if _, ok := doc["_id"]; !ok {
+        doc["_id"] = primitive.NewObjectID()
+    }
+    if id, ok := doc["id"]; ok && r.find(docUserID(doc)) != nil {
+        return fmt.Errorf("E11000 duplicate key error: id %v", id)
+    }
+    r.users = append(r.users, doc)
+    return nil
+}
+
+func (r *memoryUserRepository) ReserveIDs(ctx context.Context, n int) (int32, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    r.seq += int64(n)
+    return int32(r.seq - int64(n) + 1), nil
+}
+
+func (r *memoryUserRepository) Create(ctx context.Context, user bson.M) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    return r.insert(user)
+}
+
+func (r *memoryUserRepository) CreateMany(ctx context.Context, users []bson.M) (map[int]error, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    var failed map[int]error
+    for i, user := range users {
+        if err := r.insert(user); err != nil {
+            if failed == nil {
+                failed = make(map[int]error)
+            }
+            failed[i] = err
+        }
+    }
+    return failed, nil
+}
+
+func (r *memoryUserRepository) Get(ctx context.Context, id int32) (bson.M, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    user := r.find(id)
+    if user == nil || isDeleted(user) {
+        return nil, errUserNotFound
+    }
+    return cloneDoc(user), nil
+}
+
+func (r *memoryUserRepository) GetDeleted(ctx context.Context, id int32) (bson.M, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    user := r.find(id)
+    if user == nil || !isDeleted(user) {
+        return nil, errUserNotFound
+    }
+    return cloneDoc(user), nil
+}
+
+func (r *memoryUserRepository) Exists(ctx context.Context, id int32) (bool, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    return r.find(id) != nil, nil
+}
+
+func (r *memoryUserRepository) FindByEmailChangeToken(ctx context.Context, tokenHash string) (bson.M, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    for _, user := range r.users {
+        if hash, _ := pathValue(user, "pending_email.token_hash"); hash == tokenHash && !isDeleted(user) {
+            return cloneDoc(user), nil
+        }
+    }
+    return nil, errUserNotFound
+}
+
+func (r *memoryUserRepository) EmailsInUse(ctx context.Context, emails []string, exceptID int32) (map[string]bool, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    wanted := make(map[string]bool, len(emails))
+    for _, email := range emails {
+        wanted[email] = true
+    }
+    taken := make(map[string]bool)
+    for _, user := range r.users {
+        email, _ := user["email"].(string)
+        if wanted[email] && !isDeleted(user) && (exceptID == 0 || docUserID(user) != exceptID) {
+            taken[email] = true
+        }
+    }
+    return taken, nil
+}
+
+// forWrite devuelve el documento si está en el estado pedido y en version.
+// Requiere r.mu.
+func (r *memoryUserRepository) forWrite(id int32, version int64, deleted bool) (bson.M, error) {
+    user := r.find(id)
+    if user == nil || isDeleted(user) != deleted {
+        return nil, errUserNotFound
+    }
+    if docVersion(user) != version {
+        return nil, &versionConflictError{Current: docVersion(user)}
+    }
+    return user, nil
+}
+
+// pushEvents agrega los eventos al outbox y sube la versión. Requiere r.mu.
+func pushEvents(user bson.M, events []outboxEvent) {
+    outbox, _ := user["outbox"].(bson.A)
+    for _, event := range events {
+        var doc bson.M
+        if err := copyBSON(event, &doc); err != nil {
+            panic(fmt.Sprintf("copiando evento: %v", err))
+        }
+        outbox = append(outbox, doc)
+    }
+    if len(outbox) > 0 {
+        user["outbox"] = outbox
+    }
+    user["version"] = docVersion(user) + 1
+}
+
+func (r *memoryUserRepository) Update(ctx context.Context, id int32, version int64, change userChange) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    user, err := r.forWrite(id, version, false)
+    if err != nil {
+        return err
+    }
+    // Los valores se copian por bson, como los guardaría Mongo
+    set := cloneDoc(change.Set)
+    for path := range change.Set {
+        setPath(user, path, set[path])
+    }
+    for _, path := range change.Unset {
+        unsetPath(user, path)
+    }
+    pushEvents(user, change.Events)
+    return nil
+}
+
+func (r *memoryUserRepository) Restore(ctx context.Context, id int32, version int64, events ...outboxEvent) (bson.M, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    user, err := r.forWrite(id, version, true)
+    if err != nil {
+        return nil, err
+    }
+    delete(user, "deleted_at")
+    pushEvents(user, events)
+    return cloneDoc(user), nil
+}
+
+func (r *memoryUserRepository) Purge(ctx context.Context, id int32) (bool, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    for i, user := range r.users {
+        if docUserID(user) == id && isDeleted(user) && pendingEventCount(user) == 0 {
+            r.users = append(r.users[:i], r.users[i+1:]...)
+            return true, nil
+        }
+    }
+    return false, nil
+}
+
+// matches aplica los filtros de la consulta, sin la página
+func (q userQuery) matches(user bson.M) bool {
+    if isDeleted(user) != q.Deleted {
+        return false
+    }
+    search := func(field string) string {
+        value, _ := pathValue(user, "search."+field)
+        s, _ := value.(string)
+        return s
+    }
+    if q.Email != "" && !strings.Contains(search("email"), q.Email) {
+        return false
+    }
+    if q.Name != "" && !strings.Contains(search("full_name"), q.Name) {
+        return false
+    }
+    if q.Role != "" && user["role"] != q.Role {
+        return false
+    }
+    if q.Status != "" && userStatus(user) != q.Status {
+        return false
+    }
+    created := user["_id"].(primitive.ObjectID).Timestamp()
+    if !q.CreatedAfter.IsZero() && created.Before(q.CreatedAfter.Truncate(time.Second)) {
+        return false
+    }
+    if !q.CreatedBefore.IsZero() && !created.Before(q.CreatedBefore.Truncate(time.Second)) {
+        return false
+    }
+    return true
+}
+
+// compare ordena dos documentos según la consulta, desempatando por _id
+func (q userQuery) compare(a, b bson.M) int {
+    c := 0
+    if q.SortField != "_id" {
+        x, _ := pathValue(a, q.SortField)
+        y, _ := pathValue(b, q.SortField)
+        c = compareValues(x, y)
+    }
+    if c == 0 {
+        c = compareValues(a["_id"], b["_id"])
+    }
+    if q.Descending {
+        return -c
+    }
+    return c
+}
+
+func (r *memoryUserRepository) List(ctx context.Context, q userQuery) ([]bson.M, int64, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    var matched []bson.M
+    for _, user := range r.users {
+        if q.matches(user) {
+            matched = append(matched, user)
+        }
+    }
+    sort.SliceStable(matched, func(i, j int) bool { return q.compare(matched[i], matched[j]) < 0 })
+
+    var cursor bson.M
+    if q.After != nil {
+        cursor = bson.M{"_id": q.After.ObjectID}
+        if q.SortField != "_id" {
+            setPath(cursor, q.SortField, q.After.Value)
+        }
+    }
+    docs := make([]bson.M, 0)
+    for _, user := range matched {
+        if cursor != nil && q.compare(user, cursor) <= 0 {
+            continue
+        }
+        if q.Limit > 0 && len(docs) == q.Limit {
+            break
+        }
+        doc := cloneDoc(user)
+        delete(doc, "password")
+        docs = append(docs, doc)
+    }
+    return docs, int64(len(matched)), nil
+}
+
+func (r *memoryUserRepository) DeletedUsers(ctx context.Context) ([]bson.M, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    var docs []bson.M
+    for _, user := range r.users {
+        if isDeleted(user) {
+            docs = append(docs, bson.M{"_id": user["_id"], "id": user["id"], "deleted_at": user["deleted_at"]})
+        }
+    }
+    return docs, nil
+}
+
+func (r *memoryUserRepository) Roles(ctx context.Context) ([]string, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    seen := make(map[string]bool)
+    var roles []string
+    for _, user := range r.users {
+        role, ok := user["role"].(string)
+        if ok && !isDeleted(user) && !seen[role] {
+            seen[role] = true
+            roles = append(roles, role)
+        }
+    }
+    return roles, nil
+}
+
+func (r *memoryUserRepository) PendingEvents(ctx context.Context, limit int) ([]userOutbox, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    var docs []userOutbox
+    for _, user := range r.users {
+        if pendingEventCount(user) == 0 {
+            continue
+        }
+        if len(docs) == limit {
+            break
+        }
+        var doc userOutbox
+        if err := copyBSON(bson.M{"_id": user["_id"], "id": user["id"], "outbox": user["outbox"]}, &doc); err != nil {
+            return nil, err
+        }
+        docs = append(docs, doc)
+    }
+    return docs, nil
+}
+
+// event devuelve el evento guardado en el outbox del documento. Requiere r.mu.
+func (r *memoryUserRepository) event(docID primitive.ObjectID, eventID string) (bson.A, int) {
+    user := r.byObjectID(docID)
+    if user == nil {
+        return nil, -1
+    }
+    outbox, _ := user["outbox"].(bson.A)
+    for i, raw := range outbox {
+        if event, ok := raw.(bson.M); ok && event["event_id"] == eventID {
+            return outbox, i
+        }
+    }
+    return outbox, -1
+}
+
+func (r *memoryUserRepository) MarkEventFailed(ctx context.Context, docID primitive.ObjectID, eventID string, attempts int32, lastError string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if outbox, i := r.event(docID, eventID); i >= 0 {
+        event := outbox[i].(bson.M)
+        event["attempts"] = attempts
+        event["last_error"] = lastError
+    }
+    return nil
+}
+
+func (r *memoryUserRepository) RemoveEvent(ctx context.Context, docID primitive.ObjectID, eventID string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    outbox, i := r.event(docID, eventID)
+    if i < 0 {
+        return nil
+    }
+    user := r.byObjectID(docID)
+    user["outbox"] = append(outbox[:i:i], outbox[i+1:]...)
+    return nil
+}
+
+func (r *memoryUserRepository) Passwords(ctx context.Context) ([]storedPassword, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    var docs []storedPassword
+    for _, user := range r.users {
+        if password, ok := user["password"].(string); ok {
+            docs = append(docs, storedPassword{ID: user["_id"].(primitive.ObjectID), Password: password})
+        }
+    }
+    return docs, nil
+}
+
+func (r *memoryUserRepository) ReplacePassword(ctx context.Context, docID primitive.ObjectID, previous, hash string) (bool, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    user := r.byObjectID(docID)
+    if user == nil || user["password"] != previous || previous == hash {
+        return false, nil
+    }
+    user["password"] = hash
+    return true, nil
+}
+
+// ----------- Solicitudes de borrado ------------------------------------------
+
+type memoryErasureRepository struct {
+    mu       sync.Mutex
+    erasures []*erasureDoc
+}
+
+func newMemoryErasureRepository() *memoryErasureRepository {
+    return &memoryErasureRepository{}
+}
+
+func cloneErasure(erasure *erasureDoc) erasureDoc {
+    var clone erasureDoc
+    if err := copyBSON(erasure, &clone); err != nil {
+        panic(fmt.Sprintf("copiando solicitud de borrado: %v", err))
+    }
+    return clone
+}
+
+// find devuelve la solicitud guardada. Requiere r.mu.
+func (r *memoryErasureRepository) find(id primitive.ObjectID) *erasureDoc {
+    for _, erasure := range r.erasures {
+        if erasure.ID == id {
+            return erasure
+        }
+    }
+    return nil
+}
+
+// filter copia las solicitudes que cumplen keep. Requiere r.mu.
+func (r *memoryErasureRepository) filter(keep func(*erasureDoc) bool) []erasureDoc {
+    var erasures []erasureDoc
+    for _, erasure := range r.erasures {
+        if keep(erasure) {
+            erasures = append(erasures, cloneErasure(erasure))
+        }
+    }
+    return erasures
+}
+
+func (r *memoryErasureRepository) Create(ctx context.Context, erasure *erasureDoc) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    clone := cloneErasure(erasure)
+    r.erasures = append(r.erasures, &clone)
+    return nil
+}
+
+func (r *memoryErasureRepository) Get(ctx context.Context, id primitive.ObjectID) (*erasureDoc, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    erasure := r.find(id)
+    if erasure == nil {
+        return nil, errErasureNotFound
+    }
+    clone := cloneErasure(erasure)
+    return &clone, nil
+}
+
+func (r *memoryErasureRepository) List(ctx context.Context, status string, userID int32, limit int) ([]erasureDoc, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    erasures := r.filter(func(e *erasureDoc) bool {
+        return (status == "" || e.Status == status) && (userID == 0 || e.UserID == userID)
+    })
+    sort.Slice(erasures, func(i, j int) bool { return compareValues(erasures[i].ID, erasures[j].ID) > 0 })
+    if len(erasures) > limit {
+        erasures = erasures[:limit]
+    }
+    return erasures, nil
+}
+
+func (r *memoryErasureRepository) Transition(ctx context.Context, id primitive.ObjectID, from, to string, completedAt *time.Time) (bool, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    erasure := r.find(id)
+    if erasure == nil || erasure.Status != from {
+        return false, nil
+    }
+    erasure.Status = to
+    if completedAt != nil {
+        at := *completedAt
+        erasure.CompletedAt = &at
+    }
+    return true, nil
+}
+
+func (r *memoryErasureRepository) DeletePreparing(ctx context.Context, id primitive.ObjectID) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    for i, erasure := range r.erasures {
+        if erasure.ID == id && erasure.Status == erasureStatusPreparing {
+            r.erasures = append(r.erasures[:i], r.erasures[i+1:]...)
+            break
+        }
+    }
+    return nil
+}
+
+func (r *memoryErasureRepository) Preparing(ctx context.Context, cutoff time.Time) ([]erasureDoc, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    return r.filter(func(e *erasureDoc) bool {
+        return e.Status == erasureStatusPreparing && !e.RequestedAt.After(cutoff)
+    }), nil
+}
+
+func (r *memoryErasureRepository) DueForRetry(ctx context.Context, cutoff time.Time) ([]erasureDoc, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    return r.filter(func(e *erasureDoc) bool {
+        return e.Status == erasureStatusPending && (e.LastAttemptAt == nil || !e.LastAttemptAt.After(cutoff))
+    }), nil
+}
+
+func (r *memoryErasureRepository) RecordAttempt(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if erasure := r.find(id); erasure != nil {
+        erasure.Attempts++
+        erasure.LastAttemptAt = &at
+    }
+    return nil
+}
+
+func (r *memoryErasureRepository) CompleteService(ctx context.Context, id primitive.ObjectID, service string, records int64, completedAt time.Time) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    erasure := r.find(id)
+    if erasure == nil {
+        return nil
+    }
+    state, ok := erasure.Services[service]
+    if !ok || state.Status != erasureStatusPending {
+        return nil
+    }
+    erasure.Services[service] = erasureServiceDoc{Status: erasureStatusCompleted, Records: records, CompletedAt: &completedAt}
+    return nil
+}
+
+// ----------- Exportaciones ---------------------------------------------------
+
+type memoryExportRepository struct {
+    mu      sync.Mutex
+    exports []*exportDoc
+}
+
+func newMemoryExportRepository() *memoryExportRepository {
+    return &memoryExportRepository{}
+}
+
+// first devuelve una copia de la primera exportación que cumple match, o
+// errExportNotFound. Requiere r.mu.
+func (r *memoryExportRepository) first(match func(*exportDoc) bool) (*exportDoc, error) {
+    for _, export := range r.exports {
+        if match(export) {
+            clone := *export
+            return &clone, nil
+        }
+    }
+    return nil, errExportNotFound
+}
+
+func (r *memoryExportRepository) Create(ctx context.Context, export *exportDoc) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    clone := *export
+    r.exports = append(r.exports, &clone)
+    return nil
+}
+
+func (r *memoryExportRepository) Get(ctx context.Context, id primitive.ObjectID, userID int32) (*exportDoc, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    return r.first(func(e *exportDoc) bool { return e.ID == id && e.UserID == userID })
+}
+
+func (r *memoryExportRepository) Active(ctx context.Context, userID int32) (*exportDoc, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    return r.first(func(e *exportDoc) bool {
+        return e.UserID == userID && (e.Status == exportStatusPending || e.Status == exportStatusRunning)
+    })
+}
+
+func (r *memoryExportRepository) GetByToken(ctx context.Context, token string) (*exportDoc, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    return r.first(func(e *exportDoc) bool { return e.DownloadToken == token && e.Status == exportStatusCompleted })
+}
+
+func (r *memoryExportRepository) ClaimNext(ctx context.Context, now time.Time) (*exportDoc, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    var next *exportDoc
+    for _, export := range r.exports {
+        if export.Status == exportStatusPending && !export.NextAttemptAt.After(now) &&
+            (next == nil || export.NextAttemptAt.Before(next.NextAttemptAt)) {
+            next = export
+        }
+    }
+    if next == nil {
+        return nil, errExportNotFound
+    }
+    next.Status = exportStatusRunning
+    next.Attempts++
+    clone := *next
+    return &clone, nil
+}
+
+func (r *memoryExportRepository) RequeueRunning(ctx context.Context, now time.Time) (int64, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    var requeued int64
+    for _, export := range r.exports {
+        if export.Status == exportStatusRunning {
+            export.Status = exportStatusPending
+            export.NextAttemptAt = now
+            requeued++
+        }
+    }
+    return requeued, nil
+}
+
+func (r *memoryExportRepository) Complete(ctx context.Context, completed *exportDoc) (bool, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    for _, export := range r.exports {
+        if export.ID == completed.ID && export.Status == exportStatusRunning {
+            export.Status = exportStatusCompleted
+            export.CompletedAt = completed.CompletedAt
+            export.ExpiresAt = completed.ExpiresAt
+            export.DownloadToken = completed.DownloadToken
+            export.SizeBytes = completed.SizeBytes
+            export.Error = ""
+            return true, nil
+        }
+    }
+    return false, nil
+}
+
+func (r *memoryExportRepository) Fail(ctx context.Context, id primitive.ObjectID, status, message string, nextAttemptAt time.Time) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    for _, export := range r.exports {
+        if export.ID == id && export.Status == exportStatusRunning {
+            export.Status = status
+            export.Error = message
+            if !nextAttemptAt.IsZero() {
+                export.NextAttemptAt = nextAttemptAt
+            }
+        }
+    }
+    return nil
+}
+
+func (r *memoryExportRepository) Expired(ctx context.Context, now time.Time) ([]exportDoc, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    var expired []exportDoc
+    for _, export := range r.exports {
+        if export.Status == exportStatusCompleted && export.ExpiresAt != nil && !export.ExpiresAt.After(now) {
+            expired = append(expired, *export)
+        }
+    }
+    return expired, nil
+}
+
+func (r *memoryExportRepository) Expire(ctx context.Context, id primitive.ObjectID) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    for _, export := range r.exports {
+        if export.ID == id && export.Status == exportStatusCompleted {
+            export.Status = exportStatusExpired
+            export.DownloadToken = ""
+        }
+    }
+    return nil
+}
+
+func (r *memoryExportRepository) DeleteByUser(ctx context.Context, userID int32) ([]primitive.ObjectID, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    var ids []primitive.ObjectID
+    kept := r.exports[:0]
+    for _, export := range r.exports {
+        if export.UserID == userID {
+            ids = append(ids, export.ID)
+            continue
+        }
+        kept = append(kept, export)
+    }
+    r.exports = kept
+    return ids, nil
+}
@@ -0,0 +1,844 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "regexp"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+    "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoUserRepository guarda los usuarios en la colección users y el
+// contador de IDs en counters
+type mongoUserRepository struct {
+    col      *mongo.Collection
+    counters *mongo.Collection
+}
+
+func newMongoUserRepository(db *mongo.Database) *mongoUserRepository {
+    return &mongoUserRepository{col: db.Collection("users"), counters: db.Collection("counters")}
+}
+
+// notDeleted filtra los usuarios que no fueron eliminados
+func notDeleted(filter bson.M) bson.M {
+    filter["deleted_at"] = bson.M{"$exists": false}
+    return filter
+}
+
+// deletedFilter selecciona usuarios en la papelera
+func deletedFilter(filter bson.M) bson.M {
+    filter["deleted_at"] = bson.M{"$exists": true}
+    return filter
+}
+
+// hasPendingEvents dice si un documento tiene eventos sin publicar
+func hasPendingEvents(filter bson.M) bson.M {
+    filter["outbox.event_id"] = bson.M{"$exists": true}
+    return filter
+}
+
+// withEvents agrega los eventos al outbox en la misma escritura, en el
+// orden en que se publican
+func withEvents(update bson.M, events ...outboxEvent) bson.M {
+    if len(events) > 0 {
+        update["$push"] = bson.M{"outbox": bson.M{"$each": events}}
+    }
+    return update
+}
+
+// containing busca el texto literal en cualquier parte del campo. Los campos
+// de search ya están en minúsculas, así que no hace falta la opción "i".
+func containing(value string) primitive.Regex {
+    return primitive.Regex{Pattern: regexp.QuoteMeta(value)}
+}
+
+func (r *mongoUserRepository) findOne(ctx context.Context, filter bson.M) (bson.M, error) {
+    var user bson.M
+    err := r.col.FindOne(ctx, filter).Decode(&user)
+    if errors.Is(err, mongo.ErrNoDocuments) {
+        return nil, errUserNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    return user, nil
+}
+
+func (r *mongoUserRepository) ReserveIDs(ctx context.Context, n int) (int32, error) {
+    var counter struct {
+        Seq int64 `bson:"seq"`
+    }
+    err := r.counters.FindOneAndUpdate(ctx,
+        bson.M{"_id": userIDCounter},
+        bson.M{"$inc": bson.M{"seq": n}},
+        options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+    ).Decode(&counter)
+    if err != nil {
+        return 0, fmt.Errorf("reservando ID de usuario: %w", err)
+    }
+    if counter.Seq > 1<<31-1 {
+        return 0, fmt.Errorf("el contador de IDs de usuario superó int32")
+    }
+    return int32(counter.Seq - int64(n) + 1), nil
+}
+
+func (r *mongoUserRepository) Create(ctx context.Context, user bson.M) error {
+    _, err := r.col.InsertOne(ctx, user)
+    return err
+}
+
+func (r *mongoUserRepository) CreateMany(ctx context.Context, users []bson.M) (map[int]error, error) {
+    docs := make([]interface{}, len(users))
+    for i, user := range users {
+        docs[i] = user
+    }
+    _, err := r.col.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+    var bulkErr mongo.BulkWriteException
+    if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil {
+        failed := make(map[int]error, len(bulkErr.WriteErrors))
+        for _, writeErr := range bulkErr.WriteErrors {
+            failed[writeErr.Index] = errors.New(writeErr.Message)
+        }
+        return failed, nil
+    }
+    return nil, err
+}
+
+func (r *mongoUserRepository) Get(ctx context.Context, id int32) (bson.M, error) {
+    return r.findOne(ctx, notDeleted(bson.M{"id": id}))
+}
+
+func (r *mongoUserRepository) GetDeleted(ctx context.Context, id int32) (bson.M, error) {
+    return r.findOne(ctx, deletedFilter(bson.M{"id": id}))
+}
+
+func (r *mongoUserRepository) Exists(ctx context.Context, id int32) (bool, error) {
+    count, err := r.col.CountDocuments(ctx, bson.M{"id": id})
+    return count > 0, err
+}
+
+func (r *mongoUserRepository) FindByEmailChangeToken(ctx context.Context, tokenHash string) (bson.M, error) {
+    return r.findOne(ctx, notDeleted(bson.M{"pending_email.token_hash": tokenHash}))
+}
+
+func (r *mongoUserRepository) EmailsInUse(ctx context.Context, emails []string, exceptID int32) (map[string]bool, error) {
+    filter := notDeleted(bson.M{"email": bson.M{"$in": emails}})
+    if exceptID != 0 {
+        filter["id"] = bson.M{"$ne": exceptID}
+    }
+    cur, err := r.col.Find(ctx, filter, options.Find().SetProjection(bson.M{"email": 1}))
+    if err != nil {
+        return nil, err
+    }
+    var docs []struct {
+        Email string `bson:"email"`
+    }
+    if err := cur.All(ctx, &docs); err != nil {
+        return nil, err
+    }
+    taken := make(map[string]bool, len(docs))
+    for _, doc := range docs {
+        taken[doc.Email] = true
+    }
+    return taken, nil
+}
+
+func (r *mongoUserRepository) Update(ctx context.Context, id int32, version int64, change userChange) error {
+    update := bson.M{"$inc": bson.M{"version": 1}}
+    if len(change.Set) > 0 {
+        update["$set"] = change.Set
+    }
+    if len(change.Unset) > 0 {
+        unset := bson.M{}
+        for _, path := range change.Unset {
+            unset[path] = ""
+        }
+        update["$unset"] = unset
+    }
+
+    // Condicionado a la versión leída, para que los eventos describan este cambio
+    res, err := r.col.UpdateOne(ctx, notDeleted(bson.M{"id": id, "version": version}), withEvents(update, change.Events...))
+    if err != nil {
+        return err
+    }
+    if res.MatchedCount > 0 {
+        return nil
+    }
+    current, err := r.findOne(ctx, notDeleted(bson.M{"id": id}))
+    if err != nil {
+        return err
+    }
+    return &versionConflictError{Current: docVersion(current)}
+}
+
+func (r *mongoUserRepository) Restore(ctx context.Context, id int32, version int64, events ...outboxEvent) (bson.M, error) {
+    var restored bson.M
+    err := r.col.FindOneAndUpdate(ctx,
+        deletedFilter(bson.M{"id": id, "version": version}),
+        withEvents(bson.M{"$unset": bson.M{"deleted_at": ""}, "$inc": bson.M{"version": 1}}, events...),
+        options.FindOneAndUpdate().SetReturnDocument(options.After),
+    ).Decode(&restored)
+    if errors.Is(err, mongo.ErrNoDocuments) {
+        current, err := r.GetDeleted(ctx, id)
+        if err != nil {
+            return nil, err
+        }
+        return nil, &versionConflictError{Current: docVersion(current)}
+    }
+    if err != nil {
+        return nil, err
+    }
+    return restored, nil
+}
+
+func (r *mongoUserRepository) Purge(ctx context.Context, id int32) (bool, error) {
+    // Un usuario con eventos sin publicar (user.deleted) espera al relay
+    res, err := r.col.DeleteOne(ctx, deletedFilter(bson.M{"id": id, "outbox.event_id": bson.M{"$exists": false}}))
+    if err != nil {
+        return false, err
+    }
+    return res.DeletedCount > 0, nil
+}
+
+// userQueryFilter traduce los filtros de la consulta, sin la página
+func userQueryFilter(q userQuery) bson.M {
+    filter := notDeleted(bson.M{})
+    if q.Deleted {
+        filter = deletedFilter(bson.M{})
+    }
+    if q.Email != "" {
+        filter["search.email"] = containing(q.Email)
+    }
+    if q.Name != "" {
+        filter["search.full_name"] = containing(q.Name)
+    }
+    if q.Role != "" {
+        filter["role"] = q.Role
+    }
+    switch q.Status {
+    case statusActive:
+        // Los usuarios previos a la verificación no tienen status y están activos
+        filter["status"] = bson.M{"$in": bson.A{statusActive, nil}}
+    case statusPendingVerification:
+        filter["status"] = statusPendingVerification
+    }
+
+    // La fecha de alta es el timestamp del ObjectID, que está indexado
+    created := bson.M{}
+    if !q.CreatedAfter.IsZero() {
+        created["$gte"] = primitive.NewObjectIDFromTimestamp(q.CreatedAfter)
+    }
+    if !q.CreatedBefore.IsZero() {
+        created["$lt"] = primitive.NewObjectIDFromTimestamp(q.CreatedBefore)
+    }
+    if len(created) > 0 {
+        filter["_id"] = created
+    }
+    return filter
+}
+
+// keysetFilter devuelve los documentos posteriores al cursor según el orden
+// pedido, desempatando por _id
+func keysetFilter(sortField string, descending bool, after *listCursor) bson.M {
+    op := "$gt"
+    if descending {
+        op = "$lt"
+    }
+    if sortField == "_id" {
+        return bson.M{"_id": bson.M{op: after.ObjectID}}
+    }
+    return bson.M{"$or": bson.A{
+        bson.M{sortField: bson.M{op: after.Value}},
+        bson.M{sortField: after.Value, "_id": bson.M{op: after.ObjectID}},
+    }}
+}
+
+func (r *mongoUserRepository) List(ctx context.Context, q userQuery) ([]bson.M, int64, error) {
+    filter := userQueryFilter(q)
+    total, err := r.col.CountDocuments(ctx, filter)
+    if err != nil {
+        return nil, 0, err
+    }
+
+    pageFilter := filter
+    if q.After != nil {
+        pageFilter = bson.M{"$and": bson.A{filter, keysetFilter(q.SortField, q.Descending, q.After)}}
+    }
+    direction := 1
+    if q.Descending {
+        direction = -1
+    }
+    sort := bson.D{{Key: q.SortField, Value: direction}}
+    if q.SortField != "_id" {
+        sort = append(sort, bson.E{Key: "_id", Value: direction})
+    }
+
+    cur, err := r.col.Find(ctx, pageFilter, options.Find().
+        SetSort(sort).
+        SetLimit(int64(q.Limit)).
+        SetProjection(bson.M{"password": 0}))
+    if err != nil {
+        return nil, 0, err
+    }
+    defer cur.Close(ctx)
+
+    var docs []bson.M
+    if err := cur.All(ctx, &docs); err != nil {
+        return nil, 0, err
+    }
+    return docs, total, nil
+}
+
+func (r *mongoUserRepository) DeletedUsers(ctx context.Context) ([]bson.M, error) {
+    cur, err := r.col.Find(ctx, deletedFilter(bson.M{}),
+        options.Find().SetProjection(bson.M{"id": 1, "deleted_at": 1}))
+    if err != nil {
+        return nil, err
+    }
+    var docs []bson.M
+    if err := cur.All(ctx, &docs); err != nil {
+        return nil, err
+    }
+    return docs, nil
+}
+
+func (r *mongoUserRepository) Roles(ctx context.Context) ([]string, error) {
+    values, err := r.col.Distinct(ctx, "role", notDeleted(bson.M{}))
+    if err != nil {
+        return nil, err
+    }
+    roles := make([]string, 0, len(values))
+    for _, value := range values {
+        if role, ok := value.(string); ok {
+            roles = append(roles, role)
+        }
+    }
+    return roles, nil
+}
+
+func (r *mongoUserRepository) PendingEvents(ctx context.Context, limit int) ([]userOutbox, error) {
+    cur, err := r.col.Find(ctx, hasPendingEvents(bson.M{}),
+        options.Find().SetProjection(bson.M{"id": 1, "outbox": 1}).SetLimit(int64(limit)))
+    if err != nil {
+        return nil, err
+    }
+    var docs []userOutbox
+    if err := cur.All(ctx, &docs); err != nil {
+        return nil, err
+    }
+    return docs, nil
+}
+
+func (r *mongoUserRepository) MarkEventFailed(ctx context.Context, docID primitive.ObjectID, eventID string, attempts int32, lastError string) error {
+    _, err := r.col.UpdateOne(ctx,
+        bson.M{"_id": docID, "outbox.event_id": eventID},
+        bson.M{"$set": bson.M{"outbox.$.attempts": attempts, "outbox.$.last_error": lastError}},
+    )
+    return err
+}
+
+func (r *mongoUserRepository) RemoveEvent(ctx context.Context, docID primitive.ObjectID, eventID string) error {
+    _, err := r.col.UpdateOne(ctx, bson.M{"_id": docID}, bson.M{"$pull": bson.M{"outbox": bson.M{"event_id": eventID}}})
+    return err
+}
+
+func (r *mongoUserRepository) Passwords(ctx context.Context) ([]storedPassword, error) {
+    cur, err := r.col.Find(ctx,
+        bson.M{"password": bson.M{"$type": "string"}},
+        options.Find().SetProjection(bson.M{"password": 1}),
+    )
+    if err != nil {
+        return nil, err
+    }
+    var docs []storedPassword
+    if err := cur.All(ctx, &docs); err != nil {
+        return nil, err
+    }
+    return docs, nil
+}
+
+func (r *mongoUserRepository) ReplacePassword(ctx context.Context, docID primitive.ObjectID, previous, hash string) (bool, error) {
+    res, err := r.col.UpdateOne(ctx,
+        bson.M{"_id": docID, "password": previous},
+        bson.M{"$set": bson.M{"password": hash}},
+    )
+    if err != nil {
+        return false, err
+    }
+    return res.ModifiedCount > 0, nil
+}
+
+// ----------- Índices y migraciones de la colección users ----------------------
+
+// outboxIndexes permite al relay encontrar los documentos con eventos pendientes
+var outboxIndexes = []mongo.IndexModel{
+    {Keys: bson.D{{Key: "outbox.event_id", Value: 1}}, Options: options.Index().SetName("outbox_pending").SetSparse(true)},
+}
+
+// emailChangeIndexes permite buscar el cambio pendiente por el hash del token
+var emailChangeIndexes = []mongo.IndexModel{
+    {Keys: bson.D{{Key: "pending_email.token_hash", Value: 1}}, Options: options.Index().SetName("pending_email_token").SetSparse(true)},
+}
+
+// listIndexes acompañan los órdenes de ListUsers, las búsquedas por email y
+// nombre, los filtros por rol y la papelera
+var listIndexes = []mongo.IndexModel{
+    {Keys: bson.D{{Key: "search.email", Value: 1}}, Options: options.Index().SetName("email_search")},
+    {Keys: bson.D{{Key: "search.full_name", Value: 1}}, Options: options.Index().SetName("full_name_search")},
+    {Keys: bson.D{{Key: "email", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName("email_list")},
+    {Keys: bson.D{{Key: "first_name", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName("first_name_list")},
+    {Keys: bson.D{{Key: "last_name", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName("last_name_list")},
+    {Keys: bson.D{{Key: "role", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName("role_list")},
+    {
+        Keys: bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: -1}},
+        Options: options.Index().SetName("trash_list").
+            SetPartialFilterExpression(bson.M{"deleted_at": bson.M{"$exists": true}}),
+    },
+}
+
+// ensureIndexes crea los índices de las búsquedas por ID y por email, los de
+// los órdenes de ListUsers, el del relay de eventos y el de los cambios de
+// email pendientes
+func (r *mongoUserRepository) ensureIndexes(ctx context.Context) error {
+    indexes := []mongo.IndexModel{
+        {
+            // Parcial para que los documentos aún sin migrar no choquen
+            Keys: bson.D{{Key: "id", Value: 1}},
+            Options: options.Index().SetName("id_unique").SetUnique(true).
+                SetPartialFilterExpression(bson.M{"id": bson.M{"$exists": true}}),
+        },
+        {
+            Keys:    bson.D{{Key: "email", Value: 1}},
+            Options: options.Index().SetName("email"),
+        },
+    }
+    indexes = append(indexes, listIndexes...)
+    indexes = append(indexes, outboxIndexes...)
+    indexes = append(indexes, emailChangeIndexes...)
+    _, err := r.col.Indexes().CreateMany(ctx, indexes)
+    if err != nil {
+        return fmt.Errorf("creando índices de usuarios: %w", err)
+    }
+    return nil
+}
+
+// migrateIDs asigna el campo id a los documentos anteriores al contador.
+// Cada usuario conserva el ID que ya conocían los demás servicios (el
+// timestamp de su ObjectID); si otro usuario ya lo tiene, recibe uno nuevo.
+// Después adelanta el contador por encima del mayor ID. Es idempotente y
+// corre al iniciar.
+func (r *mongoUserRepository) migrateIDs(ctx context.Context) (migrated int, reassigned int, err error) {
+    cursor, err := r.col.Find(ctx,
+        bson.M{"id": bson.M{"$exists": false}},
+        options.Find().SetProjection(bson.M{"_id": 1, "email": 1}).SetSort(bson.M{"_id": 1}),
+    )
+    if err != nil {
+        return 0, 0, fmt.Errorf("buscando usuarios sin id: %w", err)
+    }
+    defer cursor.Close(ctx)
+
+    // Los IDs repetidos se reasignan al final, cuando el contador ya supera
+    // todos los IDs heredados
+    var pending []primitive.ObjectID
+    for cursor.Next(ctx) {
+        var doc struct {
+            ID    primitive.ObjectID `bson:"_id"`
+            Email string             `bson:"email"`
+        }
+        if err := cursor.Decode(&doc); err != nil {
+            return migrated, reassigned, fmt.Errorf("leyendo usuario: %w", err)
+        }
+
+        legacyID := int32(doc.ID.Timestamp().Unix())
+        _, err := r.col.UpdateOne(ctx,
+            bson.M{"_id": doc.ID, "id": bson.M{"$exists": false}},
+            bson.M{"$set": bson.M{"id": legacyID}},
+        )
+        if mongo.IsDuplicateKeyError(err) {
+            log.Printf("El ID %d de %s ya pertenece a otro usuario, se asignará uno nuevo", legacyID, doc.Email)
+            pending = append(pending, doc.ID)
+            continue
+        }
+        if err != nil {
+            return migrated, reassigned, fmt.Errorf("asignando id a %s: %w", doc.ID.Hex(), err)
+        }
+        migrated++
+    }
+    if err := cursor.Err(); err != nil {
+        return migrated, reassigned, err
+    }
+
+    if err := r.advanceIDCounter(ctx); err != nil {
+        return migrated, reassigned, err
+    }
+
+    for _, objectID := range pending {
+        newID, err := r.ReserveIDs(ctx, 1)
+        if err != nil {
+            return migrated, reassigned, err
+        }
+        res, err := r.col.UpdateOne(ctx,
+            bson.M{"_id": objectID, "id": bson.M{"$exists": false}},
+            bson.M{"$set": bson.M{"id": newID}},
+        )
+        if err != nil {
+            return migrated, reassigned, fmt.Errorf("asignando id a %s: %w", objectID.Hex(), err)
+        }
+        if res.ModifiedCount > 0 {
+            log.Printf("Usuario %s migrado con el nuevo ID %d", objectID.Hex(), newID)
+            reassigned++
+        }
+    }
+    return migrated, reassigned, nil
+}
+
+// advanceIDCounter deja el contador en el mayor ID existente (o en el
+// inicio de la secuencia), sin bajarlo nunca
+func (r *mongoUserRepository) advanceIDCounter(ctx context.Context) error {
+    maxID := int64(userIDSequenceStart - 1)
+
+    var last bson.M
+    err := r.col.FindOne(ctx,
+        bson.M{"id": bson.M{"$exists": true}},
+        options.FindOne().SetSort(bson.M{"id": -1}).SetProjection(bson.M{"id": 1}),
+    ).Decode(&last)
+    if err != nil && err != mongo.ErrNoDocuments {
+        return fmt.Errorf("buscando el mayor ID de usuario: %w", err)
+    }
+    if id := int64(docUserID(last)); id > maxID {
+        maxID = id
+    }
+
+    _, err = r.counters.UpdateOne(ctx,
+        bson.M{"_id": userIDCounter},
+        bson.M{"$max": bson.M{"seq": maxID}},
+        options.Update().SetUpsert(true),
+    )
+    if err != nil {
+        return fmt.Errorf("actualizando el contador de IDs: %w", err)
+    }
+    return nil
+}
+
+// migrateSearchFields arma el subdocumento search (email y nombre completo,
+// como searchFields) de los usuarios que no lo tienen o que guardan todavía
+// nombre y apellido por separado, y borra los índices de esos campos. Es
+// idempotente y corre al iniciar.
+func (r *mongoUserRepository) migrateSearchFields(ctx context.Context) (int64, error) {
+    trimmed := func(field string) bson.M { return bson.M{"$trim": bson.M{"input": field}} }
+    res, err := r.col.UpdateMany(ctx,
+        bson.M{"search.full_name": bson.M{"$exists": false}},
+        mongo.Pipeline{{{Key: "$set", Value: bson.M{"search": bson.M{
+            "email": bson.M{"$toLower": trimmed("$email")},
+            "full_name": bson.M{"$toLower": bson.M{"$trim": bson.M{"input": bson.M{
+                "$concat": bson.A{trimmed("$first_name"), " ", trimmed("$last_name")},
+            }}}},
+        }}}}},
+    )
+    if err != nil {
+        return 0, fmt.Errorf("completando campos de búsqueda: %w", err)
+    }
+    for _, name := range []string{"first_name_search", "last_name_search"} {
+        _, err := r.col.Indexes().DropOne(ctx, name)
+        var cmdErr mongo.CommandError
+        if err != nil && !(errors.As(err, &cmdErr) && cmdErr.Name == "IndexNotFound") {
+            return res.ModifiedCount, fmt.Errorf("borrando el índice %s: %w", name, err)
+        }
+    }
+    return res.ModifiedCount, nil
+}
+
+// migrateVersions inicia en 1 la versión de los usuarios anteriores al
+// control de concurrencia. Es idempotente y corre al iniciar.
+func (r *mongoUserRepository) migrateVersions(ctx context.Context) (int64, error) {
+    res, err := r.col.UpdateMany(ctx,
+        bson.M{"version": bson.M{"$exists": false}},
+        bson.M{"$set": bson.M{"version": int64(1)}},
+    )
+    if err != nil {
+        return 0, fmt.Errorf("inicializando versiones: %w", err)
+    }
+    return res.ModifiedCount, nil
+}
+
+// ----------- Solicitudes de borrado ------------------------------------------
+
+// mongoErasureRepository guarda las solicitudes en la colección erasures
+type mongoErasureRepository struct {
+    col *mongo.Collection
+}
+
+func newMongoErasureRepository(db *mongo.Database) *mongoErasureRepository {
+    return &mongoErasureRepository{col: db.Collection("erasures")}
+}
+
+// ensureIndexes crea los índices del reintento y de la consulta por usuario
+func (r *mongoErasureRepository) ensureIndexes(ctx context.Context) error {
+    _, err := r.col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+        {Keys: bson.D{{Key: "status", Value: 1}, {Key: "last_attempt_at", Value: 1}}, Options: options.Index().SetName("status_attempt")},
+        {Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetName("user_id")},
+    })
+    if err != nil {
+        return fmt.Errorf("creando índices de erasures: %w", err)
+    }
+    return nil
+}
+
+func (r *mongoErasureRepository) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]erasureDoc, error) {
+    cur, err := r.col.Find(ctx, filter, opts...)
+    if err != nil {
+        return nil, err
+    }
+    var erasures []erasureDoc
+    if err := cur.All(ctx, &erasures); err != nil {
+        return nil, err
+    }
+    return erasures, nil
+}
+
+func (r *mongoErasureRepository) Create(ctx context.Context, erasure *erasureDoc) error {
+    _, err := r.col.InsertOne(ctx, erasure)
+    return err
+}
+
+func (r *mongoErasureRepository) Get(ctx context.Context, id primitive.ObjectID) (*erasureDoc, error) {
+    var erasure erasureDoc
+    err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&erasure)
+    if errors.Is(err, mongo.ErrNoDocuments) {
+        return nil, errErasureNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    return &erasure, nil
+}
+
+func (r *mongoErasureRepository) List(ctx context.Context, status string, userID int32, limit int) ([]erasureDoc, error) {
+    filter := bson.M{}
+    if status != "" {
+        filter["status"] = status
+    }
+    if userID != 0 {
+        filter["user_id"] = userID
+    }
+    return r.find(ctx, filter, options.Find().SetSort(bson.M{"_id": -1}).SetLimit(int64(limit)))
+}
+
+func (r *mongoErasureRepository) Transition(ctx context.Context, id primitive.ObjectID, from, to string, completedAt *time.Time) (bool, error) {
+    set := bson.M{"status": to}
+    if completedAt != nil {
+        set["completed_at"] = *completedAt
+    }
+    res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "status": from}, bson.M{"$set": set})
+    if err != nil {
+        return false, err
+    }
+    return res.ModifiedCount > 0, nil
+}
+
+func (r *mongoErasureRepository) DeletePreparing(ctx context.Context, id primitive.ObjectID) error {
+    _, err := r.col.DeleteOne(ctx, bson.M{"_id": id, "status": erasureStatusPreparing})
+    return err
+}
+
+func (r *mongoErasureRepository) Preparing(ctx context.Context, cutoff time.Time) ([]erasureDoc, error) {
+    return r.find(ctx, bson.M{"status": erasureStatusPreparing, "requested_at": bson.M{"$lte": cutoff}})
+}
+
+func (r *mongoErasureRepository) DueForRetry(ctx context.Context, cutoff time.Time) ([]erasureDoc, error) {
+    return r.find(ctx, bson.M{
+        "status": erasureStatusPending,
+        "$or": bson.A{
+            bson.M{"last_attempt_at": bson.M{"$exists": false}},
+            bson.M{"last_attempt_at": bson.M{"$lte": cutoff}},
+        },
+    })
+}
+
+func (r *mongoErasureRepository) RecordAttempt(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+    _, err := r.col.UpdateOne(ctx,
+        bson.M{"_id": id},
+        bson.M{"$inc": bson.M{"attempts": 1}, "$set": bson.M{"last_attempt_at": at}},
+    )
+    return err
+}
+
+func (r *mongoErasureRepository) CompleteService(ctx context.Context, id primitive.ObjectID, service string, records int64, completedAt time.Time) error {
+    field := "services." + service
+    _, err := r.col.UpdateOne(ctx,
+        bson.M{"_id": id, field: bson.M{"$exists": true}, field + ".status": erasureStatusPending},
+        bson.M{"$set": bson.M{
+            field + ".status":       erasureStatusCompleted,
+            field + ".records":      records,
+            field + ".completed_at": completedAt,
+        }},
+    )
+    return err
+}
+
+// ----------- Exportaciones ---------------------------------------------------
+
+// mongoExportRepository guarda las exportaciones en la colección exports
+type mongoExportRepository struct {
+    col *mongo.Collection
+}
+
+func newMongoExportRepository(db *mongo.Database) *mongoExportRepository {
+    return &mongoExportRepository{col: db.Collection("exports")}
+}
+
+// ensureIndexes crea los índices del worker, de la consulta por usuario y de
+// la descarga por token
+func (r *mongoExportRepository) ensureIndexes(ctx context.Context) error {
+    _, err := r.col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+        {Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}, Options: options.Index().SetName("status_next_attempt")},
+        {Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetName("user_id")},
+        {
+            Keys: bson.D{{Key: "download_token", Value: 1}},
+            Options: options.Index().SetName("download_token_unique").SetUnique(true).
+                SetPartialFilterExpression(bson.M{"download_token": bson.M{"$type": "string"}}),
+        },
+    })
+    if err != nil {
+        return fmt.Errorf("creando índices de exports: %w", err)
+    }
+    return nil
+}
+
+func (r *mongoExportRepository) findOne(ctx context.Context, filter bson.M) (*exportDoc, error) {
+    var export exportDoc
+    err := r.col.FindOne(ctx, filter).Decode(&export)
+    if errors.Is(err, mongo.ErrNoDocuments) {
+        return nil, errExportNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    return &export, nil
+}
+
+func (r *mongoExportRepository) Create(ctx context.Context, export *exportDoc) error {
+    _, err := r.col.InsertOne(ctx, export)
+    return err
+}
+
+func (r *mongoExportRepository) Get(ctx context.Context, id primitive.ObjectID, userID int32) (*exportDoc, error) {
+    return r.findOne(ctx, bson.M{"_id": id, "user_id": userID})
+}
+
+func (r *mongoExportRepository) Active(ctx context.Context, userID int32) (*exportDoc, error) {
+    return r.findOne(ctx, bson.M{
+        "user_id": userID,
+        "status":  bson.M{"$in": bson.A{exportStatusPending, exportStatusRunning}},
+    })
+}
+
+func (r *mongoExportRepository) GetByToken(ctx context.Context, token string) (*exportDoc, error) {
+    return r.findOne(ctx, bson.M{"download_token": token, "status": exportStatusCompleted})
+}
+
+func (r *mongoExportRepository) ClaimNext(ctx context.Context, now time.Time) (*exportDoc, error) {
+    var export exportDoc
+    err := r.col.FindOneAndUpdate(ctx,
+        bson.M{"status": exportStatusPending, "next_attempt_at": bson.M{"$lte": now}},
+        bson.M{"$set": bson.M{"status": exportStatusRunning}, "$inc": bson.M{"attempts": 1}},
+        options.FindOneAndUpdate().
+            SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+            SetReturnDocument(options.After),
+    ).Decode(&export)
+    if errors.Is(err, mongo.ErrNoDocuments) {
+        return nil, errExportNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    return &export, nil
+}
+
+func (r *mongoExportRepository) RequeueRunning(ctx context.Context, now time.Time) (int64, error) {
+    res, err := r.col.UpdateMany(ctx,
+        bson.M{"status": exportStatusRunning},
+        bson.M{"$set": bson.M{"status": exportStatusPending, "next_attempt_at": now}},
+    )
+    if err != nil {
+        return 0, err
+    }
+    return res.ModifiedCount, nil
+}
+
+func (r *mongoExportRepository) Complete(ctx context.Context, export *exportDoc) (bool, error) {
+    res, err := r.col.UpdateOne(ctx,
+        bson.M{"_id": export.ID, "status": exportStatusRunning},
+        bson.M{
+            "$set": bson.M{
+                "status":         exportStatusCompleted,
+                "completed_at":   export.CompletedAt,
+                "expires_at":     export.ExpiresAt,
+                "download_token": export.DownloadToken,
+                "size_bytes":     export.SizeBytes,
+            },
+            "$unset": bson.M{"error": ""},
+        },
+    )
+    if err != nil {
+        return false, err
+    }
+    return res.MatchedCount > 0, nil
+}
+
+func (r *mongoExportRepository) Fail(ctx context.Context, id primitive.ObjectID, status, message string, nextAttemptAt time.Time) error {
+    set := bson.M{"status": status, "error": message}
+    if !nextAttemptAt.IsZero() {
+        set["next_attempt_at"] = nextAttemptAt
+    }
+    _, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "status": exportStatusRunning}, bson.M{"$set": set})
+    return err
+}
+
+func (r *mongoExportRepository) Expired(ctx context.Context, now time.Time) ([]exportDoc, error) {
+    cur, err := r.col.Find(ctx, bson.M{
+        "status":     exportStatusCompleted,
+        "expires_at": bson.M{"$lte": now},
+    }, options.Find().SetProjection(bson.M{"_id": 1}))
+    if err != nil {
+        return nil, err
+    }
+    var docs []exportDoc
+    if err := cur.All(ctx, &docs); err != nil {
+        return nil, err
+    }
+    return docs, nil
+}
+
+func (r *mongoExportRepository) Expire(ctx context.Context, id primitive.ObjectID) error {
+    _, err := r.col.UpdateOne(ctx,
+        bson.M{"_id": id, "status": exportStatusCompleted},
+        bson.M{"$set": bson.M{"status": exportStatusExpired}, "$unset": bson.M{"download_token": ""}},
+    )
+    return err
+}
+
+func (r *mongoExportRepository) DeleteByUser(ctx context.Context, userID int32) ([]primitive.ObjectID, error) {
+    cur, err := r.col.Find(ctx, bson.M{"user_id": userID}, options.Find().SetProjection(bson.M{"_id": 1}))
+    if err != nil {
+        return nil, err
+    }
+    var docs []exportDoc
+    if err := cur.All(ctx, &docs); err != nil {
+        return nil, err
+    }
+    if _, err := r.col.DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+        return nil, err
+    }
+    ids := make([]primitive.ObjectID, len(docs))
+    for i, export := range docs {
+        ids[i] = export.ID
+    }
+    return ids, nil
+}
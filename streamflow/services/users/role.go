@@ -0,0 +1,165 @@
+package main
+
+import (
+    "context"
+    "log"
+    "strconv"
+    "strings"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    pb "users-service/pb"
+)
+
+// El rol de un usuario solo cambia con ChangeUserRole, que el gateway expone
+// a quien tiene roles:manage. El cambio publica user.role_changed por el
+// outbox: auth actualiza las credenciales y revoca los tokens emitidos con el
+// rol anterior, y monitoring lo registra como acción auditada.
+
+// customerRole es el rol de todo usuario que se registra
+const customerRole = "Cliente"
+
+const (
+    roleMaxLength   = 50
+    reasonMaxLength = 500
+)
+
+// callerID devuelve el ID de quien llama, que el gateway envía como metadata
+func callerID(ctx context.Context) int32 {
+    md, ok := metadata.FromIncomingContext(ctx)
+    if !ok || len(md.Get("user_id")) == 0 {
+        return 0
+    }
+    id, _ := strconv.ParseInt(md.Get("user_id")[0], 10, 32)
+    return int32(id)
+}
+
+// callerHasPermission dice si quien llama tiene el permiso, según la
+// metadata permissions del gateway
+func callerHasPermission(ctx context.Context, permission string) bool {
+    md, ok := metadata.FromIncomingContext(ctx)
+    if !ok {
+        return false
+    }
+    for _, value := range md.Get("permissions") {
+        for _, granted := range strings.Fields(value) {
+            if granted == permission {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// requirePermission rechaza la llamada si quien llama no tiene el permiso.
+// Cada RPC exige el mismo permiso que su ruta en el gateway, porque el puerto
+// gRPC también es accesible directamente; quedan abiertas solo las que el
+// gateway expone sin sesión (alta, confirmación de email, avatar y descarga
+// de la exportación por token).
+func requirePermission(ctx context.Context, permission string) error {
+    if !callerHasPermission(ctx, permission) {
+        return status.Errorf(codes.PermissionDenied, "Se requiere el permiso %s", permission)
+    }
+    return nil
+}
+
+// requireSelfOr deja pasar a un usuario sobre sus propios datos y exige el
+// permiso para los de cualquier otro, como authorizeSelfOr en el gateway
+func requireSelfOr(ctx context.Context, userID int32, permission string) error {
+    if caller := callerID(ctx); caller != 0 && caller == userID {
+        return nil
+    }
+    return requirePermission(ctx, permission)
+}
+
+// ChangeUserRole asigna otro rol a un usuario. El nombre del rol lo valida el
+// gateway contra los roles de auth.
+func (s *server) ChangeUserRole(ctx context.Context, req *pb.ChangeUserRoleRequest) (*pb.UserResponse, error) {
+    if err := requirePermission(ctx, "roles:manage"); err != nil {
+        return nil, err
+    }
+    role := strings.TrimSpace(req.Role)
+    if role == "" || len(role) > roleMaxLength {
+        return nil, status.Errorf(codes.InvalidArgument, "Rol inválido")
+    }
+    reason := strings.TrimSpace(req.Reason)
+    if len(reason) > reasonMaxLength {
+        return nil, status.Errorf(codes.InvalidArgument, "El motivo admite hasta %d caracteres", reasonMaxLength)
+    }
+    changedBy := callerID(ctx)
+    if changedBy != 0 && changedBy == req.Id {
+        return nil, status.Errorf(codes.PermissionDenied, "No se puede cambiar el rol propio")
+    }
+
+    current, err := s.currentForWrite(ctx, req.Id, req.ExpectedVersion)
+    if err != nil {
+        return nil, err
+    }
+    first, _ := current["first_name"].(string)
+    last, _ := current["last_name"].(string)
+    email, _ := current["email"].(string)
+    currentRole, _ := current["role"].(string)
+    createdAt, _ := current["created_at"].(string)
+    response := &pb.UserResponse{
+        Id:        req.Id,
+        FirstName: first,
+        LastName:  last,
+        Email:     email,
+        Role:      currentRole,
+        CreatedAt: createdAt,
+        Status:    userStatus(current),
+        Version:   docVersion(current),
+    }
+    // Sin cambios no se escribe ni se revocan tokens
+    if response.Role == role {
+        return response, nil
+    }
+    previousRole := response.Role
+    response.Role = role
+    response.Version++
+
+    data := map[string]interface{}{
+        "email":         response.Email,
+        "role":          role,
+        "previous_role": previousRole,
+        "changed_by":    changedBy,
+        "changed_at":    time.Now().UTC().Format(time.RFC3339),
+    }
+    if email := s.userEmail(ctx, changedBy); email != "" {
+        data["changed_by_email"] = email
+    }
+    if reason != "" {
+        data["reason"] = reason
+    }
+    event, err := newUserEvent("user.role_changed", req.Id, response.Version, data)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "Error armando el evento: %v", err)
+    }
+
+    err = s.users.Update(ctx, req.Id, docVersion(current), userChange{
+        Set:    bson.M{"role": role},
+        Events: []outboxEvent{event},
+    })
+    if err != nil {
+        return nil, writeError(err, docVersion(current))
+    }
+
+    log.Printf("Rol del usuario %d cambiado de %q a %q por %d", req.Id, previousRole, role, changedBy)
+    return response, nil
+}
+
+// userEmail devuelve el email de un usuario, o "" si no se encuentra
+func (s *server) userEmail(ctx context.Context, id int32) string {
+    if id == 0 {
+        return ""
+    }
+    user, err := s.users.Get(ctx, id)
+    if err != nil {
+        return ""
+    }
+    email, _ := user["email"].(string)
+    return email
+}
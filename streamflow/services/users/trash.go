@@ -0,0 +1,308 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    pb "users-service/pb"
+)
+
+// DeleteUser solo marca deleted_at: el usuario queda en la papelera, de donde
+// un administrador puede restaurarlo o borrarlo definitivamente. Pasado el
+// período de gracia, el job de purga lo borra solo. Restaurar publica
+// user.restored y borrar publica user.purged, para que los demás servicios
+// reactiven o eliminen lo que tengan del usuario.
+
+// purgeGracePeriod es cuánto queda un usuario en la papelera
+var purgeGracePeriod = getDurationEnv("USER_PURGE_GRACE_PERIOD", 30*24*time.Hour)
+
+// purgeInterval es cada cuánto corre el job de purga
+var purgeInterval = getDurationEnv("USER_PURGE_INTERVAL", time.Hour)
+
+// Motivos de user.purged
+const (
+    purgeReasonManual      = "manual"
+    purgeReasonGracePeriod = "grace_period"
+)
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+    value := getEnv(key, "")
+    if value == "" {
+        return defaultValue
+    }
+    d, err := time.ParseDuration(value)
+    if err != nil || d <= 0 {
+        log.Printf("%s inválido (%q), se usa %s", key, value, defaultValue)
+        return defaultValue
+    }
+    return d
+}
+
+// deletedAt lee la fecha de borrado de un documento
+func deletedAt(user bson.M) (time.Time, bool) {
+    value, ok := user["deleted_at"].(string)
+    if !ok {
+        return time.Time{}, false
+    }
+    t, err := time.Parse(time.RFC3339, value)
+    if err != nil {
+        return time.Time{}, false
+    }
+    return t, true
+}
+
+// trashState explica por qué un usuario no está en la papelera o no se pudo
+// purgar: no existe (NotFound), sigue activo (FailedPrecondition) o tiene
+// eventos sin publicar (Unavailable)
+func (s *server) trashState(ctx context.Context, id int32) error {
+    _, err := s.users.Get(ctx, id)
+    if err == nil {
+        return status.Errorf(codes.FailedPrecondition, "El usuario %d no está eliminado", id)
+    }
+    if !errors.Is(err, errUserNotFound) {
+        return status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+    user, err := s.users.GetDeleted(ctx, id)
+    if err != nil && !errors.Is(err, errUserNotFound) {
+        return status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+    if user != nil && pendingEventCount(user) > 0 {
+        return status.Errorf(codes.Unavailable, "El usuario %d tiene eventos pendientes de publicar, intente más tarde", id)
+    }
+    return status.Errorf(codes.NotFound, "Usuario no encontrado en la papelera")
+}
+
+// RestoreUser saca un usuario de la papelera
+func (s *server) RestoreUser(ctx context.Context, req *pb.RestoreUserRequest) (*pb.UserResponse, error) {
+    if err := requirePermission(ctx, "users:write"); err != nil {
+        return nil, err
+    }
+    user, err := s.users.GetDeleted(ctx, req.Id)
+    if errors.Is(err, errUserNotFound) {
+        return nil, s.trashState(ctx, req.Id)
+    }
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+
+    // Mientras estuvo eliminado, otro usuario pudo registrarse con su email
+    email, _ := user["email"].(string)
+    taken, err := s.users.EmailsInUse(ctx, []string{email}, req.Id)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+    if taken[email] {
+        return nil, status.Errorf(codes.AlreadyExists, "El email %s ya pertenece a otro usuario", email)
+    }
+
+    first, _ := user["first_name"].(string)
+    last, _ := user["last_name"].(string)
+    role, _ := user["role"].(string)
+    event, err := newUserEvent("user.restored", req.Id, docVersion(user)+1, map[string]interface{}{
+        "first_name": first,
+        "last_name":  last,
+        "email":      email,
+        "role":       role,
+        "status":     userStatus(user),
+    })
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "Error armando el evento: %v", err)
+    }
+
+    restored, err := s.users.Restore(ctx, req.Id, docVersion(user), event)
+    var conflict *versionConflictError
+    if errors.As(err, &conflict) || errors.Is(err, errUserNotFound) {
+        return nil, status.Errorf(codes.Aborted, "El usuario fue modificado por otra solicitud, intente nuevamente")
+    }
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB restore error: %v", err)
+    }
+
+    response := &pb.UserResponse{
+        Id:        req.Id,
+        FirstName: restored["first_name"].(string),
+        LastName:  restored["last_name"].(string),
+        Email:     restored["email"].(string),
+        Role:      restored["role"].(string),
+        CreatedAt: restored["created_at"].(string),
+        Status:    userStatus(restored),
+        Version:   docVersion(restored),
+    }
+
+    return response, nil
+}
+
+// PurgeUser borra definitivamente un usuario de la papelera
+func (s *server) PurgeUser(ctx context.Context, req *pb.PurgeUserRequest) (*pb.PurgeUserResponse, error) {
+    if err := requirePermission(ctx, "users:purge"); err != nil {
+        return nil, err
+    }
+    erasure, err := s.purgeUser(ctx, req.Id, purgeReasonManual)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB purge error: %v", err)
+    }
+    if erasure == nil {
+        return nil, s.trashState(ctx, req.Id)
+    }
+    return &pb.PurgeUserResponse{
+        Message:   "Usuario borrado definitivamente",
+        ErasureId: erasure.ID.Hex(),
+    }, nil
+}
+
+// purgeUser borra el documento si sigue en la papelera, publica user.purged e
+// inicia el borrado de sus datos en los demás servicios. Devuelve nil si el
+// usuario no estaba en la papelera.
+func (s *server) purgeUser(ctx context.Context, id int32, reason string) (*erasureDoc, error) {
+    // La solicitud se registra antes de borrar, para que ningún usuario
+    // purgado quede sin ella; el job de reintentos la envía si falla el envío
+    // y la resuelve si el proceso se detiene antes de activarla
+    erasure, err := s.createErasure(ctx, id, reason)
+    if err != nil {
+        return nil, err
+    }
+
+    // Un usuario con eventos sin publicar (user.deleted) espera al relay
+    purged, err := s.users.Purge(ctx, id)
+    if err == nil && !purged {
+        return nil, s.erasures.DeletePreparing(ctx, erasure.ID)
+    }
+    if err != nil {
+        if cleanupErr := s.erasures.DeletePreparing(ctx, erasure.ID); cleanupErr != nil {
+            log.Printf("Error descartando el borrado %s: %v", erasure.ID.Hex(), cleanupErr)
+        }
+        return nil, err
+    }
+
+    if err := s.activateErasure(ctx, erasure); err != nil {
+        log.Printf("Error activando el borrado %s: %v", erasure.ID.Hex(), err)
+    }
+    if err := s.deleteUserExports(ctx, id); err != nil {
+        log.Printf("Error borrando las exportaciones del usuario %d: %v", id, err)
+    }
+    if err := deleteUserAvatars(id); err != nil {
+        log.Printf("Error borrando los avatares del usuario %d: %v", id, err)
+    }
+
+    eventData := map[string]interface{}{
+        "id":         id,
+        "reason":     reason,
+        "purged_at":  time.Now().Format(time.RFC3339),
+        "erasure_id": erasure.ID.Hex(),
+    }
+    if err := s.publishEvent("user.purged", eventData); err != nil {
+        log.Printf("Error publicando user.purged para usuario %d: %v", id, err)
+    }
+    if err := s.dispatchErasure(ctx, erasure); err != nil {
+        log.Printf("Error iniciando el borrado %s: %v", erasure.ID.Hex(), err)
+    }
+    return erasure, nil
+}
+
+// ListDeletedUsers lista la papelera, de los borrados más recientes a los
+// más antiguos
+func (s *server) ListDeletedUsers(ctx context.Context, req *pb.ListDeletedUsersRequest) (*pb.ListUsersResponse, error) {
+    if err := requirePermission(ctx, "users:read"); err != nil {
+        return nil, err
+    }
+    query := userQuery{Deleted: true, SortField: "deleted_at", Descending: true}
+
+    pageSize := int(req.PageSize)
+    if pageSize <= 0 {
+        pageSize = defaultListPageSize
+    }
+    if pageSize > maxListPageSize {
+        pageSize = maxListPageSize
+    }
+    query.Limit = pageSize + 1
+
+    const queryHash = "trash"
+    if req.PageToken != "" {
+        token, err := decodeListPageToken(req.PageToken, queryHash)
+        if err != nil {
+            return nil, err
+        }
+        if query.After, err = listCursorFromToken(query.SortField, token); err != nil {
+            return nil, err
+        }
+    }
+
+    docs, total, err := s.users.List(ctx, query)
+    if err != nil {
+        return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+    }
+
+    response := &pb.ListUsersResponse{TotalSize: total}
+    if len(docs) > pageSize {
+        docs = docs[:pageSize]
+        response.NextPageToken = encodeListPageToken(docs[len(docs)-1], "deleted_at", queryHash)
+    }
+    for _, user := range docs {
+        entry := &pb.UserResponse{
+            Id:        docUserID(user),
+            FirstName: user["first_name"].(string),
+            LastName:  user["last_name"].(string),
+            Email:     user["email"].(string),
+            Role:      user["role"].(string),
+            CreatedAt: user["created_at"].(string),
+            Status:    userStatus(user),
+            Version:   docVersion(user),
+        }
+        entry.DeletedAt, _ = user["deleted_at"].(string)
+        if t, ok := deletedAt(user); ok {
+            entry.PurgeAfter = t.Add(purgeGracePeriod).Format(time.RFC3339)
+        }
+        response.Users = append(response.Users, entry)
+    }
+    return response, nil
+}
+
+// startPurgeJob borra periódicamente los usuarios que superaron el período
+// de gracia en la papelera
+func (s *server) startPurgeJob() {
+    log.Printf("Purga de usuarios eliminados cada %s (período de gracia %s)", purgeInterval, purgeGracePeriod)
+    for {
+        purged, err := s.purgeExpiredUsers(context.Background())
+        if err != nil {
+            log.Printf("Error purgando usuarios eliminados: %v", err)
+        }
+        if purged > 0 {
+            log.Printf("Usuarios purgados: %d", purged)
+        }
+        time.Sleep(purgeInterval)
+    }
+}
+
+func (s *server) purgeExpiredUsers(ctx context.Context) (int, error) {
+    docs, err := s.users.DeletedUsers(ctx)
+    if err != nil {
+        return 0, fmt.Errorf("buscando usuarios eliminados: %w", err)
+    }
+
+    cutoff := time.Now().Add(-purgeGracePeriod)
+    purged := 0
+    for _, user := range docs {
+        t, ok := deletedAt(user)
+        if !ok {
+            log.Printf("Usuario %d con deleted_at ilegible (%v), no se purga", docUserID(user), user["deleted_at"])
+            continue
+        }
+        if t.After(cutoff) {
+            continue
+        }
+        erasure, err := s.purgeUser(ctx, docUserID(user), purgeReasonGracePeriod)
+        if err != nil {
+            return purged, fmt.Errorf("purgando usuario %d: %w", docUserID(user), err)
+        }
+        if erasure != nil {
+            purged++
+        }
+    }
+    return purged, nil
+}
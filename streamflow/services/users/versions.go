@@ -0,0 +1,61 @@
+package main
+
+import (
+    "context"
+    "errors"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Cada cambio de un usuario incrementa su campo version. El gateway lo expone
+// como ETag y lo devuelve en expected_version (If-Match): si no coincide, la
+// escritura falla con codes.Aborted en vez de pisar un cambio ajeno.
+
+// docVersion lee la versión de un documento de usuario
+func docVersion(user bson.M) int64 {
+    switch v := user["version"].(type) {
+    case int32:
+        return int64(v)
+    case int64:
+        return v
+    }
+    return 0
+}
+
+// readError traduce el error de una lectura del usuario
+func readError(err error) error {
+    if errors.Is(err, errUserNotFound) {
+        return status.Errorf(codes.NotFound, "Usuario no encontrado")
+    }
+    return status.Errorf(codes.Internal, "DB error: %v", err)
+}
+
+// writeError explica por qué falló una escritura condicional: el usuario no
+// existe (NotFound) o cambió de versión (Aborted)
+func writeError(err error, expectedVersion int64) error {
+    var conflict *versionConflictError
+    if errors.As(err, &conflict) {
+        return status.Errorf(codes.Aborted,
+            "El usuario fue modificado por otra solicitud (versión actual %d, esperada %d)", conflict.Current, expectedVersion)
+    }
+    if errors.Is(err, errUserNotFound) {
+        return status.Errorf(codes.NotFound, "Usuario no encontrado")
+    }
+    return status.Errorf(codes.Internal, "DB update error: %v", err)
+}
+
+// currentForWrite lee el usuario antes de una escritura que necesita sus
+// valores actuales (para armar el evento) y comprueba la versión esperada.
+// La escritura se condiciona después a la versión leída.
+func (s *server) currentForWrite(ctx context.Context, id int32, expectedVersion int64) (bson.M, error) {
+    current, err := s.users.Get(ctx, id)
+    if err != nil {
+        return nil, readError(err)
+    }
+    if expectedVersion > 0 && docVersion(current) != expectedVersion {
+        return nil, writeError(&versionConflictError{Current: docVersion(current)}, expectedVersion)
+    }
+    return current, nil
+}
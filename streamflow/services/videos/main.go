@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -48,6 +49,7 @@ type Video struct {
 	Genre      string             `bson:"genre"`
 	UploadDate time.Time          `bson:"upload_date"`
 	DeletedAt  *time.Time         `bson:"deleted_at,omitempty"`
+	Version    int64              `bson:"version"` // incremented on every change (ETag)
 }
 
 // Map Video ↔ proto.Video
@@ -59,6 +61,7 @@ func toProto(v *Video) *pb.Video {
 		Genre:       v.Genre,
 		LikesCount:  0,
 		UploadDate:  v.UploadDate.Format(time.RFC3339),
+		Version:     v.Version,
 	}
 }
 
@@ -69,6 +72,7 @@ func toVideoResponse(v *Video) *pb.VideoResponse {
 		Description: v.Description,
 		Genre:       v.Genre,
 		LikesCount:  0, // o el valor real si lo tienes
+		Version:     v.Version,
 	}
 }
 
@@ -76,7 +80,25 @@ func toVideoResponse(v *Video) *pb.VideoResponse {
 
 type videoServiceServer struct {
 	pb.UnimplementedVideoServiceServer
-	col *mongo.Collection
+	videos VideoRepository
+}
+
+func newVideoServiceServer(videos VideoRepository) *videoServiceServer {
+	return &videoServiceServer{videos: videos}
+}
+
+// writeError maps a failed write: the video is gone (NotFound) or changed
+// since the client read it (Aborted).
+func writeError(err error, expectedVersion int64) error {
+	var conflict *versionConflictError
+	switch {
+	case errors.Is(err, errVideoNotFound):
+		return status.Errorf(codes.NotFound, "Video no encontrado")
+	case errors.As(err, &conflict):
+		return status.Errorf(codes.Aborted, "El video fue modificado por otra solicitud (versión actual %d, esperada %d)", conflict.Current, expectedVersion)
+	default:
+		return status.Errorf(codes.Internal, "DB error: %v", err)
+	}
 }
 
 // UploadVideo
@@ -86,13 +108,12 @@ func (s *videoServiceServer) UploadVideo(ctx context.Context, req *pb.UploadVide
 		Description: req.Description,
 		Genre:       req.Genre,
 		UploadDate:  time.Now(),
+		Version:     1,
 	}
 
-	res, err := s.col.InsertOne(ctx, v)
-	if err != nil {
+	if err := s.videos.Insert(ctx, &v); err != nil {
 		return nil, status.Errorf(codes.Internal, "DB insert error: %v", err)
 	}
-	v.ID = res.InsertedID.(primitive.ObjectID)
 	log.Printf("Video creado: %s", v.Title)
 	return toVideoResponse(&v), nil
 }
@@ -103,15 +124,11 @@ func (s *videoServiceServer) GetVideo(ctx context.Context, req *pb.GetVideoReque
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "ID inválido")
 	}
-	var v Video
-	err = s.col.FindOne(ctx, bson.M{"_id": objID, "deleted_at": nil}).Decode(&v)
-	if err == mongo.ErrNoDocuments {
-		return nil, status.Errorf(codes.NotFound, "Video no encontrado")
-	}
+	v, err := s.videos.Get(ctx, objID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+		return nil, writeError(err, 0)
 	}
-	return toVideoResponse(&v), nil
+	return toVideoResponse(v), nil
 }
 
 // UpdateVideo
@@ -120,28 +137,12 @@ func (s *videoServiceServer) UpdateVideo(ctx context.Context, req *pb.UpdateVide
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "ID inválido")
 	}
-	update := bson.M{
-		"$set": bson.M{
-			"title":       req.Title,
-			"description": req.Description,
-			"genre":       req.Genre,
-		},
-	}
-	var v Video
-	err = s.col.FindOneAndUpdate(ctx,
-		bson.M{"_id": objID, "deleted_at": nil},
-		update,
-		options.FindOneAndUpdate().SetReturnDocument(options.After),
-	).Decode(&v)
-
-	if err == mongo.ErrNoDocuments {
-		return nil, status.Errorf(codes.NotFound, "Video no encontrado")
-	}
+	v, err := s.videos.Update(ctx, objID, req.Title, req.Description, req.Genre, req.ExpectedVersion)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "DB error: %v", err)
+		return nil, writeError(err, req.ExpectedVersion)
 	}
 	log.Printf("Video actualizado: %s", v.Title)
-	return toVideoResponse(&v), nil
+	return toVideoResponse(v), nil
 }
 
 // DeleteVideo (soft delete)
@@ -150,16 +151,8 @@ func (s *videoServiceServer) DeleteVideo(ctx context.Context, req *pb.DeleteVide
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "ID inválido")
 	}
-	now := time.Now()
-	res, err := s.col.UpdateOne(ctx,
-		bson.M{"_id": objID, "deleted_at": nil},
-		bson.M{"$set": bson.M{"deleted_at": now}},
-	)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "DB error: %v", err)
-	}
-	if res.MatchedCount == 0 {
-		return nil, status.Errorf(codes.NotFound, "Video no encontrado")
+	if err := s.videos.SoftDelete(ctx, objID, req.ExpectedVersion); err != nil {
+		return nil, writeError(err, req.ExpectedVersion)
 	}
 	log.Printf("Video eliminado: %s", req.Id)
 	return &pb.DeleteVideoResponse{Message: "Video eliminado exitosamente"}, nil
@@ -167,27 +160,14 @@ func (s *videoServiceServer) DeleteVideo(ctx context.Context, req *pb.DeleteVide
 
 // ListVideos
 func (s *videoServiceServer) ListVideos(ctx context.Context, req *pb.ListVideosRequest) (*pb.ListVideosResponse, error) {
-	filter := bson.M{"deleted_at": nil}
-	if req.Title != "" {
-		filter["title"] = bson.M{"$regex": req.Title, "$options": "i"}
-	}
-	if req.Genre != "" {
-		filter["genre"] = bson.M{"$regex": req.Genre, "$options": "i"}
-	}
-
-	cur, err := s.col.Find(ctx, filter, options.Find().SetSort(bson.M{"upload_date": -1}))
+	found, err := s.videos.List(ctx, req.Title, req.Genre)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "DB error: %v", err)
 	}
-	defer cur.Close(ctx)
 
 	var videos []*pb.Video
-	for cur.Next(ctx) {
-		var v Video
-		if err := cur.Decode(&v); err != nil {
-			return nil, status.Errorf(codes.Internal, "Decode error: %v", err)
-		}
-		videos = append(videos, toProto(&v))
+	for _, v := range found {
+		videos = append(videos, toProto(v))
 	}
 	return &pb.ListVideosResponse{Videos: videos}, nil
 }
@@ -204,9 +184,18 @@ func main() {
 	}
 	col := client.Database(dbName).Collection(collection)
 
+	// Videos created before versioning start at version 1
+	res, err := col.UpdateMany(ctx, bson.M{"version": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"version": int64(1)}})
+	if err != nil {
+		log.Fatalf("Mongo version migration error: %v", err)
+	}
+	if res.ModifiedCount > 0 {
+		log.Printf("Versión inicializada en %d videos", res.ModifiedCount)
+	}
+
 	// gRPC server
 	grpcServer := grpc.NewServer()
-	pb.RegisterVideoServiceServer(grpcServer, &videoServiceServer{col: col})
+	pb.RegisterVideoServiceServer(grpcServer, newVideoServiceServer(newMongoVideoRepository(col)))
 
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
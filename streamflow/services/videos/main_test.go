@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "videos-service/pb"
+)
+
+func assertCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Fatalf("expected %s, got %s (%v)", want, got, err)
+	}
+}
+
+func upload(t *testing.T, s *videoServiceServer, title, genre string) *pb.VideoResponse {
+	t.Helper()
+	v, err := s.UploadVideo(context.Background(), &pb.UploadVideoRequest{Title: title, Description: "desc", Genre: genre})
+	if err != nil {
+		t.Fatalf("UploadVideo: %v", err)
+	}
+	return v
+}
+
+func TestUploadedVideoStartsAtVersionOne(t *testing.T) {
+	s := newVideoServiceServer(newMemoryVideoRepository())
+	v := upload(t, s, "Matrix", "Ciencia ficción")
+
+	if v.Version != 1 || v.Id == "" {
+		t.Fatalf("unexpected video: %v", v)
+	}
+	got, err := s.GetVideo(context.Background(), &pb.GetVideoRequest{Id: v.Id})
+	if err != nil {
+		t.Fatalf("GetVideo: %v", err)
+	}
+	if got.Title != "Matrix" {
+		t.Fatalf("expected Matrix, got %q", got.Title)
+	}
+}
+
+func TestInvalidIDIsRejected(t *testing.T) {
+	s := newVideoServiceServer(newMemoryVideoRepository())
+	ctx := context.Background()
+
+	_, err := s.GetVideo(ctx, &pb.GetVideoRequest{Id: "no-es-un-id"})
+	assertCode(t, err, codes.InvalidArgument)
+	_, err = s.UpdateVideo(ctx, &pb.UpdateVideoRequest{Id: "no-es-un-id"})
+	assertCode(t, err, codes.InvalidArgument)
+	_, err = s.DeleteVideo(ctx, &pb.DeleteVideoRequest{Id: "no-es-un-id"})
+	assertCode(t, err, codes.InvalidArgument)
+
+	_, err = s.GetVideo(ctx, &pb.GetVideoRequest{Id: primitive.NewObjectID().Hex()})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestUpdateWithStaleVersionIsAborted(t *testing.T) {
+	s := newVideoServiceServer(newMemoryVideoRepository())
+	v := upload(t, s, "Matrix", "Ciencia ficción")
+
+	updated, err := s.UpdateVideo(context.Background(), &pb.UpdateVideoRequest{
+		Id: v.Id, Title: "Matrix Reloaded", Genre: "Acción", ExpectedVersion: v.Version,
+	})
+	if err != nil {
+		t.Fatalf("UpdateVideo: %v", err)
+	}
+	if updated.Version != 2 || updated.Title != "Matrix Reloaded" {
+		t.Fatalf("unexpected update result: %v", updated)
+	}
+
+	_, err = s.UpdateVideo(context.Background(), &pb.UpdateVideoRequest{
+		Id: v.Id, Title: "Otro", ExpectedVersion: v.Version,
+	})
+	assertCode(t, err, codes.Aborted)
+}
+
+func TestDeletedVideoIsGone(t *testing.T) {
+	s := newVideoServiceServer(newMemoryVideoRepository())
+	v := upload(t, s, "Matrix", "Ciencia ficción")
+
+	_, err := s.DeleteVideo(context.Background(), &pb.DeleteVideoRequest{Id: v.Id, ExpectedVersion: v.Version + 1})
+	assertCode(t, err, codes.Aborted)
+
+	if _, err := s.DeleteVideo(context.Background(), &pb.DeleteVideoRequest{Id: v.Id, ExpectedVersion: v.Version}); err != nil {
+		t.Fatalf("DeleteVideo: %v", err)
+	}
+
+	_, err = s.GetVideo(context.Background(), &pb.GetVideoRequest{Id: v.Id})
+	assertCode(t, err, codes.NotFound)
+	_, err = s.UpdateVideo(context.Background(), &pb.UpdateVideoRequest{Id: v.Id, Title: "Otro"})
+	assertCode(t, err, codes.NotFound)
+	_, err = s.DeleteVideo(context.Background(), &pb.DeleteVideoRequest{Id: v.Id, ExpectedVersion: v.Version + 1})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestListVideosFiltersAndSortsNewestFirst(t *testing.T) {
+	repo := newMemoryVideoRepository()
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newVideoServiceServer(repo)
+	insert := func(title, genre string) {
+		clock = clock.Add(time.Hour)
+		v := &Video{Title: title, Genre: genre, UploadDate: clock, Version: 1}
+		if err := repo.Insert(context.Background(), v); err != nil {
+			t.Fatalf("Insert: %v", err)
+		}
+	}
+	insert("Matrix", "Ciencia ficción")
+	insert("Toy Story", "Animación")
+	insert("Matrix Reloaded", "Ciencia ficción")
+
+	resp, err := s.ListVideos(context.Background(), &pb.ListVideosRequest{Title: "matrix"})
+	if err != nil {
+		t.Fatalf("ListVideos: %v", err)
+	}
+	if len(resp.Videos) != 2 || resp.Videos[0].Title != "Matrix Reloaded" || resp.Videos[1].Title != "Matrix" {
+		t.Fatalf("expected both Matrix videos newest first, got %v", resp.Videos)
+	}
+
+	resp, err = s.ListVideos(context.Background(), &pb.ListVideosRequest{Genre: "ANIMACIÓN"})
+	if err != nil {
+		t.Fatalf("ListVideos: %v", err)
+	}
+	if len(resp.Videos) != 1 || resp.Videos[0].Title != "Toy Story" {
+		t.Fatalf("expected Toy Story, got %v", resp.Videos)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ----------- Repository -----------------------------------------------------
+
+// The gRPC handlers validate requests and map errors; storage goes through
+// VideoRepository. mongoVideoRepository is the production backend and
+// memoryVideoRepository backs the unit tests.
+
+// errVideoNotFound is returned for missing or soft-deleted videos.
+var errVideoNotFound = errors.New("video not found")
+
+// versionConflictError is returned by conditional writes when the video
+// exists but is no longer at the expected version.
+type versionConflictError struct {
+	Current int64
+}
+
+func (e *versionConflictError) Error() string {
+	return fmt.Sprintf("video is at version %d", e.Current)
+}
+
+// VideoRepository stores videos. Deleted videos are never returned, and an
+// expectedVersion of 0 skips the version check.
+type VideoRepository interface {
+	// Insert stores a new video and sets its ID.
+	Insert(ctx context.Context, v *Video) error
+	Get(ctx context.Context, id primitive.ObjectID) (*Video, error)
+	// Update replaces title, description and genre and bumps the version.
+	Update(ctx context.Context, id primitive.ObjectID, title, description, genre string, expectedVersion int64) (*Video, error)
+	SoftDelete(ctx context.Context, id primitive.ObjectID, expectedVersion int64) error
+	// List returns videos whose title and genre match the given patterns
+	// (case-insensitive regular expressions, empty matches all), newest first.
+	List(ctx context.Context, title, genre string) ([]*Video, error)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryVideoRepository keeps videos in memory with the same semantics as
+// the Mongo collection (versions, soft delete, regex filters).
+type memoryVideoRepository struct {
+	mu     sync.Mutex
+	videos map[primitive.ObjectID]*Video
+	now    func() time.Time
+}
+
+func newMemoryVideoRepository() *memoryVideoRepository {
+	return &memoryVideoRepository{
+		videos: make(map[primitive.ObjectID]*Video),
+		now:    time.Now,
+	}
+}
+
+func (r *memoryVideoRepository) Insert(ctx context.Context, v *Video) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v.ID = primitive.NewObjectID()
+	stored := *v
+	r.videos[v.ID] = &stored
+	return nil
+}
+
+func (r *memoryVideoRepository) Get(ctx context.Context, id primitive.ObjectID) (*Video, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, err := r.active(id, 0)
+	if err != nil {
+		return nil, err
+	}
+	copied := *v
+	return &copied, nil
+}
+
+func (r *memoryVideoRepository) Update(ctx context.Context, id primitive.ObjectID, title, description, genre string, expectedVersion int64) (*Video, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, err := r.active(id, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	v.Title, v.Description, v.Genre = title, description, genre
+	v.Version++
+	copied := *v
+	return &copied, nil
+}
+
+func (r *memoryVideoRepository) SoftDelete(ctx context.Context, id primitive.ObjectID, expectedVersion int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, err := r.active(id, expectedVersion)
+	if err != nil {
+		return err
+	}
+	now := r.now()
+	v.DeletedAt = &now
+	v.Version++
+	return nil
+}
+
+func (r *memoryVideoRepository) List(ctx context.Context, title, genre string) ([]*Video, error) {
+	titleRe, err := caseInsensitive(title)
+	if err != nil {
+		return nil, err
+	}
+	genreRe, err := caseInsensitive(genre)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var videos []*Video
+	for _, v := range r.videos {
+		if v.DeletedAt != nil || !titleRe.MatchString(v.Title) || !genreRe.MatchString(v.Genre) {
+			continue
+		}
+		copied := *v
+		videos = append(videos, &copied)
+	}
+	sort.Slice(videos, func(i, j int) bool {
+		return videos[i].UploadDate.After(videos[j].UploadDate)
+	})
+	return videos, nil
+}
+
+// active returns the stored video if it is not deleted and is at
+// expectedVersion (0 skips the check). The caller holds the lock.
+func (r *memoryVideoRepository) active(id primitive.ObjectID, expectedVersion int64) (*Video, error) {
+	v, ok := r.videos[id]
+	if !ok || v.DeletedAt != nil {
+		return nil, errVideoNotFound
+	}
+	if expectedVersion > 0 && v.Version != expectedVersion {
+		return nil, &versionConflictError{Current: v.Version}
+	}
+	return v, nil
+}
+
+// caseInsensitive compiles a pattern like Mongo's $regex with the "i" option
+func caseInsensitive(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoVideoRepository stores videos in the videos collection.
+type mongoVideoRepository struct {
+	col *mongo.Collection
+}
+
+func newMongoVideoRepository(col *mongo.Collection) *mongoVideoRepository {
+	return &mongoVideoRepository{col: col}
+}
+
+// activeVideo filters a video that is not deleted and, when expectedVersion
+// is not 0, still has that version.
+func activeVideo(id primitive.ObjectID, expectedVersion int64) bson.M {
+	filter := bson.M{"_id": id, "deleted_at": nil}
+	if expectedVersion > 0 {
+		filter["version"] = expectedVersion
+	}
+	return filter
+}
+
+func (r *mongoVideoRepository) Insert(ctx context.Context, v *Video) error {
+	res, err := r.col.InsertOne(ctx, v)
+	if err != nil {
+		return err
+	}
+	v.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *mongoVideoRepository) Get(ctx context.Context, id primitive.ObjectID) (*Video, error) {
+	var v Video
+	err := r.col.FindOne(ctx, activeVideo(id, 0)).Decode(&v)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, errVideoNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *mongoVideoRepository) Update(ctx context.Context, id primitive.ObjectID, title, description, genre string, expectedVersion int64) (*Video, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"title":       title,
+			"description": description,
+			"genre":       genre,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	var v Video
+	err := r.col.FindOneAndUpdate(ctx,
+		activeVideo(id, expectedVersion),
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&v)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, r.conflict(ctx, id, expectedVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *mongoVideoRepository) SoftDelete(ctx context.Context, id primitive.ObjectID, expectedVersion int64) error {
+	res, err := r.col.UpdateOne(ctx,
+		activeVideo(id, expectedVersion),
+		bson.M{"$set": bson.M{"deleted_at": time.Now()}, "$inc": bson.M{"version": 1}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return r.conflict(ctx, id, expectedVersion)
+	}
+	return nil
+}
+
+func (r *mongoVideoRepository) List(ctx context.Context, title, genre string) ([]*Video, error) {
+	filter := bson.M{"deleted_at": nil}
+	if title != "" {
+		filter["title"] = bson.M{"$regex": title, "$options": "i"}
+	}
+	if genre != "" {
+		filter["genre"] = bson.M{"$regex": genre, "$options": "i"}
+	}
+
+	cur, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.M{"upload_date": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var videos []*Video
+	for cur.Next(ctx) {
+		var v Video
+		if err := cur.Decode(&v); err != nil {
+			return nil, err
+		}
+		videos = append(videos, &v)
+	}
+	return videos, cur.Err()
+}
+
+// conflict tells why a conditional write matched nothing: the video is gone
+// or changed since the client read it.
+func (r *mongoVideoRepository) conflict(ctx context.Context, id primitive.ObjectID, expectedVersion int64) error {
+	if expectedVersion == 0 {
+		return errVideoNotFound
+	}
+	v, err := r.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return &versionConflictError{Current: v.Version}
+}
@@ -0,0 +1,96 @@
+package dbmigrate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// Usage describes the migrate subcommand.
+const Usage = `usage: migrate [status | up | down [-steps N]]
+
+  status        list every migration and whether it is applied (default)
+  up            apply pending migrations
+  down          roll back the last applied migration (-steps for more)`
+
+// RunCommand implements the migrate subcommand of a service binary: args are
+// the arguments after "migrate" and the report is written to out.
+func RunCommand(ctx context.Context, m *Migrator, args []string, out io.Writer) error {
+	command := "status"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "status":
+		if len(args) > 0 {
+			return fmt.Errorf("status takes no arguments\n%s", Usage)
+		}
+		return printStatus(ctx, m, out)
+
+	case "up":
+		if len(args) > 0 {
+			return fmt.Errorf("up takes no arguments\n%s", Usage)
+		}
+		applied, err := m.Up(ctx)
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(out, "no pending migrations")
+		}
+		return nil
+
+	case "down":
+		flags := flag.NewFlagSet("down", flag.ContinueOnError)
+		flags.SetOutput(out)
+		steps := flags.Int("steps", 1, "number of migrations to roll back")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if flags.NArg() > 0 {
+			return fmt.Errorf("unexpected argument %q\n%s", flags.Arg(0), Usage)
+		}
+		rolledBack, err := m.Down(ctx, *steps)
+		for _, migration := range rolledBack {
+			fmt.Fprintf(out, "rolled back %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(rolledBack) == 0 {
+			fmt.Fprintln(out, "no applied migrations")
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", command, Usage)
+	}
+}
+
+func printStatus(ctx context.Context, m *Migrator, out io.Writer) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+	for _, s := range statuses {
+		state := "pending"
+		if s.Applied {
+			state = "applied " + s.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		if s.Missing {
+			state += " (script not in this binary)"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, state)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,118 @@
+package dbmigrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// lockTimeout bounds how long a replica waits for another one to finish
+// migrating when the context has no deadline.
+const lockTimeout = 2 * time.Minute
+
+// Dialect holds what differs between the supported databases.
+type Dialect struct {
+	createTable      string
+	record           string
+	forget           string
+	transactionalDDL bool
+	lock             func(ctx context.Context, conn *sql.Conn, name string) error
+	unlock           func(ctx context.Context, conn *sql.Conn, name string)
+}
+
+// Postgres runs each migration in a transaction and serializes replicas with
+// a session advisory lock.
+var Postgres = &Dialect{
+	createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`,
+	record:           `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`,
+	forget:           `DELETE FROM schema_migrations WHERE version = $1`,
+	transactionalDDL: true,
+	lock: func(ctx context.Context, conn *sql.Conn, name string) error {
+		ctx, cancel := withLockTimeout(ctx)
+		defer cancel()
+		_, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryKey(name))
+		return err
+	},
+	unlock: func(ctx context.Context, conn *sql.Conn, name string) {
+		conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, advisoryKey(name))
+	},
+}
+
+// MySQL (and MariaDB) commit DDL implicitly, so scripts run statement by
+// statement: each statement must end with a semicolon at the end of a line.
+// Replicas are serialized with GET_LOCK.
+var MySQL = &Dialect{
+	createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`,
+	record: `INSERT INTO schema_migrations (version, name) VALUES (?, ?)`,
+	forget: `DELETE FROM schema_migrations WHERE version = ?`,
+	lock: func(ctx context.Context, conn *sql.Conn, name string) error {
+		ctx, cancel := withLockTimeout(ctx)
+		defer cancel()
+		seconds := int(lockTimeout.Seconds())
+		if deadline, ok := ctx.Deadline(); ok {
+			seconds = int(time.Until(deadline).Seconds())
+		}
+		var acquired sql.NullInt64
+		if err := conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, ?)`, name, seconds).Scan(&acquired); err != nil {
+			return err
+		}
+		if !acquired.Valid || acquired.Int64 != 1 {
+			return errors.New("timed out waiting for another replica to finish migrating")
+		}
+		return nil
+	},
+	unlock: func(ctx context.Context, conn *sql.Conn, name string) {
+		conn.ExecContext(ctx, `SELECT RELEASE_LOCK(?)`, name)
+	},
+}
+
+func withLockTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, lockTimeout)
+}
+
+// advisoryKey maps a lock name to the bigint key Postgres advisory locks use.
+func advisoryKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("schema_migrations:" + name))
+	return int64(h.Sum64())
+}
+
+// splitStatements splits a script on semicolons that end a line, without the
+// semicolon, and drops pieces that only contain comments.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	flush := func() {
+		statement := strings.TrimSuffix(strings.TrimSpace(current.String()), ";")
+		current.Reset()
+		for _, line := range strings.Split(statement, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "--") {
+				statements = append(statements, statement)
+				return
+			}
+		}
+	}
+	for _, line := range strings.SplitAfter(script, "\n") {
+		current.WriteString(line)
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			flush()
+		}
+	}
+	flush()
+	return statements
+}
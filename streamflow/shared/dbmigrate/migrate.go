@@ -0,0 +1,295 @@
+// Package dbmigrate applies versioned SQL migrations embedded in the service
+// binary. Each migration is a pair of files named NNNN_name.up.sql and
+// NNNN_name.down.sql; applied versions are recorded in schema_migrations.
+//
+// Replicas that start at the same time take a database-level lock before
+// migrating, so only one of them applies the pending scripts and the others
+// find nothing left to do.
+package dbmigrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Migration is one versioned schema change.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes a migration as seen by the database. A migration whose
+// script is no longer embedded (applied by a newer binary) has Missing set.
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	Missing   bool
+}
+
+// Migrator runs the migrations of one service against its database.
+type Migrator struct {
+	db         *sql.DB
+	dialect    *Dialect
+	lockName   string
+	migrations []Migration
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Load reads the migrations in dir of fsys, sorted by version. Every version
+// needs both an up and a down script.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		m := fileName.FindStringSubmatch(entry.Name())
+		if m == nil {
+			return nil, fmt.Errorf("unexpected migration file %q (want NNNN_name.up.sql or NNNN_name.down.sql)", entry.Name())
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+		script, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names: %q and %q", version, migration.Name, m[2])
+		}
+		if m[3] == "up" {
+			migration.Up = string(script)
+		} else {
+			migration.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both an up and a down script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// New loads the migrations in dir of fsys. lockName identifies the service,
+// so services sharing a database server do not wait for each other.
+func New(db *sql.DB, dialect *Dialect, fsys fs.FS, dir, lockName string) (*Migrator, error) {
+	migrations, err := Load(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: dialect, lockName: lockName, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order and returns the ones
+// it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		done, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, migration.Up, true); err != nil {
+				return fmt.Errorf("applying %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the last steps applied migrations, newest first, and
+// returns the ones it rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, errors.New("steps must be positive")
+	}
+
+	byVersion := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	var rolledBack []Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		done, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		versions := make([]int64, 0, len(done))
+		for version := range done {
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+		for i := 0; i < steps && i < len(versions); i++ {
+			migration, ok := byVersion[versions[i]]
+			if !ok {
+				return fmt.Errorf("migration %d is applied but this binary has no script for it", versions[i])
+			}
+			if err := m.apply(ctx, conn, migration, migration.Down, false); err != nil {
+				return fmt.Errorf("rolling back %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			rolledBack = append(rolledBack, migration)
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Status lists the embedded migrations and whether each one is applied,
+// followed by applied versions this binary does not know about.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	done, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := done[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = record.appliedAt
+			delete(done, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for version, record := range done {
+		statuses = append(statuses, Status{
+			Version:   version,
+			Name:      record.name,
+			Applied:   true,
+			AppliedAt: record.appliedAt,
+			Missing:   true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// locked runs fn on a dedicated connection while holding the migration lock.
+// The lock belongs to the connection, so it is released even if the process
+// dies halfway.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := m.dialect.lock(ctx, conn, m.lockName); err != nil {
+		return fmt.Errorf("acquiring migration lock: %w", err)
+	}
+	defer m.dialect.unlock(context.Background(), conn, m.lockName)
+
+	return fn(conn)
+}
+
+type appliedRecord struct {
+	name      string
+	appliedAt time.Time
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]appliedRecord, error) {
+	if _, err := conn.ExecContext(ctx, m.dialect.createTable); err != nil {
+		return nil, fmt.Errorf("creating schema_migrations: %w", err)
+	}
+	rows, err := conn.QueryContext(ctx, `SELECT version, name, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	done := make(map[int64]appliedRecord)
+	for rows.Next() {
+		var version int64
+		var record appliedRecord
+		if err := rows.Scan(&version, &record.name, &record.appliedAt); err != nil {
+			return nil, fmt.Errorf("reading schema_migrations: %w", err)
+		}
+		done[version] = record
+	}
+	return done, rows.Err()
+}
+
+// apply runs one script and records (up) or forgets (down) its version. With
+// transactional DDL both happen atomically; otherwise a failing script may
+// leave part of its changes behind and has to be fixed by hand.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, script string, up bool) error {
+	record := m.dialect.forget
+	args := []interface{}{migration.Version}
+	if up {
+		record = m.dialect.record
+		args = append(args, migration.Name)
+	}
+
+	if !m.dialect.transactionalDDL {
+		for _, statement := range splitStatements(script) {
+			if _, err := conn.ExecContext(ctx, statement); err != nil {
+				return err
+			}
+		}
+		_, err := conn.ExecContext(ctx, record, args...)
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,70 @@
+package dbmigrate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadSortsAndPairsScripts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0002_add_version.up.sql":    {Data: []byte("ALTER TABLE t ADD COLUMN version INT;")},
+		"migrations/0002_add_version.down.sql":  {Data: []byte("ALTER TABLE t DROP COLUMN version;")},
+		"migrations/0001_create_table.up.sql":   {Data: []byte("CREATE TABLE t (id INT);")},
+		"migrations/0001_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+	}
+
+	migrations, err := Load(fsys, "migrations")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 1 || migrations[1].Version != 2 {
+		t.Fatalf("expected versions 1 and 2 in order, got %+v", migrations)
+	}
+	if migrations[0].Name != "create_table" || migrations[0].Down != "DROP TABLE t;" {
+		t.Fatalf("unexpected migration: %+v", migrations[0])
+	}
+}
+
+func TestLoadRejectsIncompleteOrMisnamedScripts(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"missing down": {
+			"migrations/0001_create_table.up.sql": {Data: []byte("CREATE TABLE t (id INT);")},
+		},
+		"bad name": {
+			"migrations/create_table.sql": {Data: []byte("CREATE TABLE t (id INT);")},
+		},
+		"two names": {
+			"migrations/0001_create_table.up.sql": {Data: []byte("CREATE TABLE t (id INT);")},
+			"migrations/0001_make_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		},
+	}
+	for name, fsys := range cases {
+		if _, err := Load(fsys, "migrations"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := strings.Join([]string{
+		"-- invoices",
+		"CREATE TABLE invoices (",
+		"    id INT PRIMARY KEY,",
+		"    note VARCHAR(10) DEFAULT 'a;b'",
+		");",
+		"",
+		"CREATE INDEX idx ON invoices (id);",
+		"-- trailing comment",
+	}, "\n")
+
+	got := splitStatements(script)
+	want := []string{
+		"-- invoices\nCREATE TABLE invoices (\n    id INT PRIMARY KEY,\n    note VARCHAR(10) DEFAULT 'a;b'\n)",
+		"CREATE INDEX idx ON invoices (id)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("splitStatements:\n got %q\nwant %q", got, want)
+	}
+}
@@ -0,0 +1,9 @@
+module streamflow/shared
+
+go 1.23.0
+
+toolchain go1.23.10
+
+require golang.org/x/crypto v0.36.0
+
+require golang.org/x/sys v0.31.0 // indirect
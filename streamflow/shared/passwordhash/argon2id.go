@@ -0,0 +1,119 @@
+package passwordhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2Params are the argon2id cost parameters.
+type Argon2Params struct {
+	MemoryKiB   uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params follow the OWASP recommendation for argon2id
+// (19 MiB, 2 iterations, 1 lane) with some headroom.
+var DefaultArgon2Params = Argon2Params{
+	MemoryKiB:   64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func (p Argon2Params) validate() error {
+	switch {
+	case p.MemoryKiB < 19*1024:
+		return errors.New("passwordhash: argon2id memory must be at least 19456 KiB")
+	case p.Iterations < 1:
+		return errors.New("passwordhash: argon2id iterations must be at least 1")
+	case p.Parallelism < 1:
+		return errors.New("passwordhash: argon2id parallelism must be at least 1")
+	case p.SaltLength < 16 || p.KeyLength < 16:
+		return errors.New("passwordhash: argon2id salt and key must be at least 16 bytes")
+	}
+	return nil
+}
+
+// Argon2id encodes hashes in the PHC string format:
+// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+type Argon2id struct {
+	Params Argon2Params
+}
+
+func (a Argon2id) Name() string { return "argon2id" }
+
+func (a Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, a.Params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("passwordhash: generating salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, a.Params.Iterations, a.Params.MemoryKiB, a.Params.Parallelism, a.Params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, a.Params.MemoryKiB, a.Params.Iterations, a.Params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (a Argon2id) Verify(password, encoded string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.MemoryKiB, params.Parallelism, params.KeyLength)
+	return subtle.ConstantTimeCompare(key, candidate) == 1, nil
+}
+
+func (a Argon2id) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, "$argon2id$")
+}
+
+func (a Argon2id) NeedsRehash(encoded string) bool {
+	params, _, _, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+	return params.MemoryKiB < a.Params.MemoryKiB ||
+		params.Iterations < a.Params.Iterations ||
+		params.Parallelism < a.Params.Parallelism ||
+		params.KeyLength < a.Params.KeyLength
+}
+
+func decodeArgon2id(encoded string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("passwordhash: unsupported argon2 version %q", parts[2])
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.MemoryKiB, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, fmt.Errorf("passwordhash: invalid argon2id parameters: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("passwordhash: invalid argon2id salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("passwordhash: invalid argon2id key: %w", err)
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
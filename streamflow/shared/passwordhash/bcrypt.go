@@ -0,0 +1,50 @@
+package passwordhash
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	DefaultBcryptCost = bcrypt.DefaultCost
+	MinBcryptCost     = bcrypt.MinCost
+	MaxBcryptCost     = bcrypt.MaxCost
+)
+
+// Bcrypt is the previous default scheme. Its hashes keep verifying and are
+// upgraded to Default on the next login.
+type Bcrypt struct {
+	Cost int
+}
+
+func (b Bcrypt) Name() string { return "bcrypt" }
+
+func (b Bcrypt) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (b Bcrypt) Verify(password, encoded string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (b Bcrypt) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+func (b Bcrypt) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost < b.Cost
+}
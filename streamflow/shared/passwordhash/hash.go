@@ -0,0 +1,134 @@
+// Package passwordhash hashes and verifies passwords for the auth and users
+// services.
+//
+// New hashes use the configured Default scheme (argon2id unless
+// PASSWORD_HASH_ALGORITHM says otherwise). Verify accepts every registered
+// scheme and reports when a hash should be replaced, so older hashes are
+// upgraded on the next successful login.
+package passwordhash
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Hasher is a password hashing scheme.
+type Hasher interface {
+	// Name identifies the scheme in configuration ("argon2id", "bcrypt").
+	Name() string
+	// Hash returns the encoded hash of password, parameters included.
+	Hash(password string) (string, error)
+	// Verify reports whether password matches the encoded hash.
+	Verify(password, encoded string) (bool, error)
+	// Identifies reports whether encoded was produced by this scheme.
+	Identifies(encoded string) bool
+	// NeedsRehash reports whether encoded uses weaker parameters than the
+	// hasher's current ones.
+	NeedsRehash(encoded string) bool
+}
+
+// ErrUnknownHash means the stored value is not a hash of any registered
+// scheme, e.g. a legacy plaintext password.
+var ErrUnknownHash = errors.New("passwordhash: unknown hash format")
+
+// Default hashes new passwords. Replace it with Configure or ConfigureFromEnv
+// at startup, before serving requests.
+var Default Hasher = Argon2id{Params: DefaultArgon2Params}
+
+// Hash hashes password with the Default scheme.
+func Hash(password string) (string, error) {
+	return Default.Hash(password)
+}
+
+// Verify checks password against an encoded hash of any registered scheme.
+// needsRehash is true when the password matched but the hash is not a
+// Default hash with current parameters; the caller should then store
+// Hash(password).
+func Verify(password, encoded string) (ok bool, needsRehash bool, err error) {
+	hasher := identify(encoded)
+	if hasher == nil {
+		return false, false, ErrUnknownHash
+	}
+	ok, err = hasher.Verify(password, encoded)
+	if err != nil || !ok {
+		return false, false, err
+	}
+	return true, hasher.Name() != Default.Name() || Default.NeedsRehash(encoded), nil
+}
+
+// IsHash reports whether value is a hash of a registered scheme.
+func IsHash(value string) bool {
+	return identify(value) != nil
+}
+
+func identify(encoded string) Hasher {
+	for _, hasher := range []Hasher{Default, Argon2id{Params: DefaultArgon2Params}, Bcrypt{Cost: DefaultBcryptCost}} {
+		if hasher.Identifies(encoded) {
+			return hasher
+		}
+	}
+	return nil
+}
+
+// Configure sets Default to the named scheme.
+func Configure(algorithm string, argon2Params Argon2Params, bcryptCost int) error {
+	switch algorithm {
+	case "", "argon2id":
+		if err := argon2Params.validate(); err != nil {
+			return err
+		}
+		Default = Argon2id{Params: argon2Params}
+	case "bcrypt":
+		if bcryptCost < MinBcryptCost || bcryptCost > MaxBcryptCost {
+			return fmt.Errorf("passwordhash: bcrypt cost must be between %d and %d", MinBcryptCost, MaxBcryptCost)
+		}
+		Default = Bcrypt{Cost: bcryptCost}
+	default:
+		return fmt.Errorf("passwordhash: unknown algorithm %q", algorithm)
+	}
+	return nil
+}
+
+// ConfigureFromEnv calls Configure with PASSWORD_HASH_ALGORITHM,
+// ARGON2_MEMORY_KIB, ARGON2_ITERATIONS, ARGON2_PARALLELISM and BCRYPT_COST,
+// using the defaults for unset variables.
+func ConfigureFromEnv() error {
+	params := DefaultArgon2Params
+	var err error
+	if params.MemoryKiB, err = envUint32("ARGON2_MEMORY_KIB", params.MemoryKiB); err != nil {
+		return err
+	}
+	if params.Iterations, err = envUint32("ARGON2_ITERATIONS", params.Iterations); err != nil {
+		return err
+	}
+	parallelism, err := envUint32("ARGON2_PARALLELISM", uint32(params.Parallelism))
+	if err != nil {
+		return err
+	}
+	if parallelism > 255 {
+		return errors.New("passwordhash: ARGON2_PARALLELISM must be at most 255")
+	}
+	params.Parallelism = uint8(parallelism)
+
+	cost, err := envUint32("BCRYPT_COST", uint32(DefaultBcryptCost))
+	if err != nil {
+		return err
+	}
+
+	return Configure(strings.ToLower(os.Getenv("PASSWORD_HASH_ALGORITHM")), params, int(cost))
+}
+
+func envUint32(key string, fallback uint32) (uint32, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("passwordhash: invalid %s: %w", key, err)
+	}
+	return uint32(parsed), nil
+}
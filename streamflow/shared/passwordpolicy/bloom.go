@@ -0,0 +1,61 @@
+package passwordpolicy
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// bloomFilter is a fixed-size probabilistic set: Test never returns false for
+// an added value, and returns true for other values with probability ~fpRate.
+type bloomFilter struct {
+	bits []uint64
+	m    uint64 // number of bits
+	k    uint64 // number of hash functions
+}
+
+// newBloomFilter sizes a filter for n entries at the given false-positive rate.
+func newBloomFilter(n int, fpRate float64) *bloomFilter {
+	if n < 1 {
+		n = 1
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return &bloomFilter{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// hashes derives two independent 64-bit hashes; the k probe positions are
+// h1 + i*h2 (Kirsch–Mitzenmacher double hashing).
+func (b *bloomFilter) hashes(value string) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	h1 := h.Sum64()
+	h.Write([]byte{0xff})
+	h2 := h.Sum64() | 1
+	return h1, h2
+}
+
+func (b *bloomFilter) Add(value string) {
+	h1, h2 := b.hashes(value)
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		b.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+func (b *bloomFilter) Test(value string) bool {
+	h1, h2 := b.hashes(value)
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		if b.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
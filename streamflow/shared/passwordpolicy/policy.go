@@ -0,0 +1,122 @@
+// Package passwordpolicy holds the password quality rules shared by the auth
+// and users services.
+package passwordpolicy
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Policy describes the rules a new password must satisfy.
+type Policy struct {
+	MinLength      int
+	MaxLength      int // bcrypt ignores bytes past 72
+	MinCharClasses int // of: minúsculas, mayúsculas, números, símbolos
+}
+
+// Default is the policy enforced at signup, password change and reset.
+var Default = Policy{
+	MinLength:      8,
+	MaxLength:      72,
+	MinCharClasses: 3,
+}
+
+// UserInfo is the personal data a password must not contain.
+type UserInfo struct {
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+//go:embed breached_passwords.txt
+var breachedPasswordsList string
+
+// breached is built once from the bundled list; ~0.1% false positives.
+var breached = loadBreached(breachedPasswordsList)
+
+func loadBreached(list string) *bloomFilter {
+	var entries []string
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			entries = append(entries, strings.ToLower(line))
+		}
+	}
+	filter := newBloomFilter(len(entries), 0.001)
+	for _, entry := range entries {
+		filter.Add(entry)
+	}
+	return filter
+}
+
+// Validate checks password against the default policy.
+func Validate(password string, user UserInfo) error {
+	return Default.Validate(password, user)
+}
+
+// Validate returns an error with a user-facing Spanish message for the first
+// rule the password breaks, or nil if it is acceptable.
+func (p Policy) Validate(password string, user UserInfo) error {
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Errorf("La contraseña debe tener al menos %d caracteres", p.MinLength)
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		return fmt.Errorf("La contraseña no puede superar los %d caracteres", p.MaxLength)
+	}
+	if countCharClasses(password) < p.MinCharClasses {
+		return fmt.Errorf("La contraseña debe combinar al menos %d de estos tipos de caracteres: minúsculas, mayúsculas, números y símbolos", p.MinCharClasses)
+	}
+
+	lower := strings.ToLower(password)
+	if local, _, _ := strings.Cut(strings.ToLower(user.Email), "@"); len(local) >= 3 && strings.Contains(lower, local) {
+		return errors.New("La contraseña no puede contener tu email")
+	}
+	for _, part := range strings.Fields(strings.ToLower(user.FirstName + " " + user.LastName)) {
+		if len([]rune(part)) >= 3 && strings.Contains(lower, part) {
+			return errors.New("La contraseña no puede contener tu nombre")
+		}
+	}
+
+	if IsBreached(password) {
+		return errors.New("La contraseña aparece en filtraciones de datos conocidas, elige otra")
+	}
+	return nil
+}
+
+// IsBreached reports whether password, or its base without trailing digits
+// and symbols ("Monkey2024!" -> "monkey"), is in the bundled breached list.
+func IsBreached(password string) bool {
+	lower := strings.ToLower(password)
+	if breached.Test(lower) {
+		return true
+	}
+	base := strings.TrimRightFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) })
+	return len([]rune(base)) >= 4 && base != lower && breached.Test(base)
+}
+
+func countCharClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			classes++
+		}
+	}
+	return classes
+}
@@ -0,0 +1,114 @@
+package passwordpolicy
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var testUser = UserInfo{Email: "ana.perez@example.com", FirstName: "Ana", LastName: "Pérez"}
+
+func TestValidateAcceptsStrongPassword(t *testing.T) {
+	if err := Validate("Tr4vesía-Lunar", testUser); err != nil {
+		t.Fatalf("expected a valid password, got %v", err)
+	}
+}
+
+func TestValidateLength(t *testing.T) {
+	cases := map[string]struct {
+		password string
+		wantErr  bool
+	}{
+		"too short":               {"Ab1!xyz", true},
+		"minimum length":          {"Ab1!wxyz", false},
+		"runes, not bytes, count": {"Ñú1!ñúñ", true},
+		"bcrypt limit":            {"Ab1!" + strings.Repeat("w", 68), false},
+		"over bcrypt limit":       {"Ab1!" + strings.Repeat("w", 69), true},
+	}
+	for name, c := range cases {
+		err := Validate(c.password, UserInfo{})
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: Validate(%q) = %v, wantErr %t", name, c.password, err, c.wantErr)
+		}
+	}
+}
+
+func TestValidateCharClasses(t *testing.T) {
+	for _, password := range []string{"solominusculas", "minusculas123", "MAYUSCULAS!!!"} {
+		if err := Validate(password, UserInfo{}); err == nil {
+			t.Errorf("Validate(%q): expected a character class error", password)
+		}
+	}
+}
+
+func TestValidateRejectsUserData(t *testing.T) {
+	cases := map[string]string{
+		"email local part": "Xx-Ana.Perez-9",
+		"first name":       "Zorzal-ANA-77",
+		"last name":        "Zorzal-pérez-77",
+	}
+	for name, password := range cases {
+		if err := Validate(password, testUser); err == nil {
+			t.Errorf("%s: Validate(%q) accepted a password with personal data", name, password)
+		}
+	}
+
+	// Parts shorter than 3 characters are too common to reject
+	short := UserInfo{Email: "al@example.com", FirstName: "Al", LastName: "Li"}
+	if err := Validate("Zorzal-Al-Li-77", short); err != nil {
+		t.Errorf("short name parts should be ignored, got %v", err)
+	}
+}
+
+func TestValidateRejectsBreachedPasswords(t *testing.T) {
+	for _, password := range []string{"Monkey2024!", "Dragon#1999", "Iloveyou-2020"} {
+		err := Validate(password, UserInfo{})
+		if err == nil || !strings.Contains(err.Error(), "filtraciones") {
+			t.Errorf("Validate(%q) = %v, want the breached password error", password, err)
+		}
+	}
+}
+
+// A Bloom filter may give false positives but never false negatives: every
+// bundled entry must be rejected, whatever its case.
+func TestEveryBreachedEntryIsRejected(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader(breachedPasswordsList))
+	entries := 0
+	for scanner.Scan() {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" {
+			continue
+		}
+		entries++
+		if !IsBreached(entry) || !IsBreached(strings.ToUpper(entry)) {
+			t.Errorf("IsBreached(%q) = false for a bundled entry", entry)
+		}
+	}
+	if entries == 0 {
+		t.Fatal("the bundled breached list is empty")
+	}
+}
+
+func TestBloomFilter(t *testing.T) {
+	const n = 5000
+	filter := newBloomFilter(n, 0.01)
+	for i := 0; i < n; i++ {
+		filter.Add(fmt.Sprintf("added-%d", i))
+	}
+	for i := 0; i < n; i++ {
+		if value := fmt.Sprintf("added-%d", i); !filter.Test(value) {
+			t.Fatalf("false negative for %q", value)
+		}
+	}
+
+	falsePositives := 0
+	for i := 0; i < n; i++ {
+		if filter.Test(fmt.Sprintf("other-%d", i)) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / n; rate > 0.03 {
+		t.Errorf("false positive rate %.3f, sized for 0.01", rate)
+	}
+}
@@ -8,7 +8,7 @@ describe('Auth Service E2E Tests', () => {
     // Create a test user for authentication tests
     testUser = await TestHelper.createTestUser({
       email: TestHelper.generateRandomEmail(),
-      password: 'TestPassword123!',
+      password: 'Clave-Segura1!',
       role: 'cliente',
       name: 'Auth Test User'
     });
@@ -18,7 +18,7 @@ describe('Auth Service E2E Tests', () => {
     test('should create new user successfully', async () => {
       const userData = {
         email: TestHelper.generateRandomEmail(),
-        password: 'NewUser123!',
+        password: 'Nueva-Cuenta2!',
         name: 'New Test User',
         role: 'cliente'
       };
@@ -156,7 +156,7 @@ describe('Auth Service E2E Tests', () => {
     test('should change password with valid token and data', async () => {
       const authClient = TestHelper.createAuthenticatedClient(userTokens.accessToken);
       
-      const newPassword = 'NewPassword123!';
+      const newPassword = 'Nueva-Clave3!';
       const response = await authClient.patch(`/auth/usuarios/${userTokens.user.id}`, {
         current_password: testUser.password,
         new_password: newPassword
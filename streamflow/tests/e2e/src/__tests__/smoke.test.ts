@@ -61,7 +61,7 @@ describe('Smoke Tests - Basic Service Health', () => {
     test('should be able to create new user (public endpoint)', async () => {
       const testUser = {
         email: TestHelper.generateRandomEmail(),
-        password: 'Test123!',
+        password: 'Clave-Segura1!',
         name: 'Test User',
         role: 'cliente'
       };
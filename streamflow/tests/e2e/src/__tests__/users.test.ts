@@ -15,7 +15,7 @@ describe('Users Service E2E Tests', () => {
     // Create a test user
     testUser = await TestHelper.createTestUser({
       email: TestHelper.generateRandomEmail(),
-      password: 'UserTest123!',
+      password: 'Clave-Segura1!',
       role: 'cliente',
       name: 'Users Test User'
     });
@@ -31,7 +31,7 @@ describe('Users Service E2E Tests', () => {
     test('should create a new user (admin only)', async () => {
       const userData = {
         email: TestHelper.generateRandomEmail(),
-        password: 'NewUser123!',
+        password: 'Nueva-Cuenta2!',
         name: 'Admin Created User',
         role: 'cliente'
       };
@@ -125,7 +125,7 @@ describe('Users Service E2E Tests', () => {
     test('should prevent regular users from creating users', async () => {
       const userData = {
         email: TestHelper.generateRandomEmail(),
-        password: 'NewUser123!',
+        password: 'Nueva-Cuenta2!',
         name: 'Unauthorized User',
         role: 'cliente'
       };
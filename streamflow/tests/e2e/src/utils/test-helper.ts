@@ -112,7 +112,7 @@ export class TestHelper {
   static async createTestUser(userData?: Partial<TestUser>): Promise<TestUser> {
     const user: TestUser = {
       email: `test-${uuidv4()}@streamflow.com`,
-      password: 'Test123!',
+      password: 'Clave-Segura1!',
       role: 'cliente',
       name: 'Test User',
       ...userData,